@@ -156,6 +156,60 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
+    "/v1/slos/{sloId}/evaluation-health": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                sloId: components["parameters"]["SloId"];
+            };
+            cookie?: never;
+        };
+        get: operations["getSLOEvaluationHealth"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/v1/slos/{sloId}/evaluations": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                sloId: components["parameters"]["SloId"];
+            };
+            cookie?: never;
+        };
+        get: operations["getSLOEvaluations"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/v1/slos/{sloId}/evaluate": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                sloId: components["parameters"]["SloId"];
+            };
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        post: operations["evaluateSLO"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
     "/v1/burn-events": {
         parameters: {
             query?: never;
@@ -172,6 +226,142 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
+    "/v1/maintenance-windows": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get: operations["listMaintenanceWindows"];
+        put?: never;
+        post: operations["createMaintenanceWindow"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/v1/maintenance-windows/{maintenanceWindowId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                maintenanceWindowId: components["parameters"]["MaintenanceWindowId"];
+            };
+            cookie?: never;
+        };
+        get: operations["getMaintenanceWindow"];
+        put: operations["updateMaintenanceWindow"];
+        post?: never;
+        delete: operations["deleteMaintenanceWindow"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/v1/outbox/dead-letters": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get: operations["listDeadLetterEvents"];
+        put?: never;
+        post?: never;
+        delete: operations["purgeDeadLetterEvents"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/v1/outbox/dead-letters/{outboxEventId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                outboxEventId: components["parameters"]["OutboxEventId"];
+            };
+            cookie?: never;
+        };
+        get: operations["getDeadLetterEvent"];
+        put?: never;
+        post?: never;
+        delete: operations["purgeDeadLetterEvent"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/v1/outbox/dead-letters/{outboxEventId}/requeue": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                outboxEventId: components["parameters"]["OutboxEventId"];
+            };
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        post: operations["requeueDeadLetterEvent"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/v1/events/schema": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get: operations["getEventSchema"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/v1/webhook-subscriptions": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get: operations["listWebhookSubscriptions"];
+        put?: never;
+        post: operations["createWebhookSubscription"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/v1/webhook-subscriptions/{webhookSubscriptionId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                webhookSubscriptionId: components["parameters"]["WebhookSubscriptionId"];
+            };
+            cookie?: never;
+        };
+        get: operations["getWebhookSubscription"];
+        put: operations["updateWebhookSubscription"];
+        post?: never;
+        delete: operations["deleteWebhookSubscription"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
 }
 export type webhooks = Record<string, never>;
 export interface components {
@@ -183,6 +373,155 @@ export interface components {
         ReadyResponse: {
             /** @enum {string} */
             status: "ready";
+            evaluation?: components["schemas"]["EvaluationSummary"];
+        };
+        EvaluationSummary: {
+            sloCount: number;
+            /** @description SLOs whose latest evaluation failed. */
+            failingCount: number;
+            /** @description SLOs not evaluated within the staleness threshold, including never-evaluated ones. */
+            staleCount: number;
+            /** Format: date-time */
+            lastEvaluatedAt?: string;
+        };
+        EvaluationHealth: {
+            /** Format: uuid */
+            sloId: string;
+            /** @enum {string} */
+            status: "pending" | "ok" | "failed";
+            /** Format: date-time */
+            lastAttemptAt?: string;
+            /** Format: date-time */
+            lastSuccessAt?: string;
+            /** Format: date-time */
+            lastErrorAt?: string;
+            lastError?: string;
+            /** @enum {string} */
+            lastErrorStage?: "backend" | "definition" | "query" | "persist";
+            consecutiveFailures: number;
+            lastDurationMs: number;
+            lastQueryDurationMs: number;
+        };
+        EvaluationSeries: {
+            /** Format: uuid */
+            sloId: string;
+            /** Format: date-time */
+            from: string;
+            /** Format: date-time */
+            to: string;
+            /** @description The range held more points than the limit; narrow it to see the rest. */
+            truncated: boolean;
+            points: components["schemas"]["EvaluationPoint"][];
+        };
+        EvaluationPoint: {
+            /** Format: date-time */
+            evaluatedAt: string;
+            /** @description Objective displayName; absent for an unnamed objective. */
+            objective?: string;
+            /** @description Dimension of an SLO with groupBy keys; absent otherwise. */
+            dimensionKey?: string;
+            severity: string;
+            burnTier: string;
+            /** Format: double */
+            compliance: number;
+            /** Format: double */
+            burnRate: number;
+            /** Format: double */
+            budgetRemaining: number;
+            /** Format: double */
+            events: number;
+            insufficientData: boolean;
+            etaExhaustionSeconds?: number;
+            queryDurationMs: number;
+            windows: components["schemas"]["EvaluationWindow"][];
+        };
+        EvaluationWindow: {
+            windowMinutes: number;
+            /** Format: double */
+            compliance: number;
+            /** Format: double */
+            events: number;
+        };
+        EvaluationExplanation: {
+            /** Format: uuid */
+            sloId: string;
+            /** Format: date-time */
+            evaluatedAt: string;
+            dryRun: boolean;
+            /** @description Objective explained; absent for an unnamed objective. */
+            objective?: string;
+            /** @description Every objective the evaluation covered, for SLOs with several objectives. */
+            objectives?: string[];
+            /** @description Dimension explained, for SLOs with groupBy keys. */
+            dimensionKey?: string;
+            dimensions?: {
+                [key: string]: string;
+            };
+            /** @description Every dimension the evaluation covered. */
+            dimensionKeys?: string[];
+            queries: components["schemas"]["EvaluationQuery"][];
+            windows: components["schemas"]["EvaluationWindowResult"][];
+            tiers: components["schemas"]["EvaluationTier"][];
+            /** @description Severity of the firing tier before flap damping and the minimum-traffic guard. */
+            rawSeverity: string;
+            severity: string;
+            burnTier: string;
+            /** Format: double */
+            compliance: number;
+            /** Format: double */
+            burnRate: number;
+            insufficientData: boolean;
+            /** Format: double */
+            budgetRemaining: number;
+            budgetExhausted: boolean;
+            etaExhaustionSeconds?: number;
+            /** @description A maintenance window covers the SLO, so no burn starts or continues. */
+            inMaintenance?: boolean;
+            action: components["schemas"]["EvaluationAction"];
+            /** @description Outbox events the evaluation enqueued, or would enqueue in a dry run. */
+            events: components["schemas"]["EvaluationEvent"][];
+            queryDurationMs: number;
+        };
+        EvaluationQuery: {
+            windowMinutes: number;
+            /** @description compliance for single-query SLIs, otherwise total, good or bad. */
+            name: string;
+            text: string;
+            /** @description Bound parameters for the query's placeholders, in order. */
+            args: unknown[];
+        };
+        EvaluationWindowResult: {
+            windowMinutes: number;
+            /** Format: double */
+            compliance: number;
+            /** Format: double */
+            events: number;
+            /** @description The window met the minimum event count; only sufficient windows have a burn rate. */
+            sufficient: boolean;
+            /** Format: double */
+            burnRate?: number;
+        };
+        EvaluationTier: {
+            name: string;
+            severity: string;
+            /** Format: double */
+            threshold: number;
+            /** Format: double */
+            resolveThreshold: number;
+            longWindowMinutes: number;
+            shortWindowMinutes?: number;
+            firing: boolean;
+        };
+        EvaluationAction: {
+            emitEvent: boolean;
+            eventType?: string;
+        };
+        EvaluationEvent: {
+            eventType: string;
+            idempotencyKey: string;
+            payload: {
+                [key: string]: unknown;
+            };
         };
         Problem: {
             /** Format: uri */
@@ -240,11 +579,85 @@ export interface components {
             windowMinutes: number;
             route: string;
             /** @enum {string} */
-            type: "latency" | "error_rate";
+            type: "latency" | "error_rate" | "ratio" | "composite";
             threshold: number;
             /** @enum {string} */
-            datasourceType: "clickhouse" | "prometheus";
+            datasourceType: "clickhouse" | "prometheus" | "composite";
+            /** @description Empty for composite SLOs, which have no datasource of their own. */
             datasourceUid: string;
+            datasourceUrl?: string;
+            goodQuery?: string;
+            badQuery?: string;
+            totalQuery?: string;
+            good?: components["schemas"]["SpanSelector"];
+            bad?: components["schemas"]["SpanSelector"];
+            total?: components["schemas"]["SpanSelector"];
+            serviceName?: string;
+            spanKind?: string;
+            spanName?: string;
+            attributes?: components["schemas"]["SpanAttributeMatcher"][];
+            /** @enum {string} */
+            budgetingMethod?: "Occurrences" | "Timeslices";
+            timeSliceMinutes?: number;
+            timeSliceQuantile?: number;
+            calendar?: components["schemas"]["CalendarWindow"];
+            burnRateTiers?: components["schemas"]["BurnRateTier"][];
+            /** @description Burn windows with fewer events are reported as insufficient data. */
+            minEvents?: number;
+            /** @description Timeslices with fewer events are not scored. */
+            minSliceEvents?: number;
+            /** @description Span attribute keys or Prometheus labels the SLO is evaluated per combination of. */
+            groupBy?: string[];
+            composite?: components["schemas"]["CompositeSLO"];
+            /** @description Every objective of the SLO in declared order; target and threshold mirror the first. */
+            objectives?: components["schemas"]["SLOObjective"][];
+        };
+        SLOObjective: {
+            /** @description The objective's displayName; empty for an unnamed objective. */
+            name: string;
+            target: number;
+            /** @description Threshold compared against, for ClickHouse latency and Timeslices indicators. */
+            threshold?: number;
+            /** @enum {string} */
+            op?: "lt" | "lte" | "gt" | "gte";
+        };
+        BurnRateTier: {
+            name: string;
+            severity: string;
+            /** Format: double */
+            threshold: number;
+            longWindowMinutes: number;
+            shortWindowMinutes: number;
+        };
+        CalendarWindow: {
+            /** @enum {string} */
+            unit: "week" | "month" | "quarter";
+            count: number;
+            startTime?: string;
+            timeZone: string;
+        };
+        CompositeSLO: {
+            /** @enum {string} */
+            aggregation: "weightedAverage" | "min" | "and";
+            components: components["schemas"]["CompositeComponent"][];
+        };
+        CompositeComponent: {
+            /** Format: uuid */
+            sloId: string;
+            weight: number;
+        };
+        SpanSelector: {
+            route?: string;
+            serviceName?: string;
+            spanKind?: string;
+            spanName?: string;
+            attributes?: components["schemas"]["SpanAttributeMatcher"][];
+        };
+        SpanAttributeMatcher: {
+            key: string;
+            /** @enum {string} */
+            op: "equals" | "not-equals" | "in" | "regex";
+            values: string[];
         };
         BurnEvent: {
             /** Format: uuid */
@@ -254,13 +667,18 @@ export interface components {
             /** Format: uuid */
             sloId: string;
             /** @enum {string} */
-            eventType: "burn_started" | "burn_continued" | "burn_resolved" | "error_budget_exhausted" | "error_budget_recovered";
+            eventType: "burn_started" | "burn_continued" | "burn_resolved" | "error_budget_exhausted" | "error_budget_recovered" | "insufficient_data" | "insufficient_data_resolved";
             value: number;
             threshold: number;
             /** Format: date-time */
             observedAt: string;
             source: string;
             idempotencyKey: string;
+            objective?: string;
+            dimensionKey?: string;
+            dimensions?: {
+                [key: string]: string;
+            };
         };
         AlertState: {
             /** Format: uuid */
@@ -285,6 +703,92 @@ export interface components {
             items: components["schemas"]["Team"][];
             page: components["schemas"]["Pagination"];
         };
+        MaintenanceWindowListResponse: {
+            items: components["schemas"]["MaintenanceWindow"][];
+            page: components["schemas"]["Pagination"];
+        };
+        DeliveryAttempt: {
+            attemptNo: number;
+            error: string;
+            /** Format: date-time */
+            attemptedAt: string;
+        };
+        DeadLetterEvent: {
+            /** Format: uuid */
+            id: string;
+            aggregateType: string;
+            /** Format: uuid */
+            aggregateId: string;
+            eventType: string;
+            payload: {
+                [key: string]: unknown;
+            };
+            /** @description Failed delivery attempts since the event was enqueued or last requeued. */
+            retryCount: number;
+            idempotencyKey: string;
+            lastError: string;
+            /** Format: date-time */
+            createdAt: string;
+            /** Format: date-time */
+            deadLetteredAt: string;
+            /** @description Every failed delivery attempt, oldest first. Only returned for a single event. */
+            attempts?: components["schemas"]["DeliveryAttempt"][];
+        };
+        DeadLetterEventListResponse: {
+            items: components["schemas"]["DeadLetterEvent"][];
+            page: components["schemas"]["Pagination"];
+        };
+        PurgeDeadLetterEventsResponse: {
+            purged: number;
+        };
+        WebhookScopeType: "team" | "service";
+        /** @description CloudEvents content mode of the requests. structured POSTs the whole event as application/cloudevents+json; binary sends the attributes as ce- headers and the data as the body. Defaults to structured. */
+        WebhookContentMode: "structured" | "binary";
+        WebhookSubscriptionRequest: {
+            name: string;
+            scopeType: components["schemas"]["WebhookScopeType"];
+            /**
+             * Format: uuid
+             * @description The team or service whose SLO events are pushed.
+             */
+            scopeId: string;
+            /**
+             * Format: uri
+             * @description http or https endpoint the events are POSTed to.
+             */
+            url: string;
+            /** @description HMAC-SHA256 signing key. Required on create; omitted on update keeps the current one. */
+            secret?: string;
+            /** @description Event types to push; empty or absent pushes all of them. */
+            eventTypes?: ("burn_started" | "burn_continued" | "burn_resolved" | "error_budget_exhausted" | "error_budget_recovered" | "insufficient_data" | "insufficient_data_resolved")[];
+            contentMode?: components["schemas"]["WebhookContentMode"];
+            /** @description Per-request timeout; defaults to 5000. */
+            timeoutMs?: number;
+            /** @description Defaults to true. */
+            enabled?: boolean;
+        };
+        WebhookSubscription: {
+            /** Format: uuid */
+            id: string;
+            name: string;
+            scopeType: components["schemas"]["WebhookScopeType"];
+            /** Format: uuid */
+            scopeId: string;
+            /** Format: uri */
+            url: string;
+            eventTypes: string[];
+            contentMode: components["schemas"]["WebhookContentMode"];
+            timeoutMs: number;
+            enabled: boolean;
+            /** Format: date-time */
+            createdAt: string;
+            /** Format: date-time */
+            updatedAt: string;
+        };
+        WebhookSubscriptionListResponse: {
+            items: components["schemas"]["WebhookSubscription"][];
+            page: components["schemas"]["Pagination"];
+        };
         ServiceListResponse: {
             items: components["schemas"]["Service"][];
             page: components["schemas"]["Pagination"];
@@ -308,6 +812,71 @@ export interface components {
             name: string;
             slug: string;
         };
+        MaintenanceScopeType: "team" | "service" | "slo";
+        MaintenanceRecurrence: {
+            /** @enum {string} */
+            frequency: "daily" | "weekly" | "monthly";
+            /**
+             * @description Repeat every this many days, weeks or months.
+             * @default 1
+             */
+            interval?: number;
+            /**
+             * Format: date-time
+             * @description No occurrence starts after this time; absent repeats forever.
+             */
+            until?: string;
+        };
+        MaintenanceWindowRequest: {
+            name: string;
+            description?: string;
+            scopeType: components["schemas"]["MaintenanceScopeType"];
+            /**
+             * Format: uuid
+             * @description The team, service or SLO the window applies to.
+             */
+            scopeId: string;
+            /**
+             * Format: date-time
+             * @description Start of the first occurrence.
+             */
+            startsAt: string;
+            /**
+             * Format: date-time
+             * @description End of the first occurrence; later occurrences last as long.
+             */
+            endsAt: string;
+            recurrence?: components["schemas"]["MaintenanceRecurrence"];
+            /** @description IANA time zone recurrences keep their wall-clock time in; defaults to UTC. */
+            timeZone?: string;
+            /**
+             * @description Leave the window's minutes out of error budget accounting.
+             * @default false
+             */
+            excludeFromBudget?: boolean;
+        };
+        MaintenanceWindow: {
+            /** Format: uuid */
+            id: string;
+            name: string;
+            description?: string;
+            scopeType: components["schemas"]["MaintenanceScopeType"];
+            /** Format: uuid */
+            scopeId: string;
+            /** Format: date-time */
+            startsAt: string;
+            /** Format: date-time */
+            endsAt: string;
+            recurrence?: components["schemas"]["MaintenanceRecurrence"];
+            timeZone: string;
+            excludeFromBudget: boolean;
+            /** @description Whether an occurrence is in effect now. */
+            active: boolean;
+            /** Format: date-time */
+            createdAt: string;
+            /** Format: date-time */
+            updatedAt: string;
+        };
         CreateServiceRequest: {
             name: string;
             slug: string;
@@ -350,6 +919,9 @@ export interface components {
         TeamId: string;
         ServiceId: string;
         SloId: string;
+        MaintenanceWindowId: string;
+        OutboxEventId: string;
+        WebhookSubscriptionId: string;
         Page: number;
         PageSize: number;
         IdempotencyKey: string;
@@ -798,14 +1370,96 @@ export interface operations {
             404: components["responses"]["ProblemResponse"];
         };
     };
-    listBurnEvents: {
+    getSLOEvaluationHealth: {
         parameters: {
-            query?: {
-                page?: components["parameters"]["Page"];
-                pageSize?: components["parameters"]["PageSize"];
-                serviceId?: string;
-                sloId?: string;
-                from?: string;
+            query?: never;
+            header?: never;
+            path: {
+                sloId: components["parameters"]["SloId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Latest evaluator outcome for an SLO. */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["EvaluationHealth"];
+                };
+            };
+            404: components["responses"]["ProblemResponse"];
+        };
+    };
+    getSLOEvaluations: {
+        parameters: {
+            query?: {
+                from?: string;
+                to?: string;
+                limit?: number;
+            };
+            header?: never;
+            path: {
+                sloId: components["parameters"]["SloId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Evaluation series for the time range. */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["EvaluationSeries"];
+                };
+            };
+            400: components["responses"]["ProblemResponse"];
+            404: components["responses"]["ProblemResponse"];
+            503: components["responses"]["ProblemResponse"];
+        };
+    };
+    evaluateSLO: {
+        parameters: {
+            query?: {
+                dryRun?: boolean;
+                objective?: string;
+                dimension?: string;
+            };
+            header?: never;
+            path: {
+                sloId: components["parameters"]["SloId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Evaluation result with the queries, burn rates and decision behind it. */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["EvaluationExplanation"];
+                };
+            };
+            404: components["responses"]["ProblemResponse"];
+            422: components["responses"]["ProblemResponse"];
+            502: components["responses"]["ProblemResponse"];
+            503: components["responses"]["ProblemResponse"];
+        };
+    };
+    listBurnEvents: {
+        parameters: {
+            query?: {
+                page?: components["parameters"]["Page"];
+                pageSize?: components["parameters"]["PageSize"];
+                serviceId?: string;
+                sloId?: string;
+                from?: string;
                 to?: string;
             };
             header?: never;
@@ -825,4 +1479,380 @@ export interface operations {
             };
         };
     };
+    listMaintenanceWindows: {
+        parameters: {
+            query?: {
+                page?: components["parameters"]["Page"];
+                pageSize?: components["parameters"]["PageSize"];
+                scopeType?: components["schemas"]["MaintenanceScopeType"];
+                scopeId?: string;
+            };
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Paginated maintenance windows, latest start first. */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["MaintenanceWindowListResponse"];
+                };
+            };
+            400: components["responses"]["ProblemResponse"];
+        };
+    };
+    createMaintenanceWindow: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["MaintenanceWindowRequest"];
+            };
+        };
+        responses: {
+            /** @description Maintenance window created. */
+            201: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["MaintenanceWindow"];
+                };
+            };
+            400: components["responses"]["ProblemResponse"];
+        };
+    };
+    getMaintenanceWindow: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                maintenanceWindowId: components["parameters"]["MaintenanceWindowId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Maintenance window found. */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["MaintenanceWindow"];
+                };
+            };
+            404: components["responses"]["ProblemResponse"];
+        };
+    };
+    updateMaintenanceWindow: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                maintenanceWindowId: components["parameters"]["MaintenanceWindowId"];
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["MaintenanceWindowRequest"];
+            };
+        };
+        responses: {
+            /** @description Maintenance window updated. */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["MaintenanceWindow"];
+                };
+            };
+            400: components["responses"]["ProblemResponse"];
+            404: components["responses"]["ProblemResponse"];
+        };
+    };
+    deleteMaintenanceWindow: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                maintenanceWindowId: components["parameters"]["MaintenanceWindowId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Maintenance window deleted. */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            404: components["responses"]["ProblemResponse"];
+        };
+    };
+    listDeadLetterEvents: {
+        parameters: {
+            query?: {
+                page?: components["parameters"]["Page"];
+                pageSize?: components["parameters"]["PageSize"];
+            };
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Paginated dead-lettered outbox events, most recently dead-lettered first. */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["DeadLetterEventListResponse"];
+                };
+            };
+        };
+    };
+    purgeDeadLetterEvents: {
+        parameters: {
+            query?: {
+                before?: string;
+            };
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Dead-lettered events purged. */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["PurgeDeadLetterEventsResponse"];
+                };
+            };
+        };
+    };
+    getDeadLetterEvent: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                outboxEventId: components["parameters"]["OutboxEventId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Dead-lettered event with its delivery attempts. */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["DeadLetterEvent"];
+                };
+            };
+            404: components["responses"]["ProblemResponse"];
+        };
+    };
+    purgeDeadLetterEvent: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                outboxEventId: components["parameters"]["OutboxEventId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Dead-lettered event purged. */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            404: components["responses"]["ProblemResponse"];
+        };
+    };
+    requeueDeadLetterEvent: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                outboxEventId: components["parameters"]["OutboxEventId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Event is pending again with a fresh retry budget. */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            404: components["responses"]["ProblemResponse"];
+        };
+    };
+    getEventSchema: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description JSON Schema of the CloudEvents the control plane emits, in structured content mode. */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/schema+json": Record<string, never>;
+                };
+            };
+        };
+    };
+    listWebhookSubscriptions: {
+        parameters: {
+            query?: {
+                page?: components["parameters"]["Page"];
+                pageSize?: components["parameters"]["PageSize"];
+                scopeType?: components["schemas"]["WebhookScopeType"];
+                scopeId?: string;
+            };
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Paginated webhook subscriptions, newest first. */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["WebhookSubscriptionListResponse"];
+                };
+            };
+            400: components["responses"]["ProblemResponse"];
+        };
+    };
+    createWebhookSubscription: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["WebhookSubscriptionRequest"];
+            };
+        };
+        responses: {
+            /** @description Webhook subscription created. */
+            201: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["WebhookSubscription"];
+                };
+            };
+            400: components["responses"]["ProblemResponse"];
+        };
+    };
+    getWebhookSubscription: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                webhookSubscriptionId: components["parameters"]["WebhookSubscriptionId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Webhook subscription found. */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["WebhookSubscription"];
+                };
+            };
+            404: components["responses"]["ProblemResponse"];
+        };
+    };
+    updateWebhookSubscription: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                webhookSubscriptionId: components["parameters"]["WebhookSubscriptionId"];
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["WebhookSubscriptionRequest"];
+            };
+        };
+        responses: {
+            /** @description Webhook subscription updated. */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["WebhookSubscription"];
+                };
+            };
+            400: components["responses"]["ProblemResponse"];
+            404: components["responses"]["ProblemResponse"];
+        };
+    };
+    deleteWebhookSubscription: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                webhookSubscriptionId: components["parameters"]["WebhookSubscriptionId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Webhook subscription deleted. */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            404: components["responses"]["ProblemResponse"];
+        };
+    };
 }
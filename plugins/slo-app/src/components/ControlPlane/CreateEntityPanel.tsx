@@ -96,6 +96,7 @@ export function CreateEntityPanel({ apiUrl, teams, services, onRefresh }: Props)
         '          type: clickhouse',
         '          spec:',
         `            route: ${sloRoute}`,
+        '            serviceName: api-gateway',
         `            type: ${sloType}`,
         `            threshold: ${sloThreshold}`,
         `            datasourceUid: ${sloDatasourceUid}`,
@@ -253,6 +254,7 @@ export function CreateEntityPanel({ apiUrl, teams, services, onRefresh }: Props)
                         '          type: clickhouse',
                         '          spec:',
                         `            route: ${sloRoute}`,
+                        '            serviceName: api-gateway',
                         `            type: ${sloType}`,
                         `            threshold: ${sloThreshold}`,
                         `            datasourceUid: ${sloDatasourceUid}`,
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/go-chi/chi/v5"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -16,12 +17,17 @@ import (
 	apiv1 "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/api"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/burn"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/config"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/evaluator"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/grafana"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/history"
 	httpapi "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/http"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/leader"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/outbox"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/reconciler"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/telemetry"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/webhook"
 )
 
 func main() {
@@ -69,20 +75,57 @@ func main() {
 		log.Fatalf("ensure clickhouse table: %v", err)
 	}
 
+	backends, err := sli.NewDefaultRegistry(cfg.ClickHouseDSN, cfg.PrometheusURL, cfg.PrometheusHTTPTimeout)
+	if err != nil {
+		log.Fatalf("sli backends: %v", err)
+	}
 	st := store.New(db)
-	server := httpapi.NewServer(st)
-	worker := outbox.NewWorker(st, burnSink, cfg.OutboxPollInterval, cfg.OutboxBatchSize)
+	// On-demand evaluations share the evaluator's settings and history but not its lease.
+	evCfg := evaluator.ConfigFrom(cfg)
+	evCfg.Maintenance = st
+	var historyReader httpapi.HistoryReader
+	if cfg.EvaluationHistoryRetention > 0 {
+		hs, err := history.New(cfg.ClickHouseDSN, cfg.EvaluationHistoryRetention)
+		if err != nil {
+			log.Fatalf("evaluation history: %v", err)
+		}
+		defer hs.Close()
+		historyReader = hs
+		evCfg.History = hs
+	}
+	ev := evaluator.New(st, backends, evCfg)
+	defer ev.Close()
+	server := httpapi.NewServer(st, httpapi.Config{
+		EvaluationStaleAfter: 3 * cfg.EvaluatorInterval,
+		History:              historyReader,
+		Evaluator:            ev,
+		WebhookAllowedHosts:  cfg.WebhookAllowedHosts,
+	})
+	outboxWorkerID := cfg.OutboxWorkerID
+	if outboxWorkerID == "" {
+		outboxWorkerID = leader.DefaultHolderID()
+	}
+	worker := outbox.NewWorker(st, burnSink, webhook.NewClient(), outbox.Config{
+		PollInterval:   cfg.OutboxPollInterval,
+		BatchSize:      cfg.OutboxBatchSize,
+		WorkerID:       outboxWorkerID,
+		ClaimTTL:       cfg.OutboxClaimTTL,
+		MaxRetries:     cfg.OutboxMaxRetries,
+		RetryBaseDelay: cfg.OutboxRetryBaseDelay,
+		RetryMaxDelay:  cfg.OutboxRetryMaxDelay,
+	})
 	go worker.Run(ctx)
 	if cfg.GrafanaURL != "" {
 		grafanaClient := grafana.NewClient(cfg.GrafanaURL, cfg.GrafanaToken, cfg.GrafanaHTTPTimeout)
 		alertWorker := reconciler.NewWorker(st, grafanaClient, reconciler.Config{
-			PollInterval:       cfg.AlertReconcilerPollInterval,
-			BatchSize:          cfg.AlertReconcilerBatchSize,
-			FolderUID:          cfg.GrafanaFolderUID,
-			GroupPrefix:        "slo",
-			RuleIntervalSecond: 60,
-			DefaultLabels:      cfg.AlertDefaultLabels,
-			DefaultAnnotations: cfg.AlertDefaultAnnotations,
+			PollInterval:        cfg.AlertReconcilerPollInterval,
+			BatchSize:           cfg.AlertReconcilerBatchSize,
+			FolderUID:           cfg.GrafanaFolderUID,
+			GroupPrefix:         "slo",
+			RuleIntervalSecond:  60,
+			DefaultLabels:       cfg.AlertDefaultLabels,
+			DefaultAnnotations:  cfg.AlertDefaultAnnotations,
+			EventsDatasourceUID: cfg.GrafanaEventsDatasourceUID,
 		})
 		go alertWorker.Run(ctx)
 	}
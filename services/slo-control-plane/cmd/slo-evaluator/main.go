@@ -5,19 +5,29 @@ import (
 	"database/sql"
 	"flag"
 	"log"
+	"os"
 	"os/signal"
 	"path/filepath"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/config"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/evaluator"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/history"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/leader"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/shard"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		runReplay(os.Args[2:])
+		return
+	}
 	once := flag.Bool("once", false, "run a single evaluation pass and exit")
 	flag.Parse()
 
@@ -42,21 +52,69 @@ func main() {
 		log.Fatalf("run migrations: %v", err)
 	}
 
-	st := store.New(db)
-	ev, err := evaluator.New(st, cfg.ClickHouseDSN, evaluator.Config{
-		Interval:              cfg.EvaluatorInterval,
-		ContinueInterval:      cfg.EvaluatorContinueInterval,
-		FastWindowMinutes:     cfg.EvaluatorFastWindowMin,
-		SlowWindowMinutes:     cfg.EvaluatorSlowWindowMin,
-		FastBurnRateThreshold: cfg.EvaluatorFastBurnRate,
-		SlowBurnRateThreshold: cfg.EvaluatorSlowBurnRate,
-	})
+	backends, err := sli.NewDefaultRegistry(cfg.ClickHouseDSN, cfg.PrometheusURL, cfg.PrometheusHTTPTimeout)
 	if err != nil {
-		log.Fatalf("evaluator init: %v", err)
+		log.Fatalf("sli backends: %v", err)
+	}
+
+	var evalHistory evaluator.HistoryWriter
+	if cfg.EvaluationHistoryRetention > 0 {
+		hs, err := history.New(cfg.ClickHouseDSN, cfg.EvaluationHistoryRetention)
+		if err != nil {
+			log.Fatalf("evaluation history: %v", err)
+		}
+		defer hs.Close()
+		if err := hs.EnsureTable(ctx); err != nil {
+			log.Fatalf("ensure evaluation history table: %v", err)
+		}
+		evalHistory = hs
 	}
+
+	st := store.New(db)
+	instanceID := cfg.EvaluatorInstanceID
+	if instanceID == "" {
+		instanceID = leader.DefaultHolderID()
+	}
+	// Sharding spreads SLOs over every live replica; otherwise one elected leader evaluates them all.
+	var elector *leader.Elector
+	var leaderCheck evaluator.LeaderCheck
+	var membership *shard.Membership
+	var shardFilter evaluator.ShardFilter
+	switch {
+	case cfg.EvaluatorSharding:
+		membership = shard.NewMembership(st, shard.Config{
+			MemberID:          instanceID,
+			TTL:               cfg.EvaluatorLeaseTTL,
+			HeartbeatInterval: cfg.EvaluatorLeaseRenewInterval,
+		})
+		shardFilter = membership
+	case cfg.EvaluatorLeaderElection:
+		elector = leader.New(st, leader.Config{
+			HolderID:      instanceID,
+			TTL:           cfg.EvaluatorLeaseTTL,
+			RenewInterval: cfg.EvaluatorLeaseRenewInterval,
+		})
+		leaderCheck = elector
+	}
+	evCfg := evaluator.ConfigFrom(cfg)
+	evCfg.Leader = leaderCheck
+	evCfg.Shard = shardFilter
+	evCfg.History = evalHistory
+	evCfg.Maintenance = st
+	ev := evaluator.New(st, backends, evCfg)
 	defer ev.Close()
 
 	if *once {
+		if elector != nil {
+			if !elector.Tick(ctx) {
+				log.Printf("slo-evaluator skipped single pass: lease held by another replica")
+				return
+			}
+			defer elector.Release(context.Background())
+		}
+		if membership != nil {
+			membership.Tick(ctx)
+		}
 		if err := ev.EvaluateOnce(ctx); err != nil {
 			log.Fatalf("evaluate once: %v", err)
 		}
@@ -65,14 +123,34 @@ func main() {
 	}
 
 	log.Printf(
-		"slo-evaluator running interval=%s continue_interval=%s fast_window=%dm slow_window=%dm fast_rate=%.2f slow_rate=%.2f",
+		"slo-evaluator running interval=%s concurrency=%d slo_timeout=%s continue_interval=%s fast_window=%dm slow_window=%dm fast_rate=%.2f slow_rate=%.2f",
 		cfg.EvaluatorInterval,
+		cfg.EvaluatorConcurrency,
+		cfg.EvaluatorSLOTimeout,
 		cfg.EvaluatorContinueInterval,
 		cfg.EvaluatorFastWindowMin,
 		cfg.EvaluatorSlowWindowMin,
 		cfg.EvaluatorFastBurnRate,
 		cfg.EvaluatorSlowBurnRate,
 	)
+	if elector != nil {
+		log.Printf("slo-evaluator leader election enabled holder=%s lease_ttl=%s renew_interval=%s", elector.HolderID(), cfg.EvaluatorLeaseTTL, cfg.EvaluatorLeaseRenewInterval)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			elector.Run(ctx)
+		}()
+		defer func() { <-done }()
+	}
+	if membership != nil {
+		log.Printf("slo-evaluator sharding enabled member=%s heartbeat_ttl=%s heartbeat_interval=%s", membership.MemberID(), cfg.EvaluatorLeaseTTL, cfg.EvaluatorLeaseRenewInterval)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			membership.Run(ctx)
+		}()
+		defer func() { <-done }()
+	}
 	ev.Run(ctx)
 	log.Printf("slo-evaluator stopped")
 	time.Sleep(100 * time.Millisecond)
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	_ "time/tzdata"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 
@@ -131,6 +132,8 @@ func buildLegacyOpenSLO(slo store.SLO) (string, error) {
 		return "", fmt.Errorf("missing route/type/threshold in legacy canonical")
 	}
 	ux := stringFromAny(slo.Canonical["userExperience"])
+	// Legacy trace SLIs always measured the gateway's spans; spec.service names no real service.
+	serviceName := firstNonEmpty(stringFromAny(slo.Canonical["serviceName"]), "api-gateway")
 
 	return fmt.Sprintf(`apiVersion: openslo/v1
 kind: SLO
@@ -157,11 +160,12 @@ spec:
           type: %s
           spec:
             route: %s
+            serviceName: %s
             type: %s
             threshold: %g
             datasourceUid: %s
             datasourceType: %s
-`, name, yamlSafe(display), yamlSafe(firstNonEmpty(ux, display)), yamlSafe(desc), target, window, name, yamlSafe(dsType), yamlSafe(route), yamlSafe(sloType), threshold, yamlSafe(dsUID), yamlSafe(dsType)), nil
+`, name, yamlSafe(display), yamlSafe(firstNonEmpty(ux, display)), yamlSafe(desc), target, window, name, yamlSafe(dsType), yamlSafe(route), yamlSafe(serviceName), yamlSafe(sloType), threshold, yamlSafe(dsUID), yamlSafe(dsType)), nil
 }
 
 func sanitizeName(s string) string {
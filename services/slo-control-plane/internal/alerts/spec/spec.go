@@ -6,12 +6,17 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/google/uuid"
 	openslov1 "github.com/thisisibrahimd/openslo-go/pkg/openslo/v1"
 
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/grafana"
 	opensloparser "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/openslo"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
 )
 
@@ -20,6 +25,10 @@ type BuildOptions struct {
 	GroupPrefix        string
 	DefaultLabels      map[string]string
 	DefaultAnnotations map[string]string
+	// EventsDatasourceUID is the ClickHouse datasource holding slo_burn_events; used when the SLO itself is not on ClickHouse.
+	EventsDatasourceUID string
+	// Paused builds the rules paused, while the SLO is in a maintenance window.
+	Paused bool
 }
 
 type DesiredRuleSpec struct {
@@ -40,9 +49,14 @@ type alertConfig struct {
 }
 
 func BuildDesiredRules(in store.SLOReconcileInput, opts BuildOptions) ([]DesiredRuleSpec, error) {
-	if in.DatasourceUID == "" {
+	// Composite SLOs have no datasource of their own; their events live in the events datasource.
+	if in.DatasourceUID == "" && in.DatasourceType != opensloparser.DatasourceComposite {
 		return nil, fmt.Errorf("slo %s has empty datasource uid", in.ID)
 	}
+	eventsUID := eventsDatasourceUID(in, opts)
+	if eventsUID == "" {
+		return nil, fmt.Errorf("slo %s uses datasource type %q and no events datasource uid is configured", in.ID, in.DatasourceType)
+	}
 	configs, err := alertsFromOpenSLO(in.OpenSLO)
 	if err != nil {
 		return nil, err
@@ -50,6 +64,8 @@ func BuildDesiredRules(in store.SLOReconcileInput, opts BuildOptions) ([]Desired
 	if len(configs) == 0 {
 		return []DesiredRuleSpec{}, nil
 	}
+	rt := opensloparser.MapToRuntime(in.Canonical)
+	groupBy, perObjective := rt.GroupBy, len(rt.Objectives) > 1
 	group := buildGroupName(opts.GroupPrefix, in.ID.String())
 	baseLabels := mergeLabels(opts.DefaultLabels, alertingStringMap(in.ServiceMetadata, "labels"))
 	baseAnnotations := mergeLabels(opts.DefaultAnnotations, alertingStringMap(in.ServiceMetadata, "annotations"))
@@ -64,18 +80,23 @@ func BuildDesiredRules(in store.SLOReconcileInput, opts BuildOptions) ([]Desired
 			labels["severity"] = cfg.Severity
 		}
 		labels["alert_condition"] = cfg.Name
+		query, err := buildConditionQuery(in.ID, groupBy, perObjective, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("slo %s alert condition %q: %w", in.ID, cfg.Name, err)
+		}
 		rule := grafana.ProvisionedAlertRule{
 			Uid:       buildRuleUID(in.ID.String(), cfg.AlertKind+"-"+cfg.Name),
 			Title:     fmt.Sprintf("SLO %s: %s", strings.Title(cfg.AlertKind), in.Name),
 			Condition: "A",
 			Data: []map[string]any{
-				clickhouseQuery("A", in.DatasourceUID, buildConditionQuery(in.ID.String(), cfg)),
+				clickhouseQuery("A", eventsUID, query),
 			},
 			For:          cfg.For,
 			NoDataState:  "NoData",
 			ExecErrState: "Alerting",
 			Labels:       labels,
 			Annotations:  baseAnnotations,
+			IsPaused:     opts.Paused,
 		}
 		h, err := stableRuleHash(group, rule)
 		if err != nil {
@@ -92,15 +113,38 @@ func BuildDesiredRules(in store.SLOReconcileInput, opts BuildOptions) ([]Desired
 	return out, nil
 }
 
-func buildConditionQuery(sloID string, cfg alertConfig) string {
+func eventsDatasourceUID(in store.SLOReconcileInput, opts BuildOptions) string {
+	switch strings.ToLower(strings.TrimSpace(in.DatasourceType)) {
+	case "", "clickhouse":
+		return in.DatasourceUID
+	default:
+		return strings.TrimSpace(opts.EventsDatasourceUID)
+	}
+}
+
+// buildConditionQuery renders Grafana rule SQL, which cannot carry bound parameters. Every
+// interpolated value is therefore typed: a UUID, an operator from a fixed set, a finite number and,
+// for grouped SLOs, validated attribute keys. Objective names are only ever grouped on.
+// Backfilled events (burn.ReplaySource) are excluded so a replay never changes alert state.
+// Insufficient-data events are excluded too: they leave the burn and breach state as it was, so
+// the newest of the other events still decides the alert.
+func buildConditionQuery(sloID uuid.UUID, groupBy []string, perObjective bool, cfg alertConfig) (string, error) {
+	if cfg.AlertKind != store.AlertKindBreach && (math.IsNaN(cfg.Threshold) || math.IsInf(cfg.Threshold, 0)) {
+		return "", fmt.Errorf("threshold must be a finite number")
+	}
+	if len(groupBy) > 0 || perObjective {
+		return buildSeriesConditionQuery(sloID, groupBy, perObjective, cfg)
+	}
 	if cfg.AlertKind == store.AlertKindBreach {
 		return fmt.Sprintf(`SELECT now() AS time, count() AS active_breaches
 FROM (
   SELECT argMax(event_type, observed_at) AS last_event_type
   FROM slo_burn_events
   WHERE slo_id = '%s'
+    AND NOT startsWith(source, 'slo-replay:')
+    AND event_type NOT IN ('insufficient_data', 'insufficient_data_resolved')
 )
-WHERE last_event_type = 'error_budget_exhausted'`, sloID)
+WHERE last_event_type = 'error_budget_exhausted'`, sloID.String()), nil
 	}
 	return fmt.Sprintf(`SELECT now() AS time, count() AS active_burns
 FROM (
@@ -109,11 +153,79 @@ FROM (
     argMax(value, observed_at) AS last_burn_rate
   FROM slo_burn_events
   WHERE slo_id = '%s'
+    AND NOT startsWith(source, 'slo-replay:')
+    AND event_type NOT IN ('insufficient_data', 'insufficient_data_resolved')
 )
 WHERE last_event_type IN ('burn_started', 'burn_continued', 'error_budget_exhausted')
-  AND last_burn_rate %s %g`, sloID, sqlOp(cfg.Op), cfg.Threshold)
+  AND last_burn_rate %s %s`, sloID.String(), sqlOp(cfg.Op), strconv.FormatFloat(cfg.Threshold, 'g', -1, 64)), nil
 }
 
+// buildSeriesConditionQuery returns one row per objective of an SLO with several objectives and per
+// dimension of a grouped SLO, valued 1 while it alerts and 0 otherwise. Grafana turns the string
+// columns into labels, so each row is its own alert instance labelled with objective,
+// dimension_key and one label per group-by key.
+func buildSeriesConditionQuery(sloID uuid.UUID, groupBy []string, perObjective bool, cfg alertConfig) (string, error) {
+	labels, err := dimensionLabels(groupBy)
+	if err != nil {
+		return "", err
+	}
+	var keys []string
+	if perObjective {
+		keys = append(keys, "objective")
+	}
+	if len(groupBy) > 0 {
+		keys = append(keys, "dimension_key")
+	}
+	series := strings.Join(keys, ", ")
+	filter := ""
+	if len(groupBy) > 0 {
+		filter = "\n    AND dimension_key != ''"
+	}
+	var cols, outer strings.Builder
+	for i, key := range groupBy {
+		fmt.Fprintf(&cols, "    any(dimensions['%s']) AS %s,\n", key, labels[i])
+		fmt.Fprintf(&outer, "%s, ", labels[i])
+	}
+	value := "toUInt8(last_event_type = 'error_budget_exhausted') AS active_breaches"
+	if cfg.AlertKind != store.AlertKindBreach {
+		value = fmt.Sprintf("toUInt8(last_event_type IN ('burn_started', 'burn_continued', 'error_budget_exhausted') AND last_burn_rate %s %s) AS active_burns",
+			sqlOp(cfg.Op), strconv.FormatFloat(cfg.Threshold, 'g', -1, 64))
+	}
+	return fmt.Sprintf(`SELECT now() AS time, %s, %s%s
+FROM (
+  SELECT
+    %s,
+%s    argMax(event_type, observed_at) AS last_event_type,
+    argMax(value, observed_at) AS last_burn_rate
+  FROM slo_burn_events
+  WHERE slo_id = '%s'%s
+    AND NOT startsWith(source, 'slo-replay:')
+    AND event_type NOT IN ('insufficient_data', 'insufficient_data_resolved')
+  GROUP BY %s
+)`, series, outer.String(), value, series, cols.String(), sloID.String(), filter, series), nil
+}
+
+// dimensionLabels maps group-by keys to alert label names, which allow only letters, digits and
+// underscores.
+func dimensionLabels(groupBy []string) ([]string, error) {
+	reserved := map[string]bool{"time": true, "objective": true, "dimension_key": true, "last_event_type": true, "last_burn_rate": true, "active_burns": true, "active_breaches": true}
+	out := make([]string, 0, len(groupBy))
+	for _, key := range groupBy {
+		if err := sli.ValidateAttributeKey(key); err != nil {
+			return nil, err
+		}
+		label := labelNamePattern.ReplaceAllString(key, "_")
+		if reserved[label] {
+			return nil, fmt.Errorf("groupBy key %q maps to alert label %q, which is already used", key, label)
+		}
+		reserved[label] = true
+		out = append(out, label)
+	}
+	return out, nil
+}
+
+var labelNamePattern = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
 func clickhouseQuery(refID, datasourceUID, sqlExpr string) map[string]any {
 	return map[string]any{
 		"refId": refID,
@@ -1,6 +1,8 @@
 package spec
 
 import (
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -194,3 +196,182 @@ spec:
 		t.Fatalf("expected metadata override label, got %q", specs[0].Rule.Labels["team"])
 	}
 }
+
+func TestBuildDesiredRulesPrometheusSLOQueriesEventsDatasource(t *testing.T) {
+	in := store.SLOReconcileInput{
+		SLO: store.SLO{
+			ID:             uuid.New(),
+			ServiceID:      uuid.New(),
+			Name:           "Checkout Availability",
+			DatasourceType: "prometheus",
+			DatasourceUID:  "prometheus",
+			OpenSLO: `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: checkout-availability
+spec:
+  service: api-gateway
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.99
+  indicator:
+    metadata:
+      name: checkout-indicator
+    spec:
+      thresholdMetric:
+        metricSource:
+          type: prometheus
+          spec:
+            type: error_rate
+            goodQuery: sum(rate(http_requests_total{code!~"5.."}[{{.window}}]))
+            totalQuery: sum(rate(http_requests_total[{{.window}}]))
+            datasourceUid: prometheus
+            datasourceType: prometheus
+---
+apiVersion: openslo/v1
+kind: AlertCondition
+metadata:
+  name: checkout-burn
+spec:
+  severity: page
+  condition:
+    kind: burnrate
+    op: gte
+    threshold: 2
+    alertAfter: 2m
+`,
+		},
+	}
+	if _, err := BuildDesiredRules(in, BuildOptions{FolderUID: "slo-folder"}); err == nil {
+		t.Fatalf("expected error without events datasource uid")
+	}
+	specs, err := BuildDesiredRules(in, BuildOptions{FolderUID: "slo-folder", EventsDatasourceUID: "clickhouse"})
+	if err != nil {
+		t.Fatalf("BuildDesiredRules() error = %v", err)
+	}
+	if len(specs) != 1 {
+		t.Fatalf("expected one rule spec, got %d", len(specs))
+	}
+	if got := specs[0].Rule.Data[0]["datasourceUid"]; got != "clickhouse" {
+		t.Fatalf("expected burn events to be queried from clickhouse, got %v", got)
+	}
+}
+
+func TestBuildDesiredRulesCompositeSLOUsesEventsDatasource(t *testing.T) {
+	in := store.SLOReconcileInput{
+		SLO: store.SLO{
+			ID:             uuid.New(),
+			ServiceID:      uuid.New(),
+			Name:           "Place Order",
+			DatasourceType: "composite",
+			OpenSLO: `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: place-order
+spec:
+  service: checkout
+  objectives:
+    - target: 0.99
+  composite:
+    components:
+      - sloId: 5f0b2c7e-2a54-4f55-9d47-8f1d9f7a1c01
+---
+apiVersion: openslo/v1
+kind: AlertCondition
+metadata:
+  name: place-order-burn
+spec:
+  severity: page
+  condition:
+    kind: burnrate
+    op: gte
+    threshold: 2
+`,
+		},
+	}
+	specs, err := BuildDesiredRules(in, BuildOptions{FolderUID: "slo-folder", EventsDatasourceUID: "clickhouse"})
+	if err != nil {
+		t.Fatalf("BuildDesiredRules() error = %v", err)
+	}
+	if len(specs) != 1 || specs[0].Rule.Data[0]["datasourceUid"] != "clickhouse" {
+		t.Fatalf("expected one rule on the events datasource, got %+v", specs)
+	}
+}
+
+func TestBuildConditionQueryOnlyInterpolatesTypedValues(t *testing.T) {
+	sloID := uuid.MustParse("6f1f7f3e-9a53-4b43-9c6b-2f4a3d5e6a7b")
+	q, err := buildConditionQuery(sloID, nil, false, alertConfig{AlertKind: store.AlertKindBurn, Op: "gte' OR 1=1 --", Threshold: 14.4})
+	if err != nil {
+		t.Fatalf("buildConditionQuery() error = %v", err)
+	}
+	if !strings.Contains(q, "WHERE slo_id = '6f1f7f3e-9a53-4b43-9c6b-2f4a3d5e6a7b'") || !strings.Contains(q, "last_burn_rate >= 14.4") {
+		t.Fatalf("unexpected burn condition sql: %s", q)
+	}
+	if strings.Contains(q, "OR 1=1") {
+		t.Fatalf("hostile operator leaked into sql: %s", q)
+	}
+	if !strings.Contains(q, "NOT startsWith(source, 'slo-replay:')") {
+		t.Fatalf("expected replayed events to be excluded: %s", q)
+	}
+	for _, series := range [][]string{nil, {"region"}} {
+		for _, kind := range []string{store.AlertKindBurn, store.AlertKindBreach} {
+			q, err := buildConditionQuery(sloID, series, false, alertConfig{AlertKind: kind, Op: "gte", Threshold: 1})
+			if err != nil || !strings.Contains(q, "AND event_type NOT IN ('insufficient_data', 'insufficient_data_resolved')") {
+				t.Fatalf("expected insufficient-data events to be excluded from %s sql: %s err=%v", kind, q, err)
+			}
+		}
+	}
+	for _, bad := range []float64{math.NaN(), math.Inf(1)} {
+		if _, err := buildConditionQuery(sloID, nil, false, alertConfig{AlertKind: store.AlertKindBurn, Threshold: bad}); err == nil {
+			t.Fatalf("expected non-finite threshold %v to be rejected", bad)
+		}
+	}
+}
+
+func TestBuildConditionQueryPerDimension(t *testing.T) {
+	sloID := uuid.New()
+	q, err := buildConditionQuery(sloID, []string{"cloud.region", "tenant"}, false, alertConfig{AlertKind: store.AlertKindBurn, Op: "gte", Threshold: 2})
+	if err != nil {
+		t.Fatalf("buildConditionQuery() error = %v", err)
+	}
+	for _, want := range []string{
+		"SELECT now() AS time, dimension_key, cloud_region, tenant, toUInt8(",
+		"any(dimensions['cloud.region']) AS cloud_region,",
+		"AND last_burn_rate >= 2) AS active_burns",
+		"GROUP BY dimension_key",
+		"NOT startsWith(source, 'slo-replay:')",
+	} {
+		if !strings.Contains(q, want) {
+			t.Fatalf("expected %q in grouped burn sql: %s", want, q)
+		}
+	}
+	q, err = buildConditionQuery(sloID, []string{"region"}, false, alertConfig{AlertKind: store.AlertKindBreach})
+	if err != nil || !strings.Contains(q, "toUInt8(last_event_type = 'error_budget_exhausted') AS active_breaches") {
+		t.Fatalf("unexpected grouped breach sql: %s err=%v", q, err)
+	}
+	for _, bad := range [][]string{{"region'] OR 1=1 --"}, {"a.b", "a_b"}, {"time"}, {"objective"}} {
+		if _, err := buildConditionQuery(sloID, bad, false, alertConfig{AlertKind: store.AlertKindBurn, Threshold: 1}); err == nil {
+			t.Fatalf("expected groupBy %v to be rejected", bad)
+		}
+	}
+}
+
+func TestBuildConditionQueryPerObjective(t *testing.T) {
+	sloID := uuid.New()
+	q, err := buildConditionQuery(sloID, nil, true, alertConfig{AlertKind: store.AlertKindBurn, Op: "gte", Threshold: 2})
+	if err != nil {
+		t.Fatalf("buildConditionQuery() error = %v", err)
+	}
+	for _, want := range []string{"SELECT now() AS time, objective, toUInt8(", "GROUP BY objective\n"} {
+		if !strings.Contains(q, want) {
+			t.Fatalf("expected %q in per-objective sql: %s", want, q)
+		}
+	}
+	if strings.Contains(q, "dimension_key") {
+		t.Fatalf("expected an ungrouped SLO not to group by dimension: %s", q)
+	}
+	q, err = buildConditionQuery(sloID, []string{"region"}, true, alertConfig{AlertKind: store.AlertKindBreach})
+	if err != nil || !strings.Contains(q, "SELECT now() AS time, objective, dimension_key, region, ") || !strings.Contains(q, "GROUP BY objective, dimension_key") {
+		t.Fatalf("unexpected per-objective grouped sql: %s err=%v", q, err)
+	}
+}
@@ -41,25 +41,118 @@ func (e AlertStateAlertKind) Valid() bool {
 
 // Defines values for BurnEventEventType.
 const (
-	BurnContinued        BurnEventEventType = "burn_continued"
-	BurnResolved         BurnEventEventType = "burn_resolved"
-	BurnStarted          BurnEventEventType = "burn_started"
-	ErrorBudgetExhausted BurnEventEventType = "error_budget_exhausted"
-	ErrorBudgetRecovered BurnEventEventType = "error_budget_recovered"
+	BurnEventEventTypeBurnContinued            BurnEventEventType = "burn_continued"
+	BurnEventEventTypeBurnResolved             BurnEventEventType = "burn_resolved"
+	BurnEventEventTypeBurnStarted              BurnEventEventType = "burn_started"
+	BurnEventEventTypeErrorBudgetExhausted     BurnEventEventType = "error_budget_exhausted"
+	BurnEventEventTypeErrorBudgetRecovered     BurnEventEventType = "error_budget_recovered"
+	BurnEventEventTypeInsufficientData         BurnEventEventType = "insufficient_data"
+	BurnEventEventTypeInsufficientDataResolved BurnEventEventType = "insufficient_data_resolved"
 )
 
 // Valid indicates whether the value is a known member of the BurnEventEventType enum.
 func (e BurnEventEventType) Valid() bool {
 	switch e {
-	case BurnContinued:
+	case BurnEventEventTypeBurnContinued:
 		return true
-	case BurnResolved:
+	case BurnEventEventTypeBurnResolved:
 		return true
-	case BurnStarted:
+	case BurnEventEventTypeBurnStarted:
 		return true
-	case ErrorBudgetExhausted:
+	case BurnEventEventTypeErrorBudgetExhausted:
 		return true
-	case ErrorBudgetRecovered:
+	case BurnEventEventTypeErrorBudgetRecovered:
+		return true
+	case BurnEventEventTypeInsufficientData:
+		return true
+	case BurnEventEventTypeInsufficientDataResolved:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for CalendarWindowUnit.
+const (
+	Month   CalendarWindowUnit = "month"
+	Quarter CalendarWindowUnit = "quarter"
+	Week    CalendarWindowUnit = "week"
+)
+
+// Valid indicates whether the value is a known member of the CalendarWindowUnit enum.
+func (e CalendarWindowUnit) Valid() bool {
+	switch e {
+	case Month:
+		return true
+	case Quarter:
+		return true
+	case Week:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for CompositeSLOAggregation.
+const (
+	And             CompositeSLOAggregation = "and"
+	Min             CompositeSLOAggregation = "min"
+	WeightedAverage CompositeSLOAggregation = "weightedAverage"
+)
+
+// Valid indicates whether the value is a known member of the CompositeSLOAggregation enum.
+func (e CompositeSLOAggregation) Valid() bool {
+	switch e {
+	case And:
+		return true
+	case Min:
+		return true
+	case WeightedAverage:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for EvaluationHealthLastErrorStage.
+const (
+	Backend    EvaluationHealthLastErrorStage = "backend"
+	Definition EvaluationHealthLastErrorStage = "definition"
+	Persist    EvaluationHealthLastErrorStage = "persist"
+	Query      EvaluationHealthLastErrorStage = "query"
+)
+
+// Valid indicates whether the value is a known member of the EvaluationHealthLastErrorStage enum.
+func (e EvaluationHealthLastErrorStage) Valid() bool {
+	switch e {
+	case Backend:
+		return true
+	case Definition:
+		return true
+	case Persist:
+		return true
+	case Query:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for EvaluationHealthStatus.
+const (
+	EvaluationHealthStatusFailed  EvaluationHealthStatus = "failed"
+	EvaluationHealthStatusOk      EvaluationHealthStatus = "ok"
+	EvaluationHealthStatusPending EvaluationHealthStatus = "pending"
+)
+
+// Valid indicates whether the value is a known member of the EvaluationHealthStatus enum.
+func (e EvaluationHealthStatus) Valid() bool {
+	switch e {
+	case EvaluationHealthStatusFailed:
+		return true
+	case EvaluationHealthStatusOk:
+		return true
+	case EvaluationHealthStatusPending:
 		return true
 	default:
 		return false
@@ -68,13 +161,55 @@ func (e BurnEventEventType) Valid() bool {
 
 // Defines values for HealthResponseStatus.
 const (
-	Ok HealthResponseStatus = "ok"
+	HealthResponseStatusOk HealthResponseStatus = "ok"
 )
 
 // Valid indicates whether the value is a known member of the HealthResponseStatus enum.
 func (e HealthResponseStatus) Valid() bool {
 	switch e {
-	case Ok:
+	case HealthResponseStatusOk:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for MaintenanceRecurrenceFrequency.
+const (
+	Daily   MaintenanceRecurrenceFrequency = "daily"
+	Monthly MaintenanceRecurrenceFrequency = "monthly"
+	Weekly  MaintenanceRecurrenceFrequency = "weekly"
+)
+
+// Valid indicates whether the value is a known member of the MaintenanceRecurrenceFrequency enum.
+func (e MaintenanceRecurrenceFrequency) Valid() bool {
+	switch e {
+	case Daily:
+		return true
+	case Monthly:
+		return true
+	case Weekly:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for MaintenanceScopeType.
+const (
+	MaintenanceScopeTypeService MaintenanceScopeType = "service"
+	MaintenanceScopeTypeSlo     MaintenanceScopeType = "slo"
+	MaintenanceScopeTypeTeam    MaintenanceScopeType = "team"
+)
+
+// Valid indicates whether the value is a known member of the MaintenanceScopeType enum.
+func (e MaintenanceScopeType) Valid() bool {
+	switch e {
+	case MaintenanceScopeTypeService:
+		return true
+	case MaintenanceScopeTypeSlo:
+		return true
+	case MaintenanceScopeTypeTeam:
 		return true
 	default:
 		return false
@@ -96,18 +231,63 @@ func (e ReadyResponseStatus) Valid() bool {
 	}
 }
 
+// Defines values for SLOObjectiveOp.
+const (
+	Gt  SLOObjectiveOp = "gt"
+	Gte SLOObjectiveOp = "gte"
+	Lt  SLOObjectiveOp = "lt"
+	Lte SLOObjectiveOp = "lte"
+)
+
+// Valid indicates whether the value is a known member of the SLOObjectiveOp enum.
+func (e SLOObjectiveOp) Valid() bool {
+	switch e {
+	case Gt:
+		return true
+	case Gte:
+		return true
+	case Lt:
+		return true
+	case Lte:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for SLORuntimeBudgetingMethod.
+const (
+	Occurrences SLORuntimeBudgetingMethod = "Occurrences"
+	Timeslices  SLORuntimeBudgetingMethod = "Timeslices"
+)
+
+// Valid indicates whether the value is a known member of the SLORuntimeBudgetingMethod enum.
+func (e SLORuntimeBudgetingMethod) Valid() bool {
+	switch e {
+	case Occurrences:
+		return true
+	case Timeslices:
+		return true
+	default:
+		return false
+	}
+}
+
 // Defines values for SLORuntimeDatasourceType.
 const (
-	Clickhouse SLORuntimeDatasourceType = "clickhouse"
-	Prometheus SLORuntimeDatasourceType = "prometheus"
+	SLORuntimeDatasourceTypeClickhouse SLORuntimeDatasourceType = "clickhouse"
+	SLORuntimeDatasourceTypeComposite  SLORuntimeDatasourceType = "composite"
+	SLORuntimeDatasourceTypePrometheus SLORuntimeDatasourceType = "prometheus"
 )
 
 // Valid indicates whether the value is a known member of the SLORuntimeDatasourceType enum.
 func (e SLORuntimeDatasourceType) Valid() bool {
 	switch e {
-	case Clickhouse:
+	case SLORuntimeDatasourceTypeClickhouse:
+		return true
+	case SLORuntimeDatasourceTypeComposite:
 		return true
-	case Prometheus:
+	case SLORuntimeDatasourceTypePrometheus:
 		return true
 	default:
 		return false
@@ -116,16 +296,115 @@ func (e SLORuntimeDatasourceType) Valid() bool {
 
 // Defines values for SLORuntimeType.
 const (
-	ErrorRate SLORuntimeType = "error_rate"
-	Latency   SLORuntimeType = "latency"
+	SLORuntimeTypeComposite SLORuntimeType = "composite"
+	SLORuntimeTypeErrorRate SLORuntimeType = "error_rate"
+	SLORuntimeTypeLatency   SLORuntimeType = "latency"
+	SLORuntimeTypeRatio     SLORuntimeType = "ratio"
 )
 
 // Valid indicates whether the value is a known member of the SLORuntimeType enum.
 func (e SLORuntimeType) Valid() bool {
 	switch e {
-	case ErrorRate:
+	case SLORuntimeTypeComposite:
+		return true
+	case SLORuntimeTypeErrorRate:
+		return true
+	case SLORuntimeTypeLatency:
+		return true
+	case SLORuntimeTypeRatio:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for SpanAttributeMatcherOp.
+const (
+	Equals    SpanAttributeMatcherOp = "equals"
+	In        SpanAttributeMatcherOp = "in"
+	NotEquals SpanAttributeMatcherOp = "not-equals"
+	Regex     SpanAttributeMatcherOp = "regex"
+)
+
+// Valid indicates whether the value is a known member of the SpanAttributeMatcherOp enum.
+func (e SpanAttributeMatcherOp) Valid() bool {
+	switch e {
+	case Equals:
+		return true
+	case In:
+		return true
+	case NotEquals:
+		return true
+	case Regex:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for WebhookContentMode.
+const (
+	Binary     WebhookContentMode = "binary"
+	Structured WebhookContentMode = "structured"
+)
+
+// Valid indicates whether the value is a known member of the WebhookContentMode enum.
+func (e WebhookContentMode) Valid() bool {
+	switch e {
+	case Binary:
+		return true
+	case Structured:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for WebhookScopeType.
+const (
+	WebhookScopeTypeService WebhookScopeType = "service"
+	WebhookScopeTypeTeam    WebhookScopeType = "team"
+)
+
+// Valid indicates whether the value is a known member of the WebhookScopeType enum.
+func (e WebhookScopeType) Valid() bool {
+	switch e {
+	case WebhookScopeTypeService:
+		return true
+	case WebhookScopeTypeTeam:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for WebhookSubscriptionRequestEventTypes.
+const (
+	WebhookSubscriptionRequestEventTypesBurnContinued            WebhookSubscriptionRequestEventTypes = "burn_continued"
+	WebhookSubscriptionRequestEventTypesBurnResolved             WebhookSubscriptionRequestEventTypes = "burn_resolved"
+	WebhookSubscriptionRequestEventTypesBurnStarted              WebhookSubscriptionRequestEventTypes = "burn_started"
+	WebhookSubscriptionRequestEventTypesErrorBudgetExhausted     WebhookSubscriptionRequestEventTypes = "error_budget_exhausted"
+	WebhookSubscriptionRequestEventTypesErrorBudgetRecovered     WebhookSubscriptionRequestEventTypes = "error_budget_recovered"
+	WebhookSubscriptionRequestEventTypesInsufficientData         WebhookSubscriptionRequestEventTypes = "insufficient_data"
+	WebhookSubscriptionRequestEventTypesInsufficientDataResolved WebhookSubscriptionRequestEventTypes = "insufficient_data_resolved"
+)
+
+// Valid indicates whether the value is a known member of the WebhookSubscriptionRequestEventTypes enum.
+func (e WebhookSubscriptionRequestEventTypes) Valid() bool {
+	switch e {
+	case WebhookSubscriptionRequestEventTypesBurnContinued:
+		return true
+	case WebhookSubscriptionRequestEventTypesBurnResolved:
+		return true
+	case WebhookSubscriptionRequestEventTypesBurnStarted:
+		return true
+	case WebhookSubscriptionRequestEventTypesErrorBudgetExhausted:
+		return true
+	case WebhookSubscriptionRequestEventTypesErrorBudgetRecovered:
 		return true
-	case Latency:
+	case WebhookSubscriptionRequestEventTypesInsufficientData:
+		return true
+	case WebhookSubscriptionRequestEventTypesInsufficientDataResolved:
 		return true
 	default:
 		return false
@@ -155,9 +434,12 @@ type AlertStateListResponse struct {
 
 // BurnEvent defines model for BurnEvent.
 type BurnEvent struct {
+	DimensionKey   *string            `json:"dimensionKey,omitempty"`
+	Dimensions     *map[string]string `json:"dimensions,omitempty"`
 	EventType      BurnEventEventType `json:"eventType"`
 	Id             openapi_types.UUID `json:"id"`
 	IdempotencyKey string             `json:"idempotencyKey"`
+	Objective      *string            `json:"objective,omitempty"`
 	ObservedAt     time.Time          `json:"observedAt"`
 	ServiceId      openapi_types.UUID `json:"serviceId"`
 	SloId          openapi_types.UUID `json:"sloId"`
@@ -175,6 +457,41 @@ type BurnEventListResponse struct {
 	Page  Pagination  `json:"page"`
 }
 
+// BurnRateTier defines model for BurnRateTier.
+type BurnRateTier struct {
+	LongWindowMinutes  int     `json:"longWindowMinutes"`
+	Name               string  `json:"name"`
+	Severity           string  `json:"severity"`
+	ShortWindowMinutes int     `json:"shortWindowMinutes"`
+	Threshold          float64 `json:"threshold"`
+}
+
+// CalendarWindow defines model for CalendarWindow.
+type CalendarWindow struct {
+	Count     int                `json:"count"`
+	StartTime *string            `json:"startTime,omitempty"`
+	TimeZone  string             `json:"timeZone"`
+	Unit      CalendarWindowUnit `json:"unit"`
+}
+
+// CalendarWindowUnit defines model for CalendarWindow.Unit.
+type CalendarWindowUnit string
+
+// CompositeComponent defines model for CompositeComponent.
+type CompositeComponent struct {
+	SloId  openapi_types.UUID `json:"sloId"`
+	Weight float32            `json:"weight"`
+}
+
+// CompositeSLO defines model for CompositeSLO.
+type CompositeSLO struct {
+	Aggregation CompositeSLOAggregation `json:"aggregation"`
+	Components  []CompositeComponent    `json:"components"`
+}
+
+// CompositeSLOAggregation defines model for CompositeSLO.Aggregation.
+type CompositeSLOAggregation string
+
 // CreateSLORequest defines model for CreateSLORequest.
 type CreateSLORequest struct {
 	Openslo   string             `json:"openslo"`
@@ -195,6 +512,196 @@ type CreateTeamRequest struct {
 	Slug string `json:"slug"`
 }
 
+// DeadLetterEvent defines model for DeadLetterEvent.
+type DeadLetterEvent struct {
+	AggregateId   openapi_types.UUID `json:"aggregateId"`
+	AggregateType string             `json:"aggregateType"`
+
+	// Attempts Every failed delivery attempt, oldest first. Only returned for a single event.
+	Attempts       *[]DeliveryAttempt     `json:"attempts,omitempty"`
+	CreatedAt      time.Time              `json:"createdAt"`
+	DeadLetteredAt time.Time              `json:"deadLetteredAt"`
+	EventType      string                 `json:"eventType"`
+	Id             openapi_types.UUID     `json:"id"`
+	IdempotencyKey string                 `json:"idempotencyKey"`
+	LastError      string                 `json:"lastError"`
+	Payload        map[string]interface{} `json:"payload"`
+
+	// RetryCount Failed delivery attempts since the event was enqueued or last requeued.
+	RetryCount int `json:"retryCount"`
+}
+
+// DeadLetterEventListResponse defines model for DeadLetterEventListResponse.
+type DeadLetterEventListResponse struct {
+	Items []DeadLetterEvent `json:"items"`
+	Page  Pagination        `json:"page"`
+}
+
+// DeliveryAttempt defines model for DeliveryAttempt.
+type DeliveryAttempt struct {
+	AttemptNo   int       `json:"attemptNo"`
+	AttemptedAt time.Time `json:"attemptedAt"`
+	Error       string    `json:"error"`
+}
+
+// EvaluationAction defines model for EvaluationAction.
+type EvaluationAction struct {
+	EmitEvent bool    `json:"emitEvent"`
+	EventType *string `json:"eventType,omitempty"`
+}
+
+// EvaluationEvent defines model for EvaluationEvent.
+type EvaluationEvent struct {
+	EventType      string                 `json:"eventType"`
+	IdempotencyKey string                 `json:"idempotencyKey"`
+	Payload        map[string]interface{} `json:"payload"`
+}
+
+// EvaluationExplanation defines model for EvaluationExplanation.
+type EvaluationExplanation struct {
+	Action          EvaluationAction `json:"action"`
+	BudgetExhausted bool             `json:"budgetExhausted"`
+	BudgetRemaining float64          `json:"budgetRemaining"`
+	BurnRate        float64          `json:"burnRate"`
+	BurnTier        string           `json:"burnTier"`
+	Compliance      float64          `json:"compliance"`
+
+	// DimensionKey Dimension explained, for SLOs with groupBy keys.
+	DimensionKey *string `json:"dimensionKey,omitempty"`
+
+	// DimensionKeys Every dimension the evaluation covered.
+	DimensionKeys        *[]string          `json:"dimensionKeys,omitempty"`
+	Dimensions           *map[string]string `json:"dimensions,omitempty"`
+	DryRun               bool               `json:"dryRun"`
+	EtaExhaustionSeconds *int               `json:"etaExhaustionSeconds,omitempty"`
+	EvaluatedAt          time.Time          `json:"evaluatedAt"`
+
+	// Events Outbox events the evaluation enqueued, or would enqueue in a dry run.
+	Events []EvaluationEvent `json:"events"`
+
+	// InMaintenance A maintenance window covers the SLO, so no burn starts or continues.
+	InMaintenance    *bool `json:"inMaintenance,omitempty"`
+	InsufficientData bool  `json:"insufficientData"`
+
+	// Objective Objective explained; absent for an unnamed objective.
+	Objective *string `json:"objective,omitempty"`
+
+	// Objectives Every objective the evaluation covered, for SLOs with several objectives.
+	Objectives      *[]string         `json:"objectives,omitempty"`
+	Queries         []EvaluationQuery `json:"queries"`
+	QueryDurationMs int               `json:"queryDurationMs"`
+
+	// RawSeverity Severity of the firing tier before flap damping and the minimum-traffic guard.
+	RawSeverity string                   `json:"rawSeverity"`
+	Severity    string                   `json:"severity"`
+	SloId       openapi_types.UUID       `json:"sloId"`
+	Tiers       []EvaluationTier         `json:"tiers"`
+	Windows     []EvaluationWindowResult `json:"windows"`
+}
+
+// EvaluationHealth defines model for EvaluationHealth.
+type EvaluationHealth struct {
+	ConsecutiveFailures int                             `json:"consecutiveFailures"`
+	LastAttemptAt       *time.Time                      `json:"lastAttemptAt,omitempty"`
+	LastDurationMs      int                             `json:"lastDurationMs"`
+	LastError           *string                         `json:"lastError,omitempty"`
+	LastErrorAt         *time.Time                      `json:"lastErrorAt,omitempty"`
+	LastErrorStage      *EvaluationHealthLastErrorStage `json:"lastErrorStage,omitempty"`
+	LastQueryDurationMs int                             `json:"lastQueryDurationMs"`
+	LastSuccessAt       *time.Time                      `json:"lastSuccessAt,omitempty"`
+	SloId               openapi_types.UUID              `json:"sloId"`
+	Status              EvaluationHealthStatus          `json:"status"`
+}
+
+// EvaluationHealthLastErrorStage defines model for EvaluationHealth.LastErrorStage.
+type EvaluationHealthLastErrorStage string
+
+// EvaluationHealthStatus defines model for EvaluationHealth.Status.
+type EvaluationHealthStatus string
+
+// EvaluationPoint defines model for EvaluationPoint.
+type EvaluationPoint struct {
+	BudgetRemaining float64 `json:"budgetRemaining"`
+	BurnRate        float64 `json:"burnRate"`
+	BurnTier        string  `json:"burnTier"`
+	Compliance      float64 `json:"compliance"`
+
+	// DimensionKey Dimension of an SLO with groupBy keys; absent otherwise.
+	DimensionKey         *string   `json:"dimensionKey,omitempty"`
+	EtaExhaustionSeconds *int      `json:"etaExhaustionSeconds,omitempty"`
+	EvaluatedAt          time.Time `json:"evaluatedAt"`
+	Events               float64   `json:"events"`
+	InsufficientData     bool      `json:"insufficientData"`
+
+	// Objective Objective displayName; absent for an unnamed objective.
+	Objective       *string            `json:"objective,omitempty"`
+	QueryDurationMs int                `json:"queryDurationMs"`
+	Severity        string             `json:"severity"`
+	Windows         []EvaluationWindow `json:"windows"`
+}
+
+// EvaluationQuery defines model for EvaluationQuery.
+type EvaluationQuery struct {
+	// Args Bound parameters for the query's placeholders, in order.
+	Args []interface{} `json:"args"`
+
+	// Name compliance for single-query SLIs, otherwise total, good or bad.
+	Name          string `json:"name"`
+	Text          string `json:"text"`
+	WindowMinutes int    `json:"windowMinutes"`
+}
+
+// EvaluationSeries defines model for EvaluationSeries.
+type EvaluationSeries struct {
+	From   time.Time          `json:"from"`
+	Points []EvaluationPoint  `json:"points"`
+	SloId  openapi_types.UUID `json:"sloId"`
+	To     time.Time          `json:"to"`
+
+	// Truncated The range held more points than the limit; narrow it to see the rest.
+	Truncated bool `json:"truncated"`
+}
+
+// EvaluationSummary defines model for EvaluationSummary.
+type EvaluationSummary struct {
+	// FailingCount SLOs whose latest evaluation failed.
+	FailingCount    int        `json:"failingCount"`
+	LastEvaluatedAt *time.Time `json:"lastEvaluatedAt,omitempty"`
+	SloCount        int        `json:"sloCount"`
+
+	// StaleCount SLOs not evaluated within the staleness threshold, including never-evaluated ones.
+	StaleCount int `json:"staleCount"`
+}
+
+// EvaluationTier defines model for EvaluationTier.
+type EvaluationTier struct {
+	Firing             bool    `json:"firing"`
+	LongWindowMinutes  int     `json:"longWindowMinutes"`
+	Name               string  `json:"name"`
+	ResolveThreshold   float64 `json:"resolveThreshold"`
+	Severity           string  `json:"severity"`
+	ShortWindowMinutes *int    `json:"shortWindowMinutes,omitempty"`
+	Threshold          float64 `json:"threshold"`
+}
+
+// EvaluationWindow defines model for EvaluationWindow.
+type EvaluationWindow struct {
+	Compliance    float64 `json:"compliance"`
+	Events        float64 `json:"events"`
+	WindowMinutes int     `json:"windowMinutes"`
+}
+
+// EvaluationWindowResult defines model for EvaluationWindowResult.
+type EvaluationWindowResult struct {
+	BurnRate   *float64 `json:"burnRate,omitempty"`
+	Compliance float64  `json:"compliance"`
+	Events     float64  `json:"events"`
+
+	// Sufficient The window met the minimum event count; only sufficient windows have a burn rate.
+	Sufficient    bool `json:"sufficient"`
+	WindowMinutes int  `json:"windowMinutes"`
+}
+
 // HealthResponse defines model for HealthResponse.
 type HealthResponse struct {
 	Status HealthResponseStatus `json:"status"`
@@ -203,6 +710,70 @@ type HealthResponse struct {
 // HealthResponseStatus defines model for HealthResponse.Status.
 type HealthResponseStatus string
 
+// MaintenanceRecurrence defines model for MaintenanceRecurrence.
+type MaintenanceRecurrence struct {
+	Frequency MaintenanceRecurrenceFrequency `json:"frequency"`
+
+	// Interval Repeat every this many days, weeks or months.
+	Interval *int `json:"interval,omitempty"`
+
+	// Until No occurrence starts after this time; absent repeats forever.
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// MaintenanceRecurrenceFrequency defines model for MaintenanceRecurrence.Frequency.
+type MaintenanceRecurrenceFrequency string
+
+// MaintenanceScopeType defines model for MaintenanceScopeType.
+type MaintenanceScopeType string
+
+// MaintenanceWindow defines model for MaintenanceWindow.
+type MaintenanceWindow struct {
+	// Active Whether an occurrence is in effect now.
+	Active            bool                   `json:"active"`
+	CreatedAt         time.Time              `json:"createdAt"`
+	Description       *string                `json:"description,omitempty"`
+	EndsAt            time.Time              `json:"endsAt"`
+	ExcludeFromBudget bool                   `json:"excludeFromBudget"`
+	Id                openapi_types.UUID     `json:"id"`
+	Name              string                 `json:"name"`
+	Recurrence        *MaintenanceRecurrence `json:"recurrence,omitempty"`
+	ScopeId           openapi_types.UUID     `json:"scopeId"`
+	ScopeType         MaintenanceScopeType   `json:"scopeType"`
+	StartsAt          time.Time              `json:"startsAt"`
+	TimeZone          string                 `json:"timeZone"`
+	UpdatedAt         time.Time              `json:"updatedAt"`
+}
+
+// MaintenanceWindowListResponse defines model for MaintenanceWindowListResponse.
+type MaintenanceWindowListResponse struct {
+	Items []MaintenanceWindow `json:"items"`
+	Page  Pagination          `json:"page"`
+}
+
+// MaintenanceWindowRequest defines model for MaintenanceWindowRequest.
+type MaintenanceWindowRequest struct {
+	Description *string `json:"description,omitempty"`
+
+	// EndsAt End of the first occurrence; later occurrences last as long.
+	EndsAt time.Time `json:"endsAt"`
+
+	// ExcludeFromBudget Leave the window's minutes out of error budget accounting.
+	ExcludeFromBudget *bool                  `json:"excludeFromBudget,omitempty"`
+	Name              string                 `json:"name"`
+	Recurrence        *MaintenanceRecurrence `json:"recurrence,omitempty"`
+
+	// ScopeId The team, service or SLO the window applies to.
+	ScopeId   openapi_types.UUID   `json:"scopeId"`
+	ScopeType MaintenanceScopeType `json:"scopeType"`
+
+	// StartsAt Start of the first occurrence.
+	StartsAt time.Time `json:"startsAt"`
+
+	// TimeZone IANA time zone recurrences keep their wall-clock time in; defaults to UTC.
+	TimeZone *string `json:"timeZone,omitempty"`
+}
+
 // Pagination defines model for Pagination.
 type Pagination struct {
 	Page     int `json:"page"`
@@ -221,9 +792,15 @@ type Problem struct {
 	AdditionalProperties map[string]interface{} `json:"-"`
 }
 
+// PurgeDeadLetterEventsResponse defines model for PurgeDeadLetterEventsResponse.
+type PurgeDeadLetterEventsResponse struct {
+	Purged int `json:"purged"`
+}
+
 // ReadyResponse defines model for ReadyResponse.
 type ReadyResponse struct {
-	Status ReadyResponseStatus `json:"status"`
+	Evaluation *EvaluationSummary  `json:"evaluation,omitempty"`
+	Status     ReadyResponseStatus `json:"status"`
 }
 
 // ReadyResponseStatus defines model for ReadyResponse.Status.
@@ -245,19 +822,67 @@ type SLOListResponse struct {
 	Page  Pagination `json:"page"`
 }
 
+// SLOObjective defines model for SLOObjective.
+type SLOObjective struct {
+	// Name The objective's displayName; empty for an unnamed objective.
+	Name   string          `json:"name"`
+	Op     *SLOObjectiveOp `json:"op,omitempty"`
+	Target float32         `json:"target"`
+
+	// Threshold Threshold compared against, for ClickHouse latency and Timeslices indicators.
+	Threshold *float32 `json:"threshold,omitempty"`
+}
+
+// SLOObjectiveOp defines model for SLOObjective.Op.
+type SLOObjectiveOp string
+
 // SLORuntime defines model for SLORuntime.
 type SLORuntime struct {
-	DatasourceType SLORuntimeDatasourceType `json:"datasourceType"`
-	DatasourceUid  string                   `json:"datasourceUid"`
-	Description    *string                  `json:"description,omitempty"`
-	Name           string                   `json:"name"`
-	Route          string                   `json:"route"`
-	Target         float32                  `json:"target"`
-	Threshold      float32                  `json:"threshold"`
-	Type           SLORuntimeType           `json:"type"`
-	UserExperience *string                  `json:"userExperience,omitempty"`
-	WindowMinutes  int                      `json:"windowMinutes"`
-}
+	Attributes      *[]SpanAttributeMatcher    `json:"attributes,omitempty"`
+	Bad             *SpanSelector              `json:"bad,omitempty"`
+	BadQuery        *string                    `json:"badQuery,omitempty"`
+	BudgetingMethod *SLORuntimeBudgetingMethod `json:"budgetingMethod,omitempty"`
+	BurnRateTiers   *[]BurnRateTier            `json:"burnRateTiers,omitempty"`
+	Calendar        *CalendarWindow            `json:"calendar,omitempty"`
+	Composite       *CompositeSLO              `json:"composite,omitempty"`
+	DatasourceType  SLORuntimeDatasourceType   `json:"datasourceType"`
+
+	// DatasourceUid Empty for composite SLOs, which have no datasource of their own.
+	DatasourceUid string        `json:"datasourceUid"`
+	DatasourceUrl *string       `json:"datasourceUrl,omitempty"`
+	Description   *string       `json:"description,omitempty"`
+	Good          *SpanSelector `json:"good,omitempty"`
+	GoodQuery     *string       `json:"goodQuery,omitempty"`
+
+	// GroupBy Span attribute keys or Prometheus labels the SLO is evaluated per combination of.
+	GroupBy *[]string `json:"groupBy,omitempty"`
+
+	// MinEvents Burn windows with fewer events are reported as insufficient data.
+	MinEvents *int `json:"minEvents,omitempty"`
+
+	// MinSliceEvents Timeslices with fewer events are not scored.
+	MinSliceEvents *int   `json:"minSliceEvents,omitempty"`
+	Name           string `json:"name"`
+
+	// Objectives Every objective of the SLO in declared order; target and threshold mirror the first.
+	Objectives        *[]SLOObjective `json:"objectives,omitempty"`
+	Route             string          `json:"route"`
+	ServiceName       *string         `json:"serviceName,omitempty"`
+	SpanKind          *string         `json:"spanKind,omitempty"`
+	SpanName          *string         `json:"spanName,omitempty"`
+	Target            float32         `json:"target"`
+	Threshold         float32         `json:"threshold"`
+	TimeSliceMinutes  *int            `json:"timeSliceMinutes,omitempty"`
+	TimeSliceQuantile *float32        `json:"timeSliceQuantile,omitempty"`
+	Total             *SpanSelector   `json:"total,omitempty"`
+	TotalQuery        *string         `json:"totalQuery,omitempty"`
+	Type              SLORuntimeType  `json:"type"`
+	UserExperience    *string         `json:"userExperience,omitempty"`
+	WindowMinutes     int             `json:"windowMinutes"`
+}
+
+// SLORuntimeBudgetingMethod defines model for SLORuntime.BudgetingMethod.
+type SLORuntimeBudgetingMethod string
 
 // SLORuntimeDatasourceType defines model for SLORuntime.DatasourceType.
 type SLORuntimeDatasourceType string
@@ -282,6 +907,25 @@ type ServiceListResponse struct {
 	Page  Pagination `json:"page"`
 }
 
+// SpanAttributeMatcher defines model for SpanAttributeMatcher.
+type SpanAttributeMatcher struct {
+	Key    string                 `json:"key"`
+	Op     SpanAttributeMatcherOp `json:"op"`
+	Values []string               `json:"values"`
+}
+
+// SpanAttributeMatcherOp defines model for SpanAttributeMatcher.Op.
+type SpanAttributeMatcherOp string
+
+// SpanSelector defines model for SpanSelector.
+type SpanSelector struct {
+	Attributes  *[]SpanAttributeMatcher `json:"attributes,omitempty"`
+	Route       *string                 `json:"route,omitempty"`
+	ServiceName *string                 `json:"serviceName,omitempty"`
+	SpanKind    *string                 `json:"spanKind,omitempty"`
+	SpanName    *string                 `json:"spanName,omitempty"`
+}
+
 // Team defines model for Team.
 type Team struct {
 	CreatedAt time.Time          `json:"createdAt"`
@@ -316,9 +960,72 @@ type UpdateTeamRequest struct {
 	Slug string `json:"slug"`
 }
 
+// WebhookContentMode CloudEvents content mode of the requests. structured POSTs the whole event as application/cloudevents+json; binary sends the attributes as ce- headers and the data as the body. Defaults to structured.
+type WebhookContentMode string
+
+// WebhookScopeType defines model for WebhookScopeType.
+type WebhookScopeType string
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	// ContentMode CloudEvents content mode of the requests. structured POSTs the whole event as application/cloudevents+json; binary sends the attributes as ce- headers and the data as the body. Defaults to structured.
+	ContentMode WebhookContentMode `json:"contentMode"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	Enabled     bool               `json:"enabled"`
+	EventTypes  []string           `json:"eventTypes"`
+	Id          openapi_types.UUID `json:"id"`
+	Name        string             `json:"name"`
+	ScopeId     openapi_types.UUID `json:"scopeId"`
+	ScopeType   WebhookScopeType   `json:"scopeType"`
+	TimeoutMs   int                `json:"timeoutMs"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+	Url         string             `json:"url"`
+}
+
+// WebhookSubscriptionListResponse defines model for WebhookSubscriptionListResponse.
+type WebhookSubscriptionListResponse struct {
+	Items []WebhookSubscription `json:"items"`
+	Page  Pagination            `json:"page"`
+}
+
+// WebhookSubscriptionRequest defines model for WebhookSubscriptionRequest.
+type WebhookSubscriptionRequest struct {
+	// ContentMode CloudEvents content mode of the requests. structured POSTs the whole event as application/cloudevents+json; binary sends the attributes as ce- headers and the data as the body. Defaults to structured.
+	ContentMode *WebhookContentMode `json:"contentMode,omitempty"`
+
+	// Enabled Defaults to true.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// EventTypes Event types to push; empty or absent pushes all of them.
+	EventTypes *[]WebhookSubscriptionRequestEventTypes `json:"eventTypes,omitempty"`
+	Name       string                                  `json:"name"`
+
+	// ScopeId The team or service whose SLO events are pushed.
+	ScopeId   openapi_types.UUID `json:"scopeId"`
+	ScopeType WebhookScopeType   `json:"scopeType"`
+
+	// Secret HMAC-SHA256 signing key. Required on create; omitted on update keeps the current one.
+	Secret *string `json:"secret,omitempty"`
+
+	// TimeoutMs Per-request timeout; defaults to 5000.
+	TimeoutMs *int `json:"timeoutMs,omitempty"`
+
+	// Url http or https endpoint the events are POSTed to.
+	Url string `json:"url"`
+}
+
+// WebhookSubscriptionRequestEventTypes defines model for WebhookSubscriptionRequest.EventTypes.
+type WebhookSubscriptionRequestEventTypes string
+
 // IdempotencyKey defines model for IdempotencyKey.
 type IdempotencyKey = string
 
+// MaintenanceWindowId defines model for MaintenanceWindowId.
+type MaintenanceWindowId = openapi_types.UUID
+
+// OutboxEventId defines model for OutboxEventId.
+type OutboxEventId = openapi_types.UUID
+
 // Page defines model for Page.
 type Page = int
 
@@ -334,6 +1041,9 @@ type SloId = openapi_types.UUID
 // TeamId defines model for TeamId.
 type TeamId = openapi_types.UUID
 
+// WebhookSubscriptionId defines model for WebhookSubscriptionId.
+type WebhookSubscriptionId = openapi_types.UUID
+
 // ProblemResponse defines model for ProblemResponse.
 type ProblemResponse = Problem
 
@@ -347,6 +1057,28 @@ type ListBurnEventsParams struct {
 	To        *time.Time          `form:"to,omitempty" json:"to,omitempty"`
 }
 
+// ListMaintenanceWindowsParams defines parameters for ListMaintenanceWindows.
+type ListMaintenanceWindowsParams struct {
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+
+	// ScopeType Together with scopeId, lists only the windows on that team, service or SLO.
+	ScopeType *MaintenanceScopeType `form:"scopeType,omitempty" json:"scopeType,omitempty"`
+	ScopeId   *openapi_types.UUID   `form:"scopeId,omitempty" json:"scopeId,omitempty"`
+}
+
+// PurgeDeadLetterEventsParams defines parameters for PurgeDeadLetterEvents.
+type PurgeDeadLetterEventsParams struct {
+	// Before Only purge events dead-lettered before this time; absent purges all of them.
+	Before *time.Time `form:"before,omitempty" json:"before,omitempty"`
+}
+
+// ListDeadLetterEventsParams defines parameters for ListDeadLetterEvents.
+type ListDeadLetterEventsParams struct {
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+}
+
 // ListServicesParams defines parameters for ListServices.
 type ListServicesParams struct {
 	Page        *Page               `form:"page,omitempty" json:"page,omitempty"`
@@ -371,12 +1103,46 @@ type UpdateSLOParams struct {
 	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
 }
 
+// EvaluateSLOParams defines parameters for EvaluateSLO.
+type EvaluateSLOParams struct {
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+
+	// Objective Objective displayName to explain; defaults to the objective with the highest burn rate.
+	Objective *string `form:"objective,omitempty" json:"objective,omitempty"`
+
+	// Dimension Dimension key to explain; defaults to the dimension with the highest burn rate.
+	Dimension *string `form:"dimension,omitempty" json:"dimension,omitempty"`
+}
+
+// GetSLOEvaluationsParams defines parameters for GetSLOEvaluations.
+type GetSLOEvaluationsParams struct {
+	From  *time.Time `form:"from,omitempty" json:"from,omitempty"`
+	To    *time.Time `form:"to,omitempty" json:"to,omitempty"`
+	Limit *int       `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // ListTeamsParams defines parameters for ListTeams.
 type ListTeamsParams struct {
 	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
 	PageSize *PageSize `form:"pageSize,omitempty" json:"pageSize,omitempty"`
 }
 
+// ListWebhookSubscriptionsParams defines parameters for ListWebhookSubscriptions.
+type ListWebhookSubscriptionsParams struct {
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+
+	// ScopeType Together with scopeId, lists only the subscriptions of that team or service.
+	ScopeType *WebhookScopeType   `form:"scopeType,omitempty" json:"scopeType,omitempty"`
+	ScopeId   *openapi_types.UUID `form:"scopeId,omitempty" json:"scopeId,omitempty"`
+}
+
+// CreateMaintenanceWindowJSONRequestBody defines body for CreateMaintenanceWindow for application/json ContentType.
+type CreateMaintenanceWindowJSONRequestBody = MaintenanceWindowRequest
+
+// UpdateMaintenanceWindowJSONRequestBody defines body for UpdateMaintenanceWindow for application/json ContentType.
+type UpdateMaintenanceWindowJSONRequestBody = MaintenanceWindowRequest
+
 // CreateServiceJSONRequestBody defines body for CreateService for application/json ContentType.
 type CreateServiceJSONRequestBody = CreateServiceRequest
 
@@ -395,6 +1161,12 @@ type CreateTeamJSONRequestBody = CreateTeamRequest
 // UpdateTeamJSONRequestBody defines body for UpdateTeam for application/json ContentType.
 type UpdateTeamJSONRequestBody = UpdateTeamRequest
 
+// CreateWebhookSubscriptionJSONRequestBody defines body for CreateWebhookSubscription for application/json ContentType.
+type CreateWebhookSubscriptionJSONRequestBody = WebhookSubscriptionRequest
+
+// UpdateWebhookSubscriptionJSONRequestBody defines body for UpdateWebhookSubscription for application/json ContentType.
+type UpdateWebhookSubscriptionJSONRequestBody = WebhookSubscriptionRequest
+
 // Getter for additional properties for Problem. Returns the specified
 // element and whether it was found
 func (a Problem) Get(fieldName string) (value interface{}, found bool) {
@@ -540,6 +1312,39 @@ type ServerInterface interface {
 	// (GET /v1/burn-events)
 	ListBurnEvents(w http.ResponseWriter, r *http.Request, params ListBurnEventsParams)
 
+	// (GET /v1/events/schema)
+	GetEventSchema(w http.ResponseWriter, r *http.Request)
+
+	// (GET /v1/maintenance-windows)
+	ListMaintenanceWindows(w http.ResponseWriter, r *http.Request, params ListMaintenanceWindowsParams)
+
+	// (POST /v1/maintenance-windows)
+	CreateMaintenanceWindow(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /v1/maintenance-windows/{maintenanceWindowId})
+	DeleteMaintenanceWindow(w http.ResponseWriter, r *http.Request, maintenanceWindowId MaintenanceWindowId)
+
+	// (GET /v1/maintenance-windows/{maintenanceWindowId})
+	GetMaintenanceWindow(w http.ResponseWriter, r *http.Request, maintenanceWindowId MaintenanceWindowId)
+
+	// (PUT /v1/maintenance-windows/{maintenanceWindowId})
+	UpdateMaintenanceWindow(w http.ResponseWriter, r *http.Request, maintenanceWindowId MaintenanceWindowId)
+
+	// (DELETE /v1/outbox/dead-letters)
+	PurgeDeadLetterEvents(w http.ResponseWriter, r *http.Request, params PurgeDeadLetterEventsParams)
+
+	// (GET /v1/outbox/dead-letters)
+	ListDeadLetterEvents(w http.ResponseWriter, r *http.Request, params ListDeadLetterEventsParams)
+
+	// (DELETE /v1/outbox/dead-letters/{outboxEventId})
+	PurgeDeadLetterEvent(w http.ResponseWriter, r *http.Request, outboxEventId OutboxEventId)
+
+	// (GET /v1/outbox/dead-letters/{outboxEventId})
+	GetDeadLetterEvent(w http.ResponseWriter, r *http.Request, outboxEventId OutboxEventId)
+
+	// (POST /v1/outbox/dead-letters/{outboxEventId}/requeue)
+	RequeueDeadLetterEvent(w http.ResponseWriter, r *http.Request, outboxEventId OutboxEventId)
+
 	// (GET /v1/services)
 	ListServices(w http.ResponseWriter, r *http.Request, params ListServicesParams)
 
@@ -573,6 +1378,15 @@ type ServerInterface interface {
 	// (GET /v1/slos/{sloId}/alert-status)
 	GetSLOAlertStatus(w http.ResponseWriter, r *http.Request, sloId SloId)
 
+	// (POST /v1/slos/{sloId}/evaluate)
+	EvaluateSLO(w http.ResponseWriter, r *http.Request, sloId SloId, params EvaluateSLOParams)
+
+	// (GET /v1/slos/{sloId}/evaluation-health)
+	GetSLOEvaluationHealth(w http.ResponseWriter, r *http.Request, sloId SloId)
+
+	// (GET /v1/slos/{sloId}/evaluations)
+	GetSLOEvaluations(w http.ResponseWriter, r *http.Request, sloId SloId, params GetSLOEvaluationsParams)
+
 	// (GET /v1/teams)
 	ListTeams(w http.ResponseWriter, r *http.Request, params ListTeamsParams)
 
@@ -587,7 +1401,22 @@ type ServerInterface interface {
 
 	// (PUT /v1/teams/{teamId})
 	UpdateTeam(w http.ResponseWriter, r *http.Request, teamId TeamId)
-}
+
+	// (GET /v1/webhook-subscriptions)
+	ListWebhookSubscriptions(w http.ResponseWriter, r *http.Request, params ListWebhookSubscriptionsParams)
+
+	// (POST /v1/webhook-subscriptions)
+	CreateWebhookSubscription(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /v1/webhook-subscriptions/{webhookSubscriptionId})
+	DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookSubscriptionId WebhookSubscriptionId)
+
+	// (GET /v1/webhook-subscriptions/{webhookSubscriptionId})
+	GetWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookSubscriptionId WebhookSubscriptionId)
+
+	// (PUT /v1/webhook-subscriptions/{webhookSubscriptionId})
+	UpdateWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookSubscriptionId WebhookSubscriptionId)
+}
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
@@ -608,6 +1437,61 @@ func (_ Unimplemented) ListBurnEvents(w http.ResponseWriter, r *http.Request, pa
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (GET /v1/events/schema)
+func (_ Unimplemented) GetEventSchema(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /v1/maintenance-windows)
+func (_ Unimplemented) ListMaintenanceWindows(w http.ResponseWriter, r *http.Request, params ListMaintenanceWindowsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /v1/maintenance-windows)
+func (_ Unimplemented) CreateMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /v1/maintenance-windows/{maintenanceWindowId})
+func (_ Unimplemented) DeleteMaintenanceWindow(w http.ResponseWriter, r *http.Request, maintenanceWindowId MaintenanceWindowId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /v1/maintenance-windows/{maintenanceWindowId})
+func (_ Unimplemented) GetMaintenanceWindow(w http.ResponseWriter, r *http.Request, maintenanceWindowId MaintenanceWindowId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /v1/maintenance-windows/{maintenanceWindowId})
+func (_ Unimplemented) UpdateMaintenanceWindow(w http.ResponseWriter, r *http.Request, maintenanceWindowId MaintenanceWindowId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /v1/outbox/dead-letters)
+func (_ Unimplemented) PurgeDeadLetterEvents(w http.ResponseWriter, r *http.Request, params PurgeDeadLetterEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /v1/outbox/dead-letters)
+func (_ Unimplemented) ListDeadLetterEvents(w http.ResponseWriter, r *http.Request, params ListDeadLetterEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /v1/outbox/dead-letters/{outboxEventId})
+func (_ Unimplemented) PurgeDeadLetterEvent(w http.ResponseWriter, r *http.Request, outboxEventId OutboxEventId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /v1/outbox/dead-letters/{outboxEventId})
+func (_ Unimplemented) GetDeadLetterEvent(w http.ResponseWriter, r *http.Request, outboxEventId OutboxEventId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /v1/outbox/dead-letters/{outboxEventId}/requeue)
+func (_ Unimplemented) RequeueDeadLetterEvent(w http.ResponseWriter, r *http.Request, outboxEventId OutboxEventId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (GET /v1/services)
 func (_ Unimplemented) ListServices(w http.ResponseWriter, r *http.Request, params ListServicesParams) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -663,6 +1547,21 @@ func (_ Unimplemented) GetSLOAlertStatus(w http.ResponseWriter, r *http.Request,
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (POST /v1/slos/{sloId}/evaluate)
+func (_ Unimplemented) EvaluateSLO(w http.ResponseWriter, r *http.Request, sloId SloId, params EvaluateSLOParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /v1/slos/{sloId}/evaluation-health)
+func (_ Unimplemented) GetSLOEvaluationHealth(w http.ResponseWriter, r *http.Request, sloId SloId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /v1/slos/{sloId}/evaluations)
+func (_ Unimplemented) GetSLOEvaluations(w http.ResponseWriter, r *http.Request, sloId SloId, params GetSLOEvaluationsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (GET /v1/teams)
 func (_ Unimplemented) ListTeams(w http.ResponseWriter, r *http.Request, params ListTeamsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -688,6 +1587,31 @@ func (_ Unimplemented) UpdateTeam(w http.ResponseWriter, r *http.Request, teamId
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (GET /v1/webhook-subscriptions)
+func (_ Unimplemented) ListWebhookSubscriptions(w http.ResponseWriter, r *http.Request, params ListWebhookSubscriptionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /v1/webhook-subscriptions)
+func (_ Unimplemented) CreateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /v1/webhook-subscriptions/{webhookSubscriptionId})
+func (_ Unimplemented) DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookSubscriptionId WebhookSubscriptionId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /v1/webhook-subscriptions/{webhookSubscriptionId})
+func (_ Unimplemented) GetWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookSubscriptionId WebhookSubscriptionId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /v1/webhook-subscriptions/{webhookSubscriptionId})
+func (_ Unimplemented) UpdateWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookSubscriptionId WebhookSubscriptionId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -773,16 +1697,307 @@ func (siw *ServerInterfaceWrapper) ListBurnEvents(w http.ResponseWriter, r *http
 		return
 	}
 
-	// ------------- Optional query parameter "to" -------------
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "to", r.URL.Query(), &params.To, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBurnEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEventSchema operation middleware
+func (siw *ServerInterfaceWrapper) GetEventSchema(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEventSchema(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListMaintenanceWindows operation middleware
+func (siw *ServerInterfaceWrapper) ListMaintenanceWindows(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListMaintenanceWindowsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "page", r.URL.Query(), &params.Page, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "pageSize" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "pageSize", r.URL.Query(), &params.PageSize, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageSize", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "scopeType" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "scopeType", r.URL.Query(), &params.ScopeType, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scopeType", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "scopeId" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "scopeId", r.URL.Query(), &params.ScopeId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scopeId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListMaintenanceWindows(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateMaintenanceWindow operation middleware
+func (siw *ServerInterfaceWrapper) CreateMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateMaintenanceWindow(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteMaintenanceWindow operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "maintenanceWindowId" -------------
+	var maintenanceWindowId MaintenanceWindowId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "maintenanceWindowId", chi.URLParam(r, "maintenanceWindowId"), &maintenanceWindowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "maintenanceWindowId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteMaintenanceWindow(w, r, maintenanceWindowId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMaintenanceWindow operation middleware
+func (siw *ServerInterfaceWrapper) GetMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "maintenanceWindowId" -------------
+	var maintenanceWindowId MaintenanceWindowId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "maintenanceWindowId", chi.URLParam(r, "maintenanceWindowId"), &maintenanceWindowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "maintenanceWindowId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMaintenanceWindow(w, r, maintenanceWindowId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateMaintenanceWindow operation middleware
+func (siw *ServerInterfaceWrapper) UpdateMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "maintenanceWindowId" -------------
+	var maintenanceWindowId MaintenanceWindowId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "maintenanceWindowId", chi.URLParam(r, "maintenanceWindowId"), &maintenanceWindowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "maintenanceWindowId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateMaintenanceWindow(w, r, maintenanceWindowId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PurgeDeadLetterEvents operation middleware
+func (siw *ServerInterfaceWrapper) PurgeDeadLetterEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PurgeDeadLetterEventsParams
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "before", r.URL.Query(), &params.Before, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PurgeDeadLetterEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListDeadLetterEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListDeadLetterEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDeadLetterEventsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "page", r.URL.Query(), &params.Page, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "pageSize" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "pageSize", r.URL.Query(), &params.PageSize, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageSize", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDeadLetterEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PurgeDeadLetterEvent operation middleware
+func (siw *ServerInterfaceWrapper) PurgeDeadLetterEvent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "outboxEventId" -------------
+	var outboxEventId OutboxEventId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "outboxEventId", chi.URLParam(r, "outboxEventId"), &outboxEventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "outboxEventId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PurgeDeadLetterEvent(w, r, outboxEventId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDeadLetterEvent operation middleware
+func (siw *ServerInterfaceWrapper) GetDeadLetterEvent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "outboxEventId" -------------
+	var outboxEventId OutboxEventId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "outboxEventId", chi.URLParam(r, "outboxEventId"), &outboxEventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "outboxEventId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDeadLetterEvent(w, r, outboxEventId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RequeueDeadLetterEvent operation middleware
+func (siw *ServerInterfaceWrapper) RequeueDeadLetterEvent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
 
-	err = runtime.BindQueryParameterWithOptions("form", true, false, "to", r.URL.Query(), &params.To, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	// ------------- Path parameter "outboxEventId" -------------
+	var outboxEventId OutboxEventId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "outboxEventId", chi.URLParam(r, "outboxEventId"), &outboxEventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "outboxEventId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListBurnEvents(w, r, params)
+		siw.Handler.RequeueDeadLetterEvent(w, r, outboxEventId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1131,6 +2346,135 @@ func (siw *ServerInterfaceWrapper) GetSLOAlertStatus(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
+// EvaluateSLO operation middleware
+func (siw *ServerInterfaceWrapper) EvaluateSLO(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "sloId" -------------
+	var sloId SloId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sloId", chi.URLParam(r, "sloId"), &sloId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sloId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params EvaluateSLOParams
+
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "dryRun", r.URL.Query(), &params.DryRun, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dryRun", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "objective" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "objective", r.URL.Query(), &params.Objective, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "objective", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "dimension" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "dimension", r.URL.Query(), &params.Dimension, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dimension", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EvaluateSLO(w, r, sloId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSLOEvaluationHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetSLOEvaluationHealth(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "sloId" -------------
+	var sloId SloId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sloId", chi.URLParam(r, "sloId"), &sloId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sloId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSLOEvaluationHealth(w, r, sloId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSLOEvaluations operation middleware
+func (siw *ServerInterfaceWrapper) GetSLOEvaluations(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "sloId" -------------
+	var sloId SloId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sloId", chi.URLParam(r, "sloId"), &sloId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sloId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSLOEvaluationsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "from", r.URL.Query(), &params.From, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "to", r.URL.Query(), &params.To, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSLOEvaluations(w, r, sloId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // ListTeams operation middleware
 func (siw *ServerInterfaceWrapper) ListTeams(w http.ResponseWriter, r *http.Request) {
 
@@ -1255,6 +2599,146 @@ func (siw *ServerInterfaceWrapper) UpdateTeam(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
+// ListWebhookSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookSubscriptions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookSubscriptionsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "page", r.URL.Query(), &params.Page, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "pageSize" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "pageSize", r.URL.Query(), &params.PageSize, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageSize", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "scopeType" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "scopeType", r.URL.Query(), &params.ScopeType, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scopeType", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "scopeId" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "scopeId", r.URL.Query(), &params.ScopeId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scopeId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookSubscriptions(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWebhookSubscription(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhookSubscriptionId" -------------
+	var webhookSubscriptionId WebhookSubscriptionId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookSubscriptionId", chi.URLParam(r, "webhookSubscriptionId"), &webhookSubscriptionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookSubscriptionId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWebhookSubscription(w, r, webhookSubscriptionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhookSubscriptionId" -------------
+	var webhookSubscriptionId WebhookSubscriptionId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookSubscriptionId", chi.URLParam(r, "webhookSubscriptionId"), &webhookSubscriptionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookSubscriptionId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhookSubscription(w, r, webhookSubscriptionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhookSubscriptionId" -------------
+	var webhookSubscriptionId WebhookSubscriptionId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookSubscriptionId", chi.URLParam(r, "webhookSubscriptionId"), &webhookSubscriptionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookSubscriptionId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateWebhookSubscription(w, r, webhookSubscriptionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -1377,6 +2861,39 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v1/burn-events", wrapper.ListBurnEvents)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/events/schema", wrapper.GetEventSchema)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/maintenance-windows", wrapper.ListMaintenanceWindows)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/maintenance-windows", wrapper.CreateMaintenanceWindow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/maintenance-windows/{maintenanceWindowId}", wrapper.DeleteMaintenanceWindow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/maintenance-windows/{maintenanceWindowId}", wrapper.GetMaintenanceWindow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/maintenance-windows/{maintenanceWindowId}", wrapper.UpdateMaintenanceWindow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/outbox/dead-letters", wrapper.PurgeDeadLetterEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/outbox/dead-letters", wrapper.ListDeadLetterEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/outbox/dead-letters/{outboxEventId}", wrapper.PurgeDeadLetterEvent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/outbox/dead-letters/{outboxEventId}", wrapper.GetDeadLetterEvent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/outbox/dead-letters/{outboxEventId}/requeue", wrapper.RequeueDeadLetterEvent)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v1/services", wrapper.ListServices)
 	})
@@ -1410,6 +2927,15 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v1/slos/{sloId}/alert-status", wrapper.GetSLOAlertStatus)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/slos/{sloId}/evaluate", wrapper.EvaluateSLO)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/slos/{sloId}/evaluation-health", wrapper.GetSLOEvaluationHealth)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/slos/{sloId}/evaluations", wrapper.GetSLOEvaluations)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v1/teams", wrapper.ListTeams)
 	})
@@ -1425,6 +2951,21 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/v1/teams/{teamId}", wrapper.UpdateTeam)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/webhook-subscriptions", wrapper.ListWebhookSubscriptions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/webhook-subscriptions", wrapper.CreateWebhookSubscription)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/webhook-subscriptions/{webhookSubscriptionId}", wrapper.DeleteWebhookSubscription)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/webhook-subscriptions/{webhookSubscriptionId}", wrapper.GetWebhookSubscription)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/webhook-subscriptions/{webhookSubscriptionId}", wrapper.UpdateWebhookSubscription)
+	})
 
 	return r
 }
@@ -1432,40 +2973,107 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xbX2/bOBL/KgLv3k6JnW4XyPktu1fsFuu7BHH3qRcUtDS22EqkSlJuc4G/+4Gk/lAS",
-	"9S+xnaTYt1ikZjgzv5n5kVQeUMCSlFGgUqDFA0oxxwlI4PrX+xCSlEmgwf0fcK+eEIoWKAIcAkc+ojgB",
-	"tLCnnal5PhJBBAlWLyT4+xLoVkZocfHm0kcJocXvSx/J+1QJEJITukX7vY9u8BZKRV8z4PeVnlSN2cJD",
-	"2OAslmhxoQWTJEv037lYQiVsgZdyV+R/vbL1uFP+z3NfWWIUvJnPB9WtgO9IAO/DUl+KZVSpE+W4jzh8",
-	"zQiHEC0kz8DWv2E8wRItUJYRNbPtrlXMunXosafJ/wA46VQgzeBTNOzVyyJlVIBG3A1n6xiS2/yZehQw",
-	"KoFK9SdO05gEWBJGZ6mZ+Y/PglE1Vun8O4cNWqC/zSpoz8yomOXyjeYQRMBJqsShRaHaC0FiEguvWNe5",
-	"dkQuQMm/ioHLlcRSLw+HIVEScHzDWQpcEmXJBscCfJRajx4QVi/+Qaj2J1CFno9onXGKfLTmgIMI3bVc",
-	"5KMtxxtM8X9wAiLFAfxJtICuebdZDL9xlqVDk7rkxFjIK+VpCFcpBL9jEXXOe8c5452jtxAwGpAYwitZ",
-	"Q0OIJZxJkgBy2CsKTA+Ax0dCYpkJh/q9jcmPZSZUAWg5wu1mh1NLrW5HVQFk688QSLXMCjBLopxSYXsC",
-	"eIiEpP5HH84tjO7LFWHO8X3LOUaea92/ZJy+2xW5N36poN75oKXVcf5JSMwlKL/qnyq1Cc2qBxwEi3f6",
-	"NyhkfVpn4RbkJ/ge4UzI9gCHgO1AWeLKHDIORqTV5lpT2FpV7Ik4tnvAMJbHo55lPADnMmXEQUQsthOb",
-	"ZsladSUf7XCcgWOkCQil1W5QRfpUcS1k2RprTipX2fJuL9BOlh8VtFvp4RueMdRI8JZQ3Yk6EioX4zL3",
-	"Vw5Ywmp5fQtfMxBT04ulQEXMNLuq2NTFExHYLJlW/AuFPbaY2Y+zJwGJQyxx92uGVbR0GxrSyzFdXmHf",
-	"KPCK2IzIzGw7WU3DnXqpuaj6CrqdqiY8zqOPdMyhDXWZ9jvgWEaPzPGq3xddhX1xFP4mlM1brtVYWTxt",
-	"JUWJ6NsG+NWmYnCmZBLHtWlz577CtivfDlk7FyPFaWjOewdSrG5kwEJ3mzEU2TlEqJCYdrSnFl+zPUBk",
-	"3NHUciZRJSong/VLmjZlpFqcTdtUWuBy1S3g8P5gAOVK2pMwulpeT1xFoMvHJK4ykimNbz08o1rXQBtV",
-	"TTCfOZkxZWk4zcwhnlNYV63et3xpK+yI08noi8LEMxAXK1rTrFPt3RDC5rYgiEnwJWKZ0KWMswRkBLVM",
-	"sMpOKSXfug5gsLa/dxSWR7ZJzjIJI9RLzLcgcwVl7XcU+Iqh1/h7/8yGH2OsGXa5P+Jq6+dyYiaAv/ue",
-	"AifQVaa/ERqyb/8mNJM5Q+s/7HL0/9z2pqzCeX5Zn63tQwMkzXg7AWmy98XUxx+Syh6o0nZT4ElV1kT8",
-	"dJU2R9gzVFvlnReD7FNuKY4CuPEQU34/Gb50kJ8BXH9qFxz9DKKxrL6ThHxFf50kHPIkwTj1BzxJ2Ou9",
-	"5oaZazr7HudXRiXHgTzbEC6kd3Xz3tsw7knAifC9nO8L31str70QNoRqXwjfwzT01hmnnj7nFOf/peUG",
-	"cqGor6cls9i7iTEFJRj5aAdcGL3z84vzebFJwilBC/STfuTrazPtzlmkjz/Unzk1VM7WKawghH4DaQ5I",
-	"UONe7M183nMXNu0OrHEE47gKW5IdUBDCCyIIvujISLwVKiq5AXfq2cxsbnuM0XvpY9pS36w7TFETiG2L",
-	"j36e/9QltlznrHkR2emD3cVMgebMgKbTGaqhlIfOpjJXN+0f3auppsz0tfjeHzVPHwapua577tq5/viL",
-	"4A5h+cXAkwVtOEvccnp7vluYZNNF3R0Ro+7bDdcNtOnnUC9DNejZUCvxV9S0XvCtiknPBr065R+PmWPG",
-	"xrWd6I1MTIT02KbsI/XwlJG4UxSOCUcsahcm+bcTIOQvzFTSg1jlvJTZ1xusYkL7lmcvDu1ZlzfzIS+n",
-	"5OeqKL81QZ1YlNV7bx/53j+f1gSsUDfScPZQFtm9oScxmAOjOhL+pZ/bSKiF4m2b2hSOMxILx709mCF+",
-	"ZyPvXOX8lIDZsIwew+ppBbH6tkuVpjRzeKy2lzlSkjv3S6OS/KQxy/faP0ySx2ygzy6vXx29O2qHbVyL",
-	"jOquyomNzqr8PthVl9eTXd/4xtX44mgtuTpvOXU7Xl47s3R5/crbsMGFlZ2zB70tGdN6NVxGtF19UnDI",
-	"lluAubPdulY2PwUYDtliy5Sd1l71pnKotb7cTG+drJ66J3cH93X34s5Mn+nves+qjy960qr8MFZ/DXK0",
-	"IHR88uuIi57p8fxLaaKVecYUfW6JqeqGLyIjc9frk9ReDvRBzzg+CTomb2ndAo0iLto3deZi3DVEXfQ9",
-	"0DGph30HcGLuYe642u5Tzw/EPp5YW4oY2QifPZj/bxlBJMrgDTEJbfFBqUSFrq6i517c/DTRPSCdsPJo",
-	"UlnJTxwHCMUR8699B3diRtAboVfNCcq8zT8eLCDRuM5iAY69EHYQszRR7vRRxmO0QJGU6WI2i9WEiAm5",
-	"uJxfzjVUcg3FDWhx56Pak/Xfb8J+UB4W2M9Ux7V+2+f3+7v9/wMAAP//9cE4tH85AAA=",
+	"H4sIAAAAAAAC/+x9W3PcNpbwX0Hx+6rmYSmp7SRTWflJsT1JdmTLUTvlqs26UhB5uhsjEqABUO0el/77",
+	"Fi4kARK8tbolO7UvtpoEcTn3c3AO8CVKWF4wClSK6PxLVGCOc5DA9a9fU8gLJoEmu3/CTj0hNDqPNoBT",
+	"4FEcUZxDdO42O1Ht4kgkG8ix+iDHny+BruUmOn/2/Mc4ygmtfv8YR3JXqA6E5ISuo/v7OHqDCZVAMU3g",
+	"A6Ep2/6a1uMWWG6aUfNAyzji8KkkHNLoXPIS3JmsGM+xjM6jsiRpFBr7qpQ37PPrO6Cyd1TmtXnYeO/w",
+	"GuphPpXAd804hXrndpfCCpeZjM6faSCSvMz137ZbBYs18LrfJfn3YN/6fbD/HxaxwpoZ4PliMTrcEvgd",
+	"SaAXZKJ+/zBwLTPWP0bGHtz/e8B57wDSvHzYCB/gZsPY7bK8EQknhSSM9g64DbZ9yPj36mNRMCpAc/c7",
+	"zm4yyK/tM/UoYVQClepPXBQZSbAa96wwLf/jX4JR9a4Z8/9zWEXn0f87a8TImXkrzmz/ZuQU6mVE59XQ",
+	"KAWJSSZQNa9TDSbbger/IgMulxJLPT2cpkT1gLN3nBXAJVErWeFMQBwVzqMvEVYf/pNQDV6ginr/iG5K",
+	"TqM4uuGAk030sQOiOFpzvMIUv8U5iAIn8DvRHfS1uy4z+Jmzshhr1NdPhoW8UJCGdFlA8gsWm952rzln",
+	"vPftNSSMJiSD9EJ61JBiCSeS5BAF1isqnhohnjgSEstSBIa/d2nyj5oTGwR0ABEGcwCo9ahhQDUIZDf/",
+	"gkSqaTYEc0kUUBrankE8RELu/zFE582Q0X09I8w53nWAY/oLzfunklOtVWZONSU5UEEYtQq6g7e6gejv",
+	"OfBZZ4KgJvdeP/UZ6k8hMZegEKh/KhlCaNk84CBYdqd/gyLhP2/KdA3yT/i8waWQ3RccEnYHXL8gVJSr",
+	"FUkIUPlniiUOPWuGCPE0mUbgpGPsdJoYaJA76HmrNN1M/nN15zgPTudWVvIkPE254SA2LHMFEi3zG+Dq",
+	"7R3OSgi8aRNyGrmTj2u2b8ik6ssd0QNSPcsO7AcZ5NH4uh6xy9axsc/GFCBeE6o1aI8gsN30LfcaS3hP",
+	"gM9cZcbo2ljEbwgtpcfgtd1W2RlfQjR5B5zIMAuIDeNyQu8elTW8wMqbzGGEHurSU3Mm4pNQd33BaYWg",
+	"+hJnQFPMTcuZcE1YacTzkD2sVRaX70kPcCXJ4b8ZDb8sKZGueN0C3EZxlDOqLcNPJeYSeEDIteCn+4nt",
+	"hJ0xgyBRdCuIhJcVAc8Ey3ShtAWy3pgFfk6yUpA7eFOB0hizNWQXYyRSyRvb5+DKlpdXc63H9ZrD2rCu",
+	"hw81GKQXd8CNf5YTGsURpmHN4/vWk4ROAB1jRoU7WW/MIFA4YA2Ra/hUgpiLbFYAFRmzXFB79g/UbG3c",
+	"OnqlGnBgLab1fuvJQWJtVfR+ZgizM3YlPQfiGyGosC0F3jiaEzR+uZ49TI80VV35M+gHqmqwH0T3BMyh",
+	"Fxpa2ivA6SVICXwfS7tis6nGWt2+Mpq7LaSEvDDCwfePX98B36EVVu4cSiEj+rdtHyOWpSAkWhEu5Cm6",
+	"otkOcZAlp5CiFeMII0HoOgOkrbHTKJ4me17ZgS7MOCGzJ9HUMcvETWuoz/vOcziOaNQPu9YF3mUMp7Pl",
+	"AwfJdy8ri8HH7j/CeBUKawkgubGIQ1ssENBPJZSQIsaRmiriYB6cRsFoXMdK9+kw9ujYt9ertXqz78DQ",
+	"hZhLEB1MT2DBR7PlW+M+hUXf5q6Z0sd89ZaFzW37eiaH9VB9276oh64+8ccLLfa18v00oC6SyoqasVrI",
+	"iaxltO38hrEMMB0TDa3JNz0NT3MfjTAmoUZFz37Cpb1Ch4M7rFqNMLL4z0WGKd4DUbjG7hDbdKjhPo5M",
+	"rOd1HQMKIto0uoYcE6pgNsWXNFGnaxsynti8crODJnxGME2m9tYOyPmi/1X1FoGCOaGQxlplLy+vBNoS",
+	"uUFrFfr8aYduYSdOgwrVGaHXdqgbWXVSIQDZ0JpnFPRF/2rZeKggYsp31yXtYWqJLTUQRpeQMJqKcW/b",
+	"rmwP0yIAOrMBaJSvaAOuUsSx0sRbVmZp9QgRijBK+Q7xkk62ttqyJwB2Qp3t0O50L5CzB4q2OqZh8Gsm",
+	"v7y8ipFgiDKkaBzpyIRQ069itC6BOahwA6yvrHfUbeVFQ1uArF41ZP4C4RsBVBoDlaKSUpwrs6ZqGqT1",
+	"+m0vodctegi9zV46qISz5jsxjxXUXmrbBJmG5t/0LmxPl7tXJdet3vhEvwgRPcfbpROk88FSvUFspUGy",
+	"ImopSBLg6AZWjANaZbhAKc4L9QLTVLezQ55IjhXu0brEPD0NB62HAoSTw0FqRvvAUcvqABgNA+zTowkH",
+	"XoNQG+Bj4ZYm0t1InlqyNfTRzKdaqY83L7xZqyBP4TiaLMCTXe3YVapxpaBrmdeltmHT4BfAmdzMjpRS",
+	"AUmpuEv5OiWHCUStd/iMWTlHlqvP5vDO+E6qfjt3CvqjpbQ+RL0/hpNb0FugKawIJRYXVT5GAVwQIaOP",
+	"PZ3+NlcwqI+WZZKAEMfeBa6WWABNDfExFas2MYvxEHXFRfUGb4hmOtgNg2WYgt8xMtuy/wvbnWyltO/y",
+	"8qprbdYqmskN8C0RYZ38JIbaBAgc0GpJiSgyvFP5CfvZLbNV+qBOfahmG9Vpvi6bp5i6WqhWNQGd1QZM",
+	"s7hhLjbG00znlK8DZuNPrKQpahIdNWKVAaSn9jeBigwnoPYagYtY2faMp8BdKzFgfFRhb3+sBmZ6FBOV",
+	"PdEDoeXlryJuWA1JJnEWozVjOtZ3g8PGl4TPcoBInE3ZkbQ9F//b1naqjaTrsWIDx2H0LGujeAZ+Vpzl",
+	"02VBoeT4PjxgFEAAZzNMVTZ9npKXNME2pOGTw/sNII7pGtAGshTlyhY3y0Jyg42rnpGcyBeIYs7ZFhGJ",
+	"JEMCjHfDQciQx9ajWzV89eTdWdWQHMFomed4Nssp9U/ouifwbbywDROAMixBSNdfM5bDaRRPMeD2USYi",
+	"Yy87W/iLni38DIbWQFk9dUi1IiUGe/pLCkKgOmNBiZAkK5WRhKiSrCfNl4wa93NoOl3kVoF5D9jerIdR",
+	"u0dOiXEiwxo1mG8ybAz05p7YRK73s7JH9kpZGZ7gEdNXOksMZ7RYiA9jcs88lpmm5Swr7JBqyDM57Cym",
+	"AMR68nNt/lnW+3Gh2NhNYS1ig305SDd2Y7cNdd7PC8TUvnDTkf1GoA2+A4RNSJBjCeEY4LHx6K0xhFQT",
+	"edhzd7Drp7LbCU6p+So0GycQew1JyTnQZO6kVmosoMnOnVeKSbbTiUxwm+2qbK9sF05lpRL4Hc7aBSE+",
+	"gVxDAVgpKGVmyg0RKMd0h1K8EzFS4+gYsB7H1z5BUVhSSbIuFb5liCUVIKrQMl5J4GZISRzfiesZaVNb",
+	"zUoNOkVnt9DTwG8EQ8uEFdDOVZaA8yZp1aSsBoHcKUHaY08s5F5+2IAy9pUT6UCOCOViwGoFiUSUbcPc",
+	"uFfehTN4QC0CTWcFiXTOXgr/4Cz/Sbt8YWtgYmLGgAXgcteQhR9mSV0/worJKdUuqUwcrSGvKt1zFiCH",
+	"U0CLdB6iQykflQFSz7OBiTPhmgScKYXQHFcU7Wd7NDMd4UbDRI+W6tEZ+SmSPTqT2C+ZbjoPt7bGaOps",
+	"/wjpCJwX2vXizhNh8oqwQMoOnSqbewRCrZXsevx5XQK2G3XGRPibQLmxEhArpZqyTjBBJqaEcKJNGWJm",
+	"1ZU1e+YaHlbEdI0zpWliZBUNMruPzqKRLq0DgSTzgP0oIqrlzKo3fZQynRJcieYP8OvF2wttCqB/Mwqo",
+	"gbxAtwCFGpdwtMVZdpJkLLk1bQl9gSwhKSih39+/PI2mZn9OFHohrnU4fh6fVuJk2JIqnLrc4ZY6FjgW",
+	"p2it31YMF01xr+kluFBbmjmSeNT2G9OwzjJVnMFXhApZ+UjjJYUuBIjMYGBD3lXtnIwShzQEYXr1dp1S",
+	"iOoVBEFV8jW00gfFnoqsUH2loQW3cWkahuZzDTjd7Tl+E22bHj2t4oDBzT+uJvMgv2p+bcYepvBEm3R6",
+	"cQNXWikfFciqzMK2nF3rdxBDMFRJ0cx+jj23vLx6NAtO0cQT2GzLy6srd3Nwj6KHri1QbxP+Tfi7ipAX",
+	"cjczGapweS9TSMskRHG0lvofCLqzEnNrm9WnOjwbrLRqRT/ba7KvkII+5pAivMZKzJtEq5cZSW5/YaUN",
+	"8dNkpxOMVD2cyIjS+4SmJMGS8b7I93BU1S6nB4HXDWPOS67m5KYKdE2j0QLTi+qzN1gmm3BS0g1Op3S1",
+	"hAwSybj9pN7t7GDTWMaErt+A3DDvdIOrxqKP4qiBeJAqbpz60nklsdVXodUmtr5ytMrNr8OsSuUEkTC5",
+	"QM5KiRRLbOqI2wGfRJHiRpFipBGeg9xAWcUjzWAh0DQ9/k4CHPC6Zty6G51eGKPthiQbE16lDDXdWAOb",
+	"cMS2NJzS2wzJsx4ba9gTVNvGcwlNfdNPaTYzJOA0FJiimmV05ohyct7VEEYZvoGszkFVIa5my6sADbgb",
+	"K6YRW83Lv8yJyZcN7eyriHYV5dbZLSvYAq/yeTEHxKFgXE0DK0HkBMcVBsZjoTmhS8VTfTNwxFx4fLVx",
+	"KBLGIR0fbN8Swxkps2zVIImiFJJMS3Sd8PACGVFrE0QrsZ8T7aHXDuPkfGdPvQYQy1kp+wrStRXztrdg",
+	"vcC0Ouol+LL3y4fqxuGWJAdNLdN3HasvfisxlSSDeTOrnMY5MkB/1C8EZEumWp1en9jBTQ6QTucZFayl",
+	"AP76cwGcQJ9L+JBdJ99AiDu7UIbA4toXdDZgW0qkrQOCxoYhyq/GeflLVjIfOB7erYCe5QIZjD+eG2TG",
+	"exJXKGTdzlvrrUlDHaMiz6WBTyXO1MQokyf1D0IjNf01fA5KFWVZtMA7YkS0oHGrK+VYEdV99YGkFpxf",
+	"jXvx+EozVNalmOmrEYSPeQDBUeTTdImk4P5o4kgj+Qlk0e8aBEc/saQ1raFzR+yM/u/ckUOeO2KA+pc8",
+	"d8Qe+/nSHK75xm6j+J7Zy4yVqXErkT2FE+UsrV00boAiTpGQvExkqfy0d1fL98bN3m5YddyHcm3dwzsT",
+	"1bN+I/QBni+Q8r35Dgmgqfm6UVDq4wROkDniV9TFgYpW1Tv19w1Ld6folbM/10xJeYOVNm+eRnFkxgwq",
+	"8OpU1AlJO0OfOwelzi9XcxEzJMgCqNwvOwcovsn6yt7rkv5ZZs3Dc2+OkjjTQa91dFkp3/Rs/s1WqnFU",
+	"8sxrPGVTcDxXRvXqoSP2iMVdSIPSORo8QLyPptADYz+Ffg9MYz8N8GA2dniyVT3nyDqll8PZKD7XdgJv",
+	"VCL1je6kKMWm2gJivMqSVE+VCM4yK/VzL7j2TR262lcfNSRzwpk0CkBVLo2pHVHhSiemqsGWHjaVJiS0",
+	"BCQcAkk0v7y5eHmy/OXi+Q9/R4KsqSr0uIXdKbq2bIAYRUYkvEAsJ9JUfiAjGXQejFGsZvtGIkbhNPJN",
+	"kL+3FxNHW04kqLO/jK3YlqqtA7eBn1gTAtlmforND4vFQg9aRRu/Wyz8s98XwVIZK3f90TZSFgpt6n+B",
+	"gKa64qg52MrgTVkvkHayoCYI7lGZ3ZUz9zoZZcUClhejkuNEnpj8p4t3v5qKQMC5qLO4RKyprqniFrG2",
+	"jXQSvVnT6f/QOsNElwkh3TPL0LsMU1AdR3F0B1yYcRenz04XVdoBLkh0Hn2nH8X6JHiNxLNNXX5v49RK",
+	"4OHq4PjoZ5C2QL91tvvzxWLgPPd557i3EvEDx7lfkjtT9JRsILnV6JJ4LRSq7AI+qmdnJl1kYDE6ueWY",
+	"a/GzZwJLUQ2Iu5Y4+mHxXV+39TzP2ofp98Lg7tmZIpqTphwkCAxlBdQHEBv92dzM8Ud4Nk2TM321xH08",
+	"qZ3OFruP7S0ErbsivDOep1910NNZxg7Tka1sDPQzGHkJdybZ/K4+HpFGwyddh25RMFYX+GLIIz2X1Gr6",
+	"gztnvCF21LNYmmazFmy6DtwXEZDK/pr+a3n1FpkhK9fXdY3V78SK1UKLVciJNGXajmPsetATAOIco3Ti",
+	"1Nr3MmYnu/uRGLRlILG1KSgx5xoZBRijjAgpTPFXk3OsHqgaYxlMTj6N4iBn+Ap2Cu2GU5B75UGTnztZ",
+	"IhyT84aLFgY5sHsSl4irImdtq1e75fdx9P1i0TeTaQrFGSz6qEvjRYBSzUm+nTXZO2RAyJ9Yujse7Con",
+	"7t633qyt2sLhs+PNI4S3N4Fz04zPfgwE9UuZsy+BW6zujY2agYQuUl/p52GkehD9vmvpBlZthqlW/f1h",
+	"ybJPrUyY/eLJ6WHFSnokuMzTFKEb0ZQULMoAdE0U/dvg+afHsY3NPYDnD04dVlaYa+bOUsDpSQayupCv",
+	"TyoEixS6NknruCNlIuhKg8ord4aDtDossFvNq7/pxqkCCt508XWZ18MFHQHCeeVBxYJKwyD1bUuDtH7R",
+	"p6yJcSQd3HA8JjCHzvMetJd8UmPuwasxypk+5zwBKrNdq2llRoXg3s88Z1+8ixvv5zLTJP0aIJSGTg4g",
+	"Kcbo62eQo9M+Guonco5xVogWNq3j7w8OpHmM5d/+ef9xOjGd2SP52xeozh+zz5S/NgPsQ5S6pUrBtgc1",
+	"mnINgwaMVhzEBunj/m3R7YGxYKFYhVEH3epl1ejJol1+auDX4ZSG0g4HRasKACjFXMHcF5bV01HHcVkf",
+	"lHEM0zF4d88ju4rVCgPQtK8O4BXuy0zqu/98GBM6qG6x4dmXOq47wdt0KWFM3FSAO6hj6dFsn+rrneXi",
+	"MQnmgG6jz6mzBGJzJfOIp3hcJg8myj2ybzgBZ0/nBh6HyTM2omcvr765HaWjathWbfMk7aqA2NKsGbO8",
+	"OqRVL69mg751Db+BxdFUcpNo+9jq+PLKDNk5cPMbV8MZa3Hn2Re9EzpF9WpymaB2dXLCIVVuRcy96jY0",
+	"s8VjEMMhVWzNsvPUq8LeqGr9ejm9k1L/2Dq5H7nfti7u5fQzfR3+SXOCygBb1ffJ61r1oyGh56b8AF50",
+	"S8QhYTQhGdGDIbOU6gALvYn8FXBkCPRV7fn88IzD51alt2Mrpuem1J2ybax/bPHOvZCIcZQy0LvwgJMN",
+	"kiS5NRlk9oIkUR3wXWbyFH1Q0RlzpYyXGVhd4yT1uZEcsEr9kIgyuVGhHR3l0VeKmPsiwSQbnqJ/1Fjq",
+	"3vpgzwltLgzzyvXVFBnVwzlXOWXkFvSh9atWx+HblXwqr6AWFJAhi7G+W6ch7PYxc90T0Sdd7qAyH+2y",
+	"/HRI6R7dYpamHm3IegNC+mfnhqZcf+vNOpCQ1HdNxy3sBifXoGv+5OpvByd3TIs7fPVgQPQ0DS1vNMu1",
+	"Fy3FzXpNuUoKCdGAuYENoSkiDwlpxtH3z5/v9d0Pi32/++5oSqg5/+tkPLF0eXnVuQbqUUjCjhXKM/Uu",
+	"DWBc7RslLIdvSAtVdyiuoV+fKOLdECEZ3zkra1/7/KolEfQhns+/RxtW8oDUbWNUTJO9T55p2dOVviYj",
+	"rBKem8T1+tiMdiJ74CCJx5F19pKUYTEndKP6UhoFFXNnyJOYxIeSRg/kJJ2MPxjTeq9bfNub6Z1y7kmB",
+	"KA0bPxKlH42Got6b2srjhZLcYt5HjiXptQXAp54fKJr0QF+xwpFL4WdfpN57nBAYqpE3FhnSKz5oaKih",
+	"rj7TITy5xeNg94DhIYePZokVu4M8EiA6Iv91i+kfOcIziKFvOsbT4tutKRQ8EU756rCmCtS7fsXlA966",
+	"TLqfLSJw6jIPVz/Qrbv8VmoHxiq5B3W5pSIf2jGisG0M/oMkptuBxo2DwHKOJKsG6r8f2WgIrTmAtg8B",
+	"ZB22fMDB0pCYOfuy7c54kvXQh90xYyK49IMaFx6B9tkXk6a/+Cro4oDWiM+7s9TFhxCdjNkn344I+DpQ",
+	"/VVUE7iiw54CF87+v2QJzlAKd5CxIjfZpLqYX1fvn5+dZarBhgl5/uPix4UmFjtIddhSVdh8H9dPjHXk",
+	"PKjTU9xnGfN+uzWZzmO3MMJ5bFNLnSf1mu8/3v/vABFbgQnRoAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -8,8 +8,14 @@ import (
 
 	_ "github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/google/uuid"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/events"
 )
 
+// ReplaySource prefixes the source of events written by a historical replay. Alert rules ignore
+// them so a backfill never pages.
+const ReplaySource = "slo-replay:"
+
 type Event struct {
 	ID             uuid.UUID
 	ServiceID      uuid.UUID
@@ -20,6 +26,11 @@ type Event struct {
 	ObservedAt     time.Time
 	Source         string
 	IdempotencyKey string
+	// Objective names the SLO objective the event is for, empty for an unnamed objective.
+	Objective string
+	// DimensionKey and Dimensions identify the group-by dimension of a grouped SLO.
+	DimensionKey string
+	Dimensions   map[string]string
 }
 
 type Sink struct {
@@ -49,12 +60,23 @@ CREATE TABLE IF NOT EXISTS slo_burn_events (
   threshold Float32,
   observed_at DateTime64(3),
   source String,
-  idempotency_key String
+  idempotency_key String,
+  objective String,
+  dimension_key String,
+  dimensions Map(String, String)
 )
 ENGINE = ReplacingMergeTree
 ORDER BY (service_id, slo_id, observed_at, idempotency_key)
 `
-	_, err := s.db.ExecContext(ctx, ddl)
+	if _, err := s.db.ExecContext(ctx, ddl); err != nil {
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, `
+		ALTER TABLE slo_burn_events
+		  ADD COLUMN IF NOT EXISTS objective String,
+		  ADD COLUMN IF NOT EXISTS dimension_key String,
+		  ADD COLUMN IF NOT EXISTS dimensions Map(String, String)
+	`)
 	return err
 }
 
@@ -62,10 +84,82 @@ func (s *Sink) InsertEvent(ctx context.Context, ev Event) error {
 	if s.db == nil {
 		return fmt.Errorf("clickhouse sink not initialized")
 	}
+	dimensions := ev.Dimensions
+	if dimensions == nil {
+		dimensions = map[string]string{}
+	}
 	_, err := s.db.ExecContext(ctx, `
 		INSERT INTO slo_burn_events (
-			id, service_id, slo_id, event_type, value, threshold, observed_at, source, idempotency_key
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`, ev.ID.String(), ev.ServiceID.String(), ev.SLOID.String(), ev.EventType, ev.Value, ev.Threshold, ev.ObservedAt, ev.Source, ev.IdempotencyKey)
+			id, service_id, slo_id, event_type, value, threshold, observed_at, source, idempotency_key, objective, dimension_key, dimensions
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, ev.ID.String(), ev.ServiceID.String(), ev.SLOID.String(), ev.EventType, ev.Value, ev.Threshold, ev.ObservedAt, ev.Source, ev.IdempotencyKey, ev.Objective, ev.DimensionKey, dimensions)
 	return err
 }
+
+// InsertNewEvents writes the events for one SLO whose idempotency keys are not stored yet and
+// returns how many it wrote. The table only deduplicates on merge, so re-runs check first.
+func (s *Sink) InsertNewEvents(ctx context.Context, sloID uuid.UUID, events []Event) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+	from, to := events[0].ObservedAt, events[0].ObservedAt
+	for _, ev := range events {
+		if ev.ObservedAt.Before(from) {
+			from = ev.ObservedAt
+		}
+		if ev.ObservedAt.After(to) {
+			to = ev.ObservedAt
+		}
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT idempotency_key
+		FROM slo_burn_events
+		WHERE slo_id = ? AND observed_at >= ? AND observed_at <= ?
+	`, sloID.String(), from, to)
+	if err != nil {
+		return 0, err
+	}
+	existing := map[string]bool{}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		existing[key] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	written := 0
+	for _, ev := range events {
+		if existing[ev.IdempotencyKey] {
+			continue
+		}
+		if err := s.InsertEvent(ctx, ev); err != nil {
+			return written, err
+		}
+		existing[ev.IdempotencyKey] = true
+		written++
+	}
+	return written, nil
+}
+
+// FromEvent maps an SLO event to its slo_burn_events row, observed at the event time.
+func FromEvent(e events.Event, idempotencyKey string) Event {
+	return Event{
+		ID:             e.ID,
+		ServiceID:      e.Data.ServiceID,
+		SLOID:          e.Data.SLOID,
+		EventType:      e.Data.EventType,
+		Value:          float32(e.Data.Value),
+		Threshold:      float32(e.Data.Threshold),
+		ObservedAt:     e.Time,
+		Source:         e.Data.Source,
+		IdempotencyKey: idempotencyKey,
+		Objective:      e.Data.Objective,
+		DimensionKey:   e.Data.DimensionKey,
+		Dimensions:     e.Data.Dimensions,
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -12,15 +13,24 @@ type Config struct {
 	HTTPAddr                    string
 	PostgresDSN                 string
 	ClickHouseDSN               string
+	PrometheusURL               string
+	PrometheusHTTPTimeout       time.Duration
 	OTelServiceName             string
 	OTelExporterOTLPEndpoint    string
 	OTelExporterOTLPInsecure    bool
 	GrafanaURL                  string
 	GrafanaToken                string
 	GrafanaFolderUID            string
+	GrafanaEventsDatasourceUID  string
 	GrafanaHTTPTimeout          time.Duration
 	OutboxPollInterval          time.Duration
 	OutboxBatchSize             int
+	OutboxMaxRetries            int
+	OutboxWorkerID              string
+	OutboxClaimTTL              time.Duration
+	OutboxRetryBaseDelay        time.Duration
+	OutboxRetryMaxDelay         time.Duration
+	WebhookAllowedHosts         []string
 	AlertReconcilerPollInterval time.Duration
 	AlertReconcilerBatchSize    int
 	AlertDefaultLabels          map[string]string
@@ -31,6 +41,23 @@ type Config struct {
 	EvaluatorSlowWindowMin      int
 	EvaluatorFastBurnRate       float64
 	EvaluatorSlowBurnRate       float64
+	EvaluatorMinEvents          int
+	EvaluatorMinSliceEvents     int
+	EvaluatorForecastSamples    int
+	EvaluatorForecastHalfLife   time.Duration
+	EvaluatorResolveRatio       float64
+	EvaluatorResolveHold        time.Duration
+	EvaluatorStartEvaluations   int
+	EvaluatorStartWindow        int
+	EvaluatorLeaderElection     bool
+	EvaluatorInstanceID         string
+	EvaluatorLeaseTTL           time.Duration
+	EvaluatorLeaseRenewInterval time.Duration
+	EvaluatorConcurrency        int
+	EvaluatorSLOTimeout         time.Duration
+	EvaluatorSharding           bool
+	EvaluatorMaxGroups          int
+	EvaluationHistoryRetention  time.Duration
 	ShutdownGraceSeconds        int
 }
 
@@ -39,15 +66,24 @@ func Load() (Config, error) {
 		HTTPAddr:                    getenv("SLO_API_HTTP_ADDR", ":8080"),
 		PostgresDSN:                 os.Getenv("SLO_API_POSTGRES_DSN"),
 		ClickHouseDSN:               os.Getenv("SLO_API_CLICKHOUSE_DSN"),
+		PrometheusURL:               getenv("SLO_API_PROMETHEUS_URL", ""),
+		PrometheusHTTPTimeout:       durationEnv("SLO_API_PROMETHEUS_HTTP_TIMEOUT", 10*time.Second),
 		OTelServiceName:             getenv("SLO_API_OTEL_SERVICE_NAME", "slo-control-plane"),
 		OTelExporterOTLPEndpoint:    getenv("SLO_API_OTEL_EXPORTER_OTLP_ENDPOINT", ""),
 		OTelExporterOTLPInsecure:    boolEnv("SLO_API_OTEL_EXPORTER_OTLP_INSECURE", false),
 		GrafanaURL:                  getenv("SLO_API_GRAFANA_URL", ""),
 		GrafanaToken:                getenv("SLO_API_GRAFANA_TOKEN", ""),
 		GrafanaFolderUID:            getenv("SLO_API_GRAFANA_FOLDER_UID", "slo-managed"),
+		GrafanaEventsDatasourceUID:  getenv("SLO_API_GRAFANA_EVENTS_DATASOURCE_UID", "clickhouse"),
 		GrafanaHTTPTimeout:          durationEnv("SLO_API_GRAFANA_HTTP_TIMEOUT", 10*time.Second),
 		OutboxPollInterval:          durationEnv("SLO_API_OUTBOX_POLL_INTERVAL", 5*time.Second),
 		OutboxBatchSize:             intEnv("SLO_API_OUTBOX_BATCH_SIZE", 100),
+		OutboxMaxRetries:            intEnv("SLO_API_OUTBOX_MAX_RETRIES", 10),
+		OutboxWorkerID:              getenv("SLO_API_OUTBOX_WORKER_ID", ""),
+		OutboxClaimTTL:              durationEnv("SLO_API_OUTBOX_CLAIM_TTL", 5*time.Minute),
+		OutboxRetryBaseDelay:        durationEnv("SLO_API_OUTBOX_RETRY_BASE_DELAY", time.Second),
+		OutboxRetryMaxDelay:         durationEnv("SLO_API_OUTBOX_RETRY_MAX_DELAY", 5*time.Minute),
+		WebhookAllowedHosts:         listEnv("SLO_API_WEBHOOK_ALLOWED_HOSTS"),
 		AlertReconcilerPollInterval: durationEnv("SLO_API_ALERT_RECONCILER_POLL_INTERVAL", 30*time.Second),
 		AlertReconcilerBatchSize:    intEnv("SLO_API_ALERT_RECONCILER_BATCH_SIZE", 100),
 		EvaluatorInterval:           durationEnv("SLO_API_EVALUATOR_INTERVAL", 30*time.Second),
@@ -56,6 +92,23 @@ func Load() (Config, error) {
 		EvaluatorSlowWindowMin:      intEnv("SLO_API_EVALUATOR_SLOW_WINDOW_MIN", 60),
 		EvaluatorFastBurnRate:       floatEnv("SLO_API_EVALUATOR_FAST_BURN_RATE", 14.4),
 		EvaluatorSlowBurnRate:       floatEnv("SLO_API_EVALUATOR_SLOW_BURN_RATE", 2.0),
+		EvaluatorMinEvents:          intEnv("SLO_API_EVALUATOR_MIN_EVENTS", 1),
+		EvaluatorMinSliceEvents:     intEnv("SLO_API_EVALUATOR_MIN_SLICE_EVENTS", 1),
+		EvaluatorForecastSamples:    intEnv("SLO_API_EVALUATOR_FORECAST_SAMPLES", 120),
+		EvaluatorForecastHalfLife:   durationEnv("SLO_API_EVALUATOR_FORECAST_HALF_LIFE", 30*time.Minute),
+		EvaluatorResolveRatio:       floatEnv("SLO_API_EVALUATOR_RESOLVE_RATIO", 1.0),
+		EvaluatorResolveHold:        durationEnv("SLO_API_EVALUATOR_RESOLVE_HOLD", 0),
+		EvaluatorStartEvaluations:   intEnv("SLO_API_EVALUATOR_START_EVALUATIONS", 1),
+		EvaluatorStartWindow:        intEnv("SLO_API_EVALUATOR_START_WINDOW", 1),
+		EvaluatorLeaderElection:     boolEnv("SLO_API_EVALUATOR_LEADER_ELECTION", true),
+		EvaluatorInstanceID:         getenv("SLO_API_EVALUATOR_INSTANCE_ID", ""),
+		EvaluatorLeaseTTL:           durationEnv("SLO_API_EVALUATOR_LEASE_TTL", 30*time.Second),
+		EvaluatorLeaseRenewInterval: durationEnv("SLO_API_EVALUATOR_LEASE_RENEW_INTERVAL", 10*time.Second),
+		EvaluatorConcurrency:        intEnv("SLO_API_EVALUATOR_CONCURRENCY", 8),
+		EvaluatorSLOTimeout:         durationEnv("SLO_API_EVALUATOR_SLO_TIMEOUT", 20*time.Second),
+		EvaluatorSharding:           boolEnv("SLO_API_EVALUATOR_SHARDING", false),
+		EvaluatorMaxGroups:          intEnv("SLO_API_EVALUATOR_MAX_GROUPS", 100),
+		EvaluationHistoryRetention:  durationEnv("SLO_API_EVALUATION_HISTORY_RETENTION", 30*24*time.Hour),
 		ShutdownGraceSeconds:        intEnv("SLO_API_SHUTDOWN_GRACE_SECONDS", 10),
 	}
 	var err error
@@ -133,6 +186,17 @@ func boolEnv(key string, fallback bool) bool {
 	return parsed
 }
 
+// listEnv reads a comma-separated list, skipping empty entries.
+func listEnv(key string) []string {
+	var out []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 func jsonStringMapEnv(key string) (map[string]string, error) {
 	v := os.Getenv(key)
 	if v == "" {
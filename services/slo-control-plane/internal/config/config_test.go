@@ -49,6 +49,39 @@ func TestLoadGrafanaAndReconcilerSettings(t *testing.T) {
 	}
 }
 
+func TestLoadOutboxRetrySettings(t *testing.T) {
+	t.Setenv("SLO_API_POSTGRES_DSN", "postgres://test")
+	t.Setenv("SLO_API_CLICKHOUSE_DSN", "clickhouse://test")
+	t.Setenv("SLO_API_OUTBOX_MAX_RETRIES", "4")
+	t.Setenv("SLO_API_OUTBOX_RETRY_MAX_DELAY", "90s")
+	t.Setenv("SLO_API_OUTBOX_CLAIM_TTL", "2m")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.OutboxMaxRetries != 4 {
+		t.Fatalf("OutboxMaxRetries = %d", cfg.OutboxMaxRetries)
+	}
+	if cfg.OutboxRetryBaseDelay != time.Second || cfg.OutboxRetryMaxDelay != 90*time.Second {
+		t.Fatalf("OutboxRetryBaseDelay/MaxDelay = %s/%s", cfg.OutboxRetryBaseDelay, cfg.OutboxRetryMaxDelay)
+	}
+	if cfg.OutboxClaimTTL != 2*time.Minute || cfg.OutboxWorkerID != "" {
+		t.Fatalf("OutboxClaimTTL/WorkerID = %s/%q", cfg.OutboxClaimTTL, cfg.OutboxWorkerID)
+	}
+	if cfg.WebhookAllowedHosts != nil {
+		t.Fatalf("WebhookAllowedHosts = %v", cfg.WebhookAllowedHosts)
+	}
+
+	t.Setenv("SLO_API_WEBHOOK_ALLOWED_HOSTS", " receiver.internal, ,10.0.0.7")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.WebhookAllowedHosts) != 2 || cfg.WebhookAllowedHosts[0] != "receiver.internal" || cfg.WebhookAllowedHosts[1] != "10.0.0.7" {
+		t.Fatalf("WebhookAllowedHosts = %v", cfg.WebhookAllowedHosts)
+	}
+}
+
 func TestLoadRejectsInvalidAlertLabelJSON(t *testing.T) {
 	t.Setenv("SLO_API_POSTGRES_DSN", "postgres://test")
 	t.Setenv("SLO_API_CLICKHOUSE_DSN", "clickhouse://test")
@@ -122,6 +155,73 @@ func TestLoadOTelCustomValues(t *testing.T) {
 	}
 }
 
+func TestLoadPrometheusSettings(t *testing.T) {
+	t.Setenv("SLO_API_POSTGRES_DSN", "postgres://test")
+	t.Setenv("SLO_API_CLICKHOUSE_DSN", "clickhouse://test")
+	t.Setenv("SLO_API_PROMETHEUS_URL", "http://prometheus:9090")
+	t.Setenv("SLO_API_PROMETHEUS_HTTP_TIMEOUT", "3s")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.PrometheusURL != "http://prometheus:9090" {
+		t.Fatalf("PrometheusURL = %q", cfg.PrometheusURL)
+	}
+	if cfg.PrometheusHTTPTimeout != 3*time.Second {
+		t.Fatalf("PrometheusHTTPTimeout = %s", cfg.PrometheusHTTPTimeout)
+	}
+	if cfg.GrafanaEventsDatasourceUID != "clickhouse" {
+		t.Fatalf("GrafanaEventsDatasourceUID = %q", cfg.GrafanaEventsDatasourceUID)
+	}
+}
+
+func TestLoadEvaluatorLeaderElection(t *testing.T) {
+	t.Setenv("SLO_API_POSTGRES_DSN", "postgres://test")
+	t.Setenv("SLO_API_CLICKHOUSE_DSN", "clickhouse://test")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.EvaluatorLeaderElection {
+		t.Fatalf("EvaluatorLeaderElection = false")
+	}
+	if cfg.EvaluatorLeaseTTL != 30*time.Second || cfg.EvaluatorLeaseRenewInterval != 10*time.Second {
+		t.Fatalf("lease ttl=%s renew=%s", cfg.EvaluatorLeaseTTL, cfg.EvaluatorLeaseRenewInterval)
+	}
+	if cfg.EvaluatorConcurrency != 8 || cfg.EvaluatorSLOTimeout != 20*time.Second || cfg.EvaluatorSharding {
+		t.Fatalf("concurrency=%d slo_timeout=%s sharding=%v", cfg.EvaluatorConcurrency, cfg.EvaluatorSLOTimeout, cfg.EvaluatorSharding)
+	}
+	if cfg.EvaluatorMaxGroups != 100 {
+		t.Fatalf("max_groups=%d", cfg.EvaluatorMaxGroups)
+	}
+	if cfg.EvaluatorMinEvents != 1 || cfg.EvaluatorMinSliceEvents != 1 {
+		t.Fatalf("min_events=%d min_slice_events=%d", cfg.EvaluatorMinEvents, cfg.EvaluatorMinSliceEvents)
+	}
+	if cfg.EvaluatorForecastSamples != 120 || cfg.EvaluatorForecastHalfLife != 30*time.Minute {
+		t.Fatalf("forecast_samples=%d forecast_half_life=%s", cfg.EvaluatorForecastSamples, cfg.EvaluatorForecastHalfLife)
+	}
+	if cfg.EvaluatorResolveRatio != 1 || cfg.EvaluatorResolveHold != 0 || cfg.EvaluatorStartEvaluations != 1 || cfg.EvaluatorStartWindow != 1 {
+		t.Fatalf("resolve_ratio=%v resolve_hold=%s start=%d/%d", cfg.EvaluatorResolveRatio, cfg.EvaluatorResolveHold, cfg.EvaluatorStartEvaluations, cfg.EvaluatorStartWindow)
+	}
+	if cfg.EvaluationHistoryRetention != 30*24*time.Hour {
+		t.Fatalf("evaluation_history_retention=%s", cfg.EvaluationHistoryRetention)
+	}
+
+	t.Setenv("SLO_API_EVALUATOR_LEADER_ELECTION", "false")
+	t.Setenv("SLO_API_EVALUATOR_INSTANCE_ID", "slo-evaluator-1")
+	t.Setenv("SLO_API_EVALUATOR_LEASE_TTL", "15s")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.EvaluatorLeaderElection || cfg.EvaluatorInstanceID != "slo-evaluator-1" || cfg.EvaluatorLeaseTTL != 15*time.Second {
+		t.Fatalf("unexpected evaluator lease config: %+v", cfg)
+	}
+}
+
 func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
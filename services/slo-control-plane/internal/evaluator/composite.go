@@ -0,0 +1,132 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	opensloparser "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/openslo"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+// compositeBackend measures a composite SLO. Each window queries every component SLO through its
+// own backend and definition and aggregates the compliances, so the composite then runs through the
+// same tiers, hysteresis and budget as any other SLO.
+type compositeBackend struct {
+	aggregation string
+	components  []compositeComponent
+}
+
+type compositeComponent struct {
+	name    string
+	weight  float64
+	def     sli.Definition
+	backend sli.Backend
+}
+
+// compositeBackend loads the component SLOs of a composite. Components must exist and must not be
+// composites themselves; a component's groupBy is ignored and it is measured as a whole.
+func (e *Evaluator) compositeBackend(ctx context.Context, composite *opensloparser.Composite) (*compositeBackend, error) {
+	if composite == nil || len(composite.Components) == 0 {
+		return nil, fmt.Errorf("composite slo has no components")
+	}
+	out := &compositeBackend{aggregation: composite.Aggregation}
+	for _, c := range composite.Components {
+		child, err := e.store.GetSLO(ctx, c.SLOID)
+		if err != nil {
+			return nil, fmt.Errorf("composite component %s: %w", c.SLOID, err)
+		}
+		comp, err := e.compositeComponent(child, c.Weight)
+		if err != nil {
+			return nil, fmt.Errorf("composite component %s: %w", c.SLOID, err)
+		}
+		out.components = append(out.components, comp)
+	}
+	return out, nil
+}
+
+func (e *Evaluator) compositeComponent(child store.SLO, weight float64) (compositeComponent, error) {
+	if child.DatasourceType == opensloparser.DatasourceComposite {
+		return compositeComponent{}, fmt.Errorf("composite SLOs cannot be nested")
+	}
+	backend, ok := e.backends.Lookup(child.DatasourceType)
+	if !ok {
+		return compositeComponent{}, fmt.Errorf("no sli backend registered for datasource type %q", child.DatasourceType)
+	}
+	def, ok := parseSLIDefinition(child)
+	if !ok {
+		return compositeComponent{}, fmt.Errorf("canonical runtime has no sli type")
+	}
+	def.GroupBy = nil
+	if def.MinSliceEvents <= 0 {
+		def.MinSliceEvents = e.cfg.MinSliceEvents
+	}
+	return compositeComponent{name: child.Name, weight: weight, def: def, backend: backend}, nil
+}
+
+func (b *compositeBackend) Compliance(ctx context.Context, _ sli.Definition, windowMinutes int, end time.Time) (sli.Sample, error) {
+	samples := make([]sli.Sample, 0, len(b.components))
+	for _, c := range b.components {
+		sample, err := c.backend.Compliance(ctx, c.def, windowMinutes, end)
+		if err != nil {
+			return sli.Sample{}, fmt.Errorf("component %q: %w", c.name, err)
+		}
+		samples = append(samples, sample)
+	}
+	return b.aggregate(samples), nil
+}
+
+// aggregate combines component samples taken over the same window. Components without events say
+// nothing about the window and are left out; the composite's events are the sum of the rest.
+// weightedAverage weights compliance, min takes the worst component and and multiplies them, the
+// chance that a journey passing through every component succeeds.
+func (b *compositeBackend) aggregate(samples []sli.Sample) sli.Sample {
+	out := sli.Sample{Compliance: 1}
+	weighted, weights := 0.0, 0.0
+	for i, s := range samples {
+		if s.Events <= 0 {
+			continue
+		}
+		out.Events += s.Events
+		switch b.aggregation {
+		case opensloparser.AggregationMin:
+			out.Compliance = math.Min(out.Compliance, s.Compliance)
+		case opensloparser.AggregationAnd:
+			out.Compliance *= s.Compliance
+		default:
+			weighted += b.components[i].weight * s.Compliance
+			weights += b.components[i].weight
+		}
+	}
+	if weights > 0 {
+		out.Compliance = weighted / weights
+	}
+	return out
+}
+
+// Explain lists each component's statements, named after the component.
+func (b *compositeBackend) Explain(_ sli.Definition, windowMinutes int, end time.Time) ([]sli.Query, error) {
+	var out []sli.Query
+	for _, c := range b.components {
+		explainer, ok := c.backend.(sli.Explainer)
+		if !ok {
+			continue
+		}
+		queries, err := explainer.Explain(c.def, windowMinutes, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range queries {
+			q.Name = c.name + ": " + q.Name
+			out = append(out, q)
+		}
+	}
+	return out, nil
+}
+
+// Close is a no-op: the component backends belong to the registry.
+func (b *compositeBackend) Close() error {
+	return nil
+}
@@ -0,0 +1,87 @@
+package evaluator
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	opensloparser "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/openslo"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+// routeBackend returns a fixed sample per route.
+type routeBackend map[string]sli.Sample
+
+func (b routeBackend) Compliance(_ context.Context, def sli.Definition, _ int, _ time.Time) (sli.Sample, error) {
+	return b[def.Route], nil
+}
+
+func (b routeBackend) Close() error { return nil }
+
+func compositeChild(route string) store.SLO {
+	return store.SLO{
+		ID:             uuid.New(),
+		Name:           route,
+		Target:         0.99,
+		WindowMinutes:  60,
+		DatasourceType: "fake",
+		Canonical:      map[string]any{"type": "error_rate", "route": route},
+	}
+}
+
+func TestCompositeBackendAggregation(t *testing.T) {
+	reg := sli.NewRegistry()
+	backend := routeBackend{
+		"/cart":     {Compliance: 0.9, Events: 100},
+		"/checkout": {Compliance: 0.99, Events: 50},
+		"/idle":     {Compliance: 1, Events: 0},
+	}
+	if err := reg.Register("fake", backend); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	e := New(nil, reg, Config{})
+	var components []compositeComponent
+	for route, weight := range map[string]float64{"/cart": 1, "/checkout": 3, "/idle": 10} {
+		c, err := e.compositeComponent(compositeChild(route), weight)
+		if err != nil {
+			t.Fatalf("compositeComponent(%s) error = %v", route, err)
+		}
+		components = append(components, c)
+	}
+
+	for aggregation, want := range map[string]float64{
+		opensloparser.AggregationWeightedAverage: (0.9 + 3*0.99) / 4,
+		opensloparser.AggregationMin:             0.9,
+		opensloparser.AggregationAnd:             0.9 * 0.99,
+	} {
+		b := &compositeBackend{aggregation: aggregation, components: components}
+		got, err := b.Compliance(context.Background(), sli.Definition{}, 5, time.Time{})
+		if err != nil {
+			t.Fatalf("%s: Compliance() error = %v", aggregation, err)
+		}
+		if math.Abs(got.Compliance-want) > 1e-9 || got.Events != 150 {
+			t.Fatalf("%s: expected compliance %v over 150 events, got %+v", aggregation, want, got)
+		}
+	}
+
+	idle := &compositeBackend{aggregation: opensloparser.AggregationMin, components: components[:0]}
+	if got := idle.aggregate([]sli.Sample{{Compliance: 1}}); got.Compliance != 1 || got.Events != 0 {
+		t.Fatalf("expected an idle composite to have no events, got %+v", got)
+	}
+}
+
+func TestCompositeComponentRejectsNesting(t *testing.T) {
+	e := New(nil, sli.NewRegistry(), Config{})
+	child := compositeChild("/cart")
+	child.DatasourceType = opensloparser.DatasourceComposite
+	if _, err := e.compositeComponent(child, 1); err == nil {
+		t.Fatalf("expected a composite component to be rejected")
+	}
+	if _, err := e.compositeComponent(compositeChild("/cart"), 1); err == nil {
+		t.Fatalf("expected an unregistered datasource to be rejected")
+	}
+}
@@ -3,17 +3,26 @@ package evaluator
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log"
 	"math"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	_ "github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/config"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/events"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/history"
+	opensloparser "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/openslo"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/telemetry"
 )
@@ -25,19 +34,75 @@ type Config struct {
 	SlowWindowMinutes     int
 	FastBurnRateThreshold float64
 	SlowBurnRateThreshold float64
+	// MinEvents and MinSliceEvents are the minimum-traffic defaults for SLOs that do not set their own.
+	MinEvents      int
+	MinSliceEvents int
+	// ForecastSamples is how many recent budget samples the exhaustion forecast fits; ForecastHalfLife
+	// weights them by age, with zero meaning an unweighted linear fit.
+	ForecastSamples  int
+	ForecastHalfLife time.Duration
+	// Hysteresis damps flapping burn state transitions; the zero value starts and resolves on every crossing.
+	Hysteresis Hysteresis
+	// Concurrency bounds how many SLOs are evaluated at once; SLOTimeout caps each one.
+	Concurrency int
+	SLOTimeout  time.Duration
+	// MaxGroups caps the dimensions a grouped SLO is evaluated for, keeping those with the most events.
+	MaxGroups int
+	// Leader gates evaluation when several replicas run; nil means always evaluate.
+	Leader LeaderCheck
+	// Shard restricts this replica to the SLOs it owns; nil means every SLO.
+	Shard ShardFilter
+	// History receives every successful evaluation of a pass; nil disables the history.
+	History HistoryWriter
+	// Maintenance lists the maintenance windows covering an SLO; nil means none.
+	Maintenance MaintenanceSource
+}
+
+// ConfigFrom maps the service configuration to the evaluation settings shared by the evaluator and
+// on-demand evaluations. Leader, Shard, History and Maintenance are left for the caller.
+func ConfigFrom(c config.Config) Config {
+	return Config{
+		Interval:              c.EvaluatorInterval,
+		ContinueInterval:      c.EvaluatorContinueInterval,
+		FastWindowMinutes:     c.EvaluatorFastWindowMin,
+		SlowWindowMinutes:     c.EvaluatorSlowWindowMin,
+		FastBurnRateThreshold: c.EvaluatorFastBurnRate,
+		SlowBurnRateThreshold: c.EvaluatorSlowBurnRate,
+		MinEvents:             c.EvaluatorMinEvents,
+		MinSliceEvents:        c.EvaluatorMinSliceEvents,
+		ForecastSamples:       c.EvaluatorForecastSamples,
+		ForecastHalfLife:      c.EvaluatorForecastHalfLife,
+		Hysteresis: Hysteresis{
+			ResolveRatio:     c.EvaluatorResolveRatio,
+			MinHold:          c.EvaluatorResolveHold,
+			StartEvaluations: c.EvaluatorStartEvaluations,
+			StartWindow:      c.EvaluatorStartWindow,
+		},
+		Concurrency: c.EvaluatorConcurrency,
+		SLOTimeout:  c.EvaluatorSLOTimeout,
+		MaxGroups:   c.EvaluatorMaxGroups,
+	}
+}
+
+type HistoryWriter interface {
+	Append(ctx context.Context, points []history.Point) error
+}
+
+type LeaderCheck interface {
+	IsLeader() bool
+}
+
+type ShardFilter interface {
+	Owns(sloID uuid.UUID) bool
 }
 
 type Evaluator struct {
-	store *store.Store
-	chDB  *sql.DB
-	cfg   Config
+	store    *store.Store
+	backends *sli.Registry
+	cfg      Config
 }
 
-func New(st *store.Store, clickhouseDSN string, cfg Config) (*Evaluator, error) {
-	ch, err := sql.Open("clickhouse", clickhouseDSN)
-	if err != nil {
-		return nil, err
-	}
+func New(st *store.Store, backends *sli.Registry, cfg Config) *Evaluator {
 	if cfg.FastWindowMinutes <= 0 {
 		cfg.FastWindowMinutes = 5
 	}
@@ -50,15 +115,28 @@ func New(st *store.Store, clickhouseDSN string, cfg Config) (*Evaluator, error)
 	if cfg.SlowBurnRateThreshold <= 0 {
 		cfg.SlowBurnRateThreshold = 2.0
 	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 8
+	}
+	if cfg.SLOTimeout <= 0 {
+		cfg.SLOTimeout = 20 * time.Second
+	}
+	if cfg.ForecastSamples <= 0 {
+		cfg.ForecastSamples = 120
+	}
+	if cfg.MaxGroups <= 0 {
+		cfg.MaxGroups = 100
+	}
+	cfg.Hysteresis = cfg.Hysteresis.normalize()
 	return &Evaluator{
-		store: st,
-		chDB:  ch,
-		cfg:   cfg,
-	}, nil
+		store:    st,
+		backends: backends,
+		cfg:      cfg,
+	}
 }
 
 func (e *Evaluator) Close() error {
-	return e.chDB.Close()
+	return e.backends.Close()
 }
 
 func (e *Evaluator) Run(ctx context.Context) {
@@ -69,11 +147,18 @@ func (e *Evaluator) Run(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-t.C:
+			if !e.isLeader() {
+				continue
+			}
 			_ = e.EvaluateOnce(ctx)
 		}
 	}
 }
 
+func (e *Evaluator) isLeader() bool {
+	return e.cfg.Leader == nil || e.cfg.Leader.IsLeader()
+}
+
 func (e *Evaluator) EvaluateOnce(ctx context.Context) error {
 	tr := otel.Tracer("slo-control-plane/evaluator")
 	ctx, span := tr.Start(ctx, "evaluator.evaluate_once", trace.WithSpanKind(trace.SpanKindInternal))
@@ -86,77 +171,741 @@ func (e *Evaluator) EvaluateOnce(ctx context.Context) error {
 	}
 	span.SetAttributes(attribute.Int("slo.count", len(slos)))
 	now := time.Now().UTC()
-	evaluated := 0
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		evaluated int
+		skipped   int
+		failed    int
+		points    []history.Point
+	)
+	sem := make(chan struct{}, e.cfg.Concurrency)
 	for _, slo := range slos {
-		if slo.DatasourceType != "clickhouse" {
+		if e.cfg.Shard != nil && !e.cfg.Shard.Owns(slo.ID) {
+			skipped++
 			continue
 		}
-		def, ok := parseSLIDefinition(slo)
-		if !ok {
-			continue
+		// Stop dispatching once the lease lapses so a new leader never overlaps with this one.
+		if !e.isLeader() {
+			span.SetAttributes(attribute.Bool("evaluator.leadership_lost", true))
+			break
 		}
-		fastWindow := minPositive(def.WindowMinutes, e.cfg.FastWindowMinutes)
-		slowWindow := minPositive(def.WindowMinutes, e.cfg.SlowWindowMinutes)
-		fastCompliance, err := e.queryCompliance(ctx, def, fastWindow)
-		if err != nil {
-			continue
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
 		}
-		slowCompliance, err := e.queryCompliance(ctx, def, slowWindow)
-		if err != nil {
-			continue
+		if ctx.Err() != nil {
+			break
 		}
-		fastBurnRate := burnRate(fastCompliance, float64(slo.Target))
-		slowBurnRate := burnRate(slowCompliance, float64(slo.Target))
-		severity := classifySeverity(fastBurnRate, slowBurnRate, e.cfg.FastBurnRateThreshold, e.cfg.SlowBurnRateThreshold)
+		wg.Add(1)
+		go func(slo store.SLO) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			started := time.Now()
+			sloPoints, queryTime, err := e.evaluateSLO(ctx, span, slo, now, nil)
+			e.recordOutcome(ctx, slo, now, time.Since(started), queryTime, err)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed++
+				return
+			}
+			evaluated++
+			points = append(points, sloPoints...)
+		}(slo)
+	}
+	wg.Wait()
+	span.SetAttributes(
+		attribute.Int("slo.evaluated_count", evaluated),
+		attribute.Int("slo.failed_count", failed),
+		attribute.Int("slo.not_owned_count", skipped),
+	)
+	e.appendHistory(ctx, points)
+	return nil
+}
 
-		currentCompliance := slowCompliance
-		currentBurnRate := slowBurnRate
-		currentThreshold := e.cfg.SlowBurnRateThreshold
-		currentWindowMin := slowWindow
-		if severity == severityFast {
-			currentCompliance = fastCompliance
-			currentBurnRate = fastBurnRate
-			currentThreshold = e.cfg.FastBurnRateThreshold
-			currentWindowMin = fastWindow
-		}
+// appendHistory writes the pass to the evaluation history. Like recordOutcome it outlives a
+// cancelled pass, and a failed write only costs history points.
+func (e *Evaluator) appendHistory(ctx context.Context, points []history.Point) {
+	if e.cfg.History == nil || len(points) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if err := e.cfg.History.Append(ctx, points); err != nil {
+		log.Printf("append evaluation history failed points=%d: %v", len(points), err)
+	}
+}
 
-		if err := e.persistEvaluation(ctx, slo, currentCompliance, severity, currentBurnRate, currentThreshold, currentWindowMin, now); err != nil {
-			continue
+const (
+	stageBackend    = "backend"
+	stageDefinition = "definition"
+	stageQuery      = "query"
+	stagePersist    = "persist"
+)
+
+// evaluationError tags a per-SLO failure with the stage that failed.
+type evaluationError struct {
+	Stage string
+	Err   error
+}
+
+func (e *evaluationError) Error() string {
+	return e.Stage + ": " + e.Err.Error()
+}
+
+func (e *evaluationError) Unwrap() error {
+	return e.Err
+}
+
+// evaluateSLO runs one SLO under its own timeout and returns a history point per objective and
+// dimension and the time spent in backend queries. A non-nil exp collects how the result for one
+// objective and dimension was reached; exp.DryRun persists nothing.
+func (e *Evaluator) evaluateSLO(ctx context.Context, parent trace.Span, slo store.SLO, now time.Time, exp *Explanation) ([]history.Point, time.Duration, error) {
+	tr := otel.Tracer("slo-control-plane/evaluator")
+	ctx, span := tr.Start(ctx, "evaluator.evaluate_slo", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("slo.id", slo.ID.String()),
+		attribute.String("slo.datasource_type", slo.DatasourceType),
+	)
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.SLOTimeout)
+	defer cancel()
+
+	maint, err := e.maintenanceWindows(ctx, slo)
+	if err != nil {
+		err = &evaluationError{Stage: stageDefinition, Err: fmt.Errorf("maintenance windows: %w", err)}
+		telemetry.RecordSpanError(span, err)
+		return nil, 0, err
+	}
+	open, err := e.openDimensions(ctx, slo)
+	if err != nil {
+		err = &evaluationError{Stage: stageDefinition, Err: fmt.Errorf("open burn states: %w", err)}
+		telemetry.RecordSpanError(span, err)
+		return nil, 0, err
+	}
+	ms, queryTime, err := e.measure(ctx, slo, maint, open, now, time.Time{}, exp)
+	if err != nil {
+		telemetry.RecordSpanError(span, err)
+		return nil, queryTime, err
+	}
+	explained := -1
+	if exp != nil {
+		if explained, err = exp.choose(opensloparser.MapToRuntime(slo.Canonical).Objectives, ms); err != nil {
+			return nil, queryTime, err
 		}
-		span.AddEvent("slo.evaluated", trace.WithAttributes(
+	}
+	dryRun := exp != nil && exp.DryRun
+	plans, err := e.persistEvaluation(ctx, slo, ms, now, dryRun)
+	if err != nil {
+		err = &evaluationError{Stage: stagePersist, Err: err}
+		telemetry.RecordSpanError(span, err)
+		return nil, queryTime, err
+	}
+	if explained >= 0 {
+		exp.record(e.cfg.Hysteresis.ResolveRatio, ms[explained], plans[explained])
+	}
+
+	points := make([]history.Point, 0, len(ms))
+	for i, m := range ms {
+		persisted := plans[i]
+		parent.AddEvent("slo.evaluated", trace.WithAttributes(
 			attribute.String("slo.id", slo.ID.String()),
 			attribute.String("slo.name", slo.Name),
+			attribute.String("slo.objective", m.Objective.Name),
+			attribute.String("slo.dimension_key", m.Dimension.Key),
 			attribute.String("slo.datasource_type", slo.DatasourceType),
-			attribute.String("slo.severity", string(severity)),
-			attribute.Float64("slo.compliance", currentCompliance),
-			attribute.Float64("slo.burn_rate", currentBurnRate),
+			attribute.String("slo.severity", string(persisted.Severity)),
+			attribute.String("slo.burn_tier", persisted.Tier),
+			attribute.Float64("slo.compliance", m.Eval.Sample.Compliance),
+			attribute.Float64("slo.burn_rate", m.Eval.BurnRate),
+			attribute.Float64("slo.events", m.Eval.Sample.Events),
+			attribute.Bool("slo.insufficient_data", persisted.Insufficient),
 		))
-		evaluated++
+		point := history.Point{
+			SLOID:            slo.ID,
+			ServiceID:        slo.ServiceID,
+			Objective:        m.Objective.Name,
+			DimensionKey:     m.Dimension.Key,
+			EvaluatedAt:      now,
+			Severity:         string(persisted.Severity),
+			BurnTier:         persisted.Tier,
+			Compliance:       m.Eval.Sample.Compliance,
+			BurnRate:         m.Eval.BurnRate,
+			BudgetRemaining:  m.Eval.Budget.Remaining,
+			Events:           m.Eval.Sample.Events,
+			InsufficientData: persisted.Insufficient,
+			ETASeconds:       persisted.ETASeconds,
+			QueryDurationMs:  int(queryTime.Milliseconds()),
+		}
+		for _, window := range tierWindows(m.Tiers) {
+			point.Windows = append(point.Windows, history.Window{
+				Minutes:    window,
+				Compliance: m.Samples[window].Compliance,
+				Events:     m.Samples[window].Events,
+			})
+		}
+		points = append(points, point)
 	}
-	span.SetAttributes(attribute.Int("slo.evaluated_count", evaluated))
-	return nil
+	return points, queryTime, nil
 }
 
-func (e *Evaluator) persistEvaluation(
-	ctx context.Context,
-	slo store.SLO,
-	compliance float64,
-	severity burnSeverity,
-	burnRate float64,
-	burnThreshold float64,
-	burnWindowMin int,
-	now time.Time,
-) error {
+// measurement is what the backend queries of one evaluation found for one objective and dimension,
+// before any burn state is read.
+type measurement struct {
+	Objective  opensloparser.Objective
+	Dimension  sli.Dimension
+	Eval       evaluation
+	Tiers      []opensloparser.BurnRateTier
+	Samples    map[int]sli.Sample
+	Sufficient map[int]bool
+	Rates      map[int]float64
+}
+
+// measure queries an SLO's burn windows and budget as of now and returns a measurement per
+// objective and dimension, ordered by objective and then dimension key; an ungrouped SLO has one per
+// objective, with an empty key. It also returns the time spent in backend queries. Backend queries
+// end at end, or at the backend's own clock when end is zero. The maintenance windows decide whether
+// the SLO is in maintenance now and which minutes the budget leaves out. The open dimensions of each
+// objective are measured even outside the top dimensions. Errors carry the stage that failed.
+func (e *Evaluator) measure(ctx context.Context, slo store.SLO, maint []store.MaintenanceWindow, open map[string][]sli.Dimension, now, end time.Time, exp *Explanation) ([]measurement, time.Duration, error) {
+	var queryTime time.Duration
+	fail := func(stage string, err error) ([]measurement, time.Duration, error) {
+		return nil, queryTime, &evaluationError{Stage: stage, Err: err}
+	}
+	rt := opensloparser.MapToRuntime(slo.Canonical)
+	var backend sli.Backend
+	if slo.DatasourceType == opensloparser.DatasourceComposite {
+		composite, err := e.compositeBackend(ctx, rt.Composite)
+		if err != nil {
+			return fail(stageDefinition, err)
+		}
+		backend = composite
+	} else {
+		var ok bool
+		if backend, ok = e.backends.Lookup(slo.DatasourceType); !ok {
+			return fail(stageBackend, fmt.Errorf("no sli backend registered for datasource type %q", slo.DatasourceType))
+		}
+	}
+	def, ok := parseSLIDefinition(slo)
+	if !ok {
+		return fail(stageDefinition, fmt.Errorf("canonical runtime has no sli type"))
+	}
+	grouped, _ := backend.(sli.GroupedBackend)
+	if len(def.GroupBy) > 0 && grouped == nil {
+		return fail(stageBackend, fmt.Errorf("sli backend for datasource type %q does not support groupBy", slo.DatasourceType))
+	}
+	minEvents := rt.MinEvents
+	if minEvents <= 0 {
+		minEvents = e.cfg.MinEvents
+	}
+	if def.MinSliceEvents <= 0 {
+		def.MinSliceEvents = e.cfg.MinSliceEvents
+	}
+	explainer, _ := backend.(sli.Explainer)
+	// Objectives that only differ in target share their queries.
+	cache := map[string]map[string]sli.GroupSample{}
+	query := func(objective string, def sli.Definition, windowMinutes int, end time.Time) (map[string]sli.GroupSample, error) {
+		if exp != nil && explainer != nil {
+			exp.addQueries(explainer, objective, def, windowMinutes, end)
+		}
+		cacheKey := fmt.Sprintf("%g/%s/%d/%d", def.Threshold, def.Op, windowMinutes, end.Unix())
+		if groups, ok := cache[cacheKey]; ok {
+			return groups, nil
+		}
+		started := time.Now()
+		defer func() { queryTime += time.Since(started) }()
+		out := map[string]sli.GroupSample{}
+		if len(def.GroupBy) == 0 {
+			sample, err := backend.Compliance(ctx, def, windowMinutes, end)
+			if err != nil {
+				return nil, err
+			}
+			out[""] = sli.GroupSample{Sample: sample}
+		} else {
+			groups, err := grouped.GroupedCompliance(ctx, def, windowMinutes, end)
+			if err != nil {
+				return nil, err
+			}
+			for _, g := range groups {
+				out[g.Key] = g
+			}
+		}
+		cache[cacheKey] = out
+		return out, nil
+	}
+	tiers := rt.BurnRateTiers
+	if len(tiers) == 0 {
+		tiers = e.defaultBurnRateTiers()
+	}
+	var periodStart, periodEnd time.Time
+	elapsedMin := 0
+	if rt.Calendar != nil {
+		var err error
+		periodStart, periodEnd, err = rt.Calendar.Period(now)
+		if err != nil {
+			return fail(stageDefinition, err)
+		}
+		// Burn windows never reach back past the period start, so a new period starts clean.
+		elapsedMin = int(math.Ceil(now.Sub(periodStart).Minutes()))
+	}
+	tiers = clampTierWindows(tiers, def.WindowMinutes, elapsedMin)
+	budgetWindow, budgetLabel := def.WindowMinutes, "slo window"
+	if rt.Calendar != nil {
+		budgetWindow, budgetLabel = elapsedMin, "budget period"
+	}
+	maintenanceNow := inMaintenance(maint, now)
+	// Errors measured during maintenance stay in the burn windows until the longest one has moved
+	// past the window, so burns are not started before then.
+	longest := 0
+	for _, window := range tierWindows(tiers) {
+		longest = max(longest, window)
+	}
+	holdStarts := maintenanceNow || maintenanceBetween(maint, now.Add(-time.Duration(longest)*time.Minute), now)
+	exclusions := budgetExclusions(maint, now.Add(-time.Duration(budgetWindow)*time.Minute), now)
+	if exp != nil {
+		exp.InMaintenance = maintenanceNow
+	}
+
+	assess := func(objective opensloparser.Objective, windows map[int]map[string]sli.GroupSample, budgetGroups map[string]sli.GroupSample, dim sli.Dimension) measurement {
+		target := float64(objective.Target)
+		samples := map[int]sli.Sample{}
+		sufficient := map[int]bool{}
+		rates := map[int]float64{}
+		for window, groups := range windows {
+			sample := groupSample(groups, dim.Key)
+			samples[window] = sample
+			// Windows below the minimum get no burn rate, so no tier can fire on them.
+			if sample.Sufficient(minEvents) {
+				sufficient[window] = true
+				rates[window] = burnRate(sample.Compliance, target)
+			}
+		}
+		severity := severityNone
+		var traffic trafficCheck
+		tier, firing := selectTier(tiers, rates)
+		sustainTier, sustaining := selectTier(resolveTiers(tiers, e.cfg.Hysteresis.ResolveRatio), rates)
+		if firing {
+			severity = burnSeverity(tier.Severity)
+		} else {
+			// Report the least severe tier's long window when nothing fires.
+			tier = tiers[len(tiers)-1]
+			traffic = trafficCheck{
+				ReportingWindowLow: !sufficient[tier.LongWindowMinutes],
+				TierBlocked:        tiersLackData(tiers, sufficient),
+			}
+		}
+		currentSample := samples[tier.LongWindowMinutes]
+		currentCompliance := currentSample.Compliance
+		currentBurnRate := burnRate(currentCompliance, target)
+
+		var budget budgetStatus
+		budgetCompliance := groupSample(budgetGroups, dim.Key).Compliance
+		if rt.Calendar == nil {
+			budget = rollingBudget(budgetCompliance, target, currentBurnRate, def.WindowMinutes)
+		} else {
+			budget = calendarBudget(calendarBudgetInput{
+				Now:              now,
+				PeriodStart:      periodStart,
+				PeriodEnd:        periodEnd,
+				Target:           target,
+				PeriodCompliance: budgetCompliance,
+				BurnRate:         currentBurnRate,
+			})
+		}
+		return measurement{
+			Objective: objective,
+			Dimension: dim,
+			Eval: evaluation{
+				Sample:    currentSample,
+				MinEvents: minEvents,
+				Traffic:   traffic,
+				Severity:  severity,
+				Firing:    firing,
+				BurnRate:  currentBurnRate,
+				Tier:      tier,
+				Sustain:   sustainTier,
+				Sustained: sustaining,
+				Budget:    budget,
+				// A maintenance window holds back burn events for every objective and dimension.
+				Maintenance: maintenanceNow,
+				HoldStarts:  holdStarts,
+			},
+			Tiers:      tiers,
+			Samples:    samples,
+			Sufficient: sufficient,
+			Rates:      rates,
+		}
+	}
+
+	var out []measurement
+	for _, objective := range rt.Objectives {
+		if objective.Target <= 0 {
+			// A runtime without its own target is measured against the SLO's.
+			objective.Target = slo.Target
+		}
+		odef := def
+		odef.Target = float64(objective.Target)
+		odef.Op = objective.Op
+		if objective.Threshold > 0 {
+			odef.Threshold = float64(objective.Threshold)
+		}
+		label := ""
+		if objective.Name != "" {
+			label = fmt.Sprintf("objective %q ", objective.Name)
+		}
+		windows := map[int]map[string]sli.GroupSample{}
+		for _, window := range tierWindows(tiers) {
+			groups, err := query(objective.Name, odef, window, end)
+			if err != nil {
+				return fail(stageQuery, fmt.Errorf("%s%dm window: %w", label, window, err))
+			}
+			windows[window] = groups
+		}
+		budgetGroups, ok := windows[budgetWindow]
+		if !ok {
+			var err error
+			if budgetGroups, err = query(objective.Name, odef, budgetWindow, end); err != nil {
+				return fail(stageQuery, fmt.Errorf("%s%s: %w", label, budgetLabel, err))
+			}
+		}
+		if len(exclusions) > 0 {
+			excluded := make([]map[string]sli.GroupSample, 0, len(exclusions))
+			for _, iv := range exclusions {
+				minutes := int(math.Ceil(iv.End.Sub(iv.Start).Minutes()))
+				groups, err := query(objective.Name, odef, minutes, iv.End)
+				if err != nil {
+					return fail(stageQuery, fmt.Errorf("%smaintenance window ending %s: %w", label, iv.End.Format(time.RFC3339), err))
+				}
+				excluded = append(excluded, groups)
+			}
+			budgetGroups = excludeSamples(budgetGroups, excluded)
+		}
+		dims, dropped := topDimensions(windows, budgetGroups, e.cfg.MaxGroups, open[objective.Name])
+		if dropped > 0 {
+			trace.SpanFromContext(ctx).SetAttributes(attribute.Int("slo.dropped_dimensions", dropped))
+		}
+		for _, dim := range dims {
+			m := assess(objective, windows, budgetGroups, dim)
+			if !reporting(windows, budgetGroups, dim.Key) {
+				// An open dimension that stopped reporting altogether would be held as insufficient data
+				// forever; evaluate it as quiet so it resolves.
+				m.Eval.Traffic = trafficCheck{}
+			}
+			out = append(out, m)
+		}
+	}
+	return out, queryTime, nil
+}
+
+// groupSample is a dimension's sample in one window; a dimension without events there has none.
+func groupSample(groups map[string]sli.GroupSample, key string) sli.Sample {
+	if g, ok := groups[key]; ok {
+		return g.Sample
+	}
+	return sli.Sample{Compliance: 1}
+}
+
+// reporting tells whether a dimension has events in any window.
+func reporting(windows map[int]map[string]sli.GroupSample, budget map[string]sli.GroupSample, key string) bool {
+	if _, ok := budget[key]; ok {
+		return true
+	}
+	for _, groups := range windows {
+		if _, ok := groups[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// openDimensions returns the dimensions of each objective whose burn or breach is still open, so
+// they keep being evaluated until they resolve.
+func (e *Evaluator) openDimensions(ctx context.Context, slo store.SLO) (map[string][]sli.Dimension, error) {
+	states, err := e.store.ListOpenBurnStates(ctx, slo.ID)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string][]sli.Dimension{}
+	for _, st := range states {
+		if st.DimensionKey != "" {
+			out[st.Objective] = append(out[st.Objective], sli.Dimension{Key: st.DimensionKey, Values: st.Dimensions})
+		}
+	}
+	return out, nil
+}
+
+// topDimensions collects the dimensions seen in any window and keeps the max with the most events
+// in the budget window, plus every dimension in keep, ordered by key. It also returns how many were
+// dropped.
+func topDimensions(windows map[int]map[string]sli.GroupSample, budget map[string]sli.GroupSample, max int, keep []sli.Dimension) ([]sli.Dimension, int) {
+	seen := map[string]sli.Dimension{}
+	collect := func(groups map[string]sli.GroupSample) {
+		for key, g := range groups {
+			seen[key] = g.Dimension
+		}
+	}
+	collect(budget)
+	for _, groups := range windows {
+		collect(groups)
+	}
+	dims := make([]sli.Dimension, 0, len(seen))
+	for _, d := range seen {
+		dims = append(dims, d)
+	}
+	sort.Slice(dims, func(i, j int) bool {
+		ei, ej := budget[dims[i].Key].Events, budget[dims[j].Key].Events
+		if ei != ej {
+			return ei > ej
+		}
+		return dims[i].Key < dims[j].Key
+	})
+	dropped := 0
+	if max > 0 && len(dims) > max {
+		dropped = len(dims) - max
+		dims = dims[:max]
+	}
+	kept := make(map[string]bool, len(dims))
+	for _, d := range dims {
+		kept[d.Key] = true
+	}
+	for _, d := range keep {
+		if kept[d.Key] {
+			continue
+		}
+		kept[d.Key] = true
+		if current, ok := seen[d.Key]; ok {
+			d = current
+			dropped--
+		}
+		dims = append(dims, d)
+	}
+	sort.Slice(dims, func(i, j int) bool { return dims[i].Key < dims[j].Key })
+	return dims, dropped
+}
+
+// recordOutcome stores the attempt in slo_evaluation_health. It outlives the SLO timeout so a
+// timed-out evaluation is still recorded as failed.
+func (e *Evaluator) recordOutcome(ctx context.Context, slo store.SLO, now time.Time, elapsed, queryTime time.Duration, evalErr error) {
+	outcome := store.EvaluationOutcome{
+		SLOID:           slo.ID,
+		AttemptedAt:     now,
+		DurationMs:      int(elapsed.Milliseconds()),
+		QueryDurationMs: int(queryTime.Milliseconds()),
+	}
+	if evalErr != nil {
+		outcome.Error = evalErr.Error()
+		var stageErr *evaluationError
+		if errors.As(evalErr, &stageErr) {
+			outcome.ErrorStage = stageErr.Stage
+			outcome.Error = stageErr.Err.Error()
+		}
+		log.Printf("slo evaluation failed slo=%s name=%q stage=%s: %s", slo.ID, slo.Name, outcome.ErrorStage, outcome.Error)
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := e.store.RecordEvaluation(ctx, outcome); err != nil {
+		log.Printf("record slo evaluation failed slo=%s: %v", slo.ID, err)
+	}
+}
+
+// evaluation is the outcome of one pass over an SLO's burn windows, ready to persist.
+type evaluation struct {
+	Sample    sli.Sample
+	MinEvents int
+	Traffic   trafficCheck
+	Severity  burnSeverity
+	Firing    bool
+	BurnRate  float64
+	// Tier is the firing tier, or the reported one when nothing fires.
+	Tier opensloparser.BurnRateTier
+	// Sustain is the first tier still above its resolve threshold; it keeps an ongoing burn alive.
+	Sustain   opensloparser.BurnRateTier
+	Sustained bool
+	Budget    budgetStatus
+	// Maintenance is set while a maintenance window covers the SLO. The burn state is still tracked,
+	// but burn_started and burn_continued events are held back; burns still resolve.
+	Maintenance bool
+	// HoldStarts is set while a maintenance window covers the SLO or overlaps its longest burn
+	// window; no new burn starts until the downtime has left every burn window.
+	HoldStarts bool
+}
+
+// evaluationPlan is the burn state and events an evaluation leads to, after hysteresis and the
+// traffic guard.
+type evaluationPlan struct {
+	Severity     burnSeverity
+	Tier         string
+	Insufficient bool
+	ETASeconds   int
+	Action       actionDecision
+	Next         store.BurnState
+	Events       []plannedEvent
+}
+
+// plannedEvent is an outbox event waiting to be enqueued.
+type plannedEvent struct {
+	EventType      string
+	Data           events.Data
+	IdempotencyKey string
+}
+
+// persistEvaluation updates the burn state of every objective and dimension and enqueues their events in one
+// transaction, and retires the open burn states of objectives the SLO no longer has. A dry run reads
+// the burn states without locking them and writes nothing. Plans are returned in measurement order.
+func (e *Evaluator) persistEvaluation(ctx context.Context, slo store.SLO, ms []measurement, now time.Time, dryRun bool) ([]evaluationPlan, error) {
+	plans := make([]evaluationPlan, 0, len(ms))
+	if dryRun {
+		for _, m := range ms {
+			prev, hasPrev, err := e.store.GetBurnState(ctx, slo.ID, m.Objective.Name, m.Dimension.Key)
+			if err != nil {
+				return nil, err
+			}
+			plans = append(plans, e.planEvaluation(slo, m.Objective.Name, m.Dimension, m.Eval, prev, hasPrev, now))
+		}
+		return plans, nil
+	}
 	tx, err := e.store.BeginTx(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tx.Rollback()
 
-	prev, hasPrev, err := e.store.GetBurnStateForUpdate(ctx, tx, slo.ID)
+	// Measurements are ordered by objective and dimension key, so concurrent evaluations lock rows in
+	// the same order.
+	for _, m := range ms {
+		prev, hasPrev, err := e.store.GetBurnStateForUpdate(ctx, tx, slo.ID, m.Objective.Name, m.Dimension.Key)
+		if err != nil {
+			return nil, err
+		}
+		plan := e.planEvaluation(slo, m.Objective.Name, m.Dimension, m.Eval, prev, hasPrev, now)
+		for _, event := range plan.Events {
+			if err := e.store.EnqueueOutbox(ctx, tx, "slo", slo.ID, event.EventType, event.Data, event.IdempotencyKey); err != nil {
+				return nil, err
+			}
+		}
+		if err := e.store.UpsertBurnStateTx(ctx, tx, plan.Next); err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	if err := e.retireObjectives(ctx, tx, slo, now); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+// retireObjectives resolves and deletes the burning or breached states of objectives that were
+// removed or renamed, so their alert series do not keep firing on the last burn_started.
+func (e *Evaluator) retireObjectives(ctx context.Context, tx *sql.Tx, slo store.SLO, now time.Time) error {
+	current := map[string]bool{}
+	for _, objective := range opensloparser.MapToRuntime(slo.Canonical).Objectives {
+		current[objective.Name] = true
+	}
+	open, err := e.store.ListOpenBurnStates(ctx, slo.ID)
 	if err != nil {
 		return err
 	}
+	for _, st := range open {
+		if current[st.Objective] {
+			continue
+		}
+		prev, ok, err := e.store.GetBurnStateForUpdate(ctx, tx, slo.ID, st.Objective, st.DimensionKey)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		for _, event := range retirementEvents(slo, prev, now) {
+			if err := e.store.EnqueueOutbox(ctx, tx, "slo", slo.ID, event.EventType, event.Data, event.IdempotencyKey); err != nil {
+				return err
+			}
+		}
+		if err := e.store.DeleteBurnStateTx(ctx, tx, slo.ID, prev.Objective, prev.DimensionKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retirementEvents closes the burn and breach of a removed objective.
+func retirementEvents(slo store.SLO, prev store.BurnState, now time.Time) []plannedEvent {
+	dim := sli.Dimension{Key: prev.DimensionKey, Values: prev.Dimensions}
+	var out []plannedEvent
+	closeWith := func(eventType string, severity burnSeverity, source string) {
+		out = append(out, plannedEvent{
+			EventType: eventType,
+			Data: withSeries(events.Data{
+				EventType:       eventType,
+				ServiceID:       slo.ServiceID,
+				SLOID:           slo.ID,
+				Source:          source,
+				Severity:        string(severity),
+				EvaluatedAt:     now,
+				BudgetRemaining: float64(prev.BudgetRemaining),
+			}, prev.Objective, dim),
+			IdempotencyKey: buildIdempotencyKey(eventType, severity, slo.ID, prev.Objective, prev.DimensionKey, now),
+		})
+	}
+	if prev.IsBurning {
+		closeWith(events.BurnResolved, severityNone, "slo-evaluator:"+string(severityNone))
+	}
+	if prev.IsBreached {
+		closeWith(events.ErrorBudgetRecovered, "critical", "slo-evaluator:breach")
+	}
+	return out
+}
+
+// planEvaluation decides the next burn state of one objective and dimension and the events to emit
+// from its previous state.
+func (e *Evaluator) planEvaluation(slo store.SLO, objective string, dim sli.Dimension, ev evaluation, prev store.BurnState, hasPrev bool, now time.Time) evaluationPlan {
+	prevBurning := hasPrev && prev.IsBurning
+	insufficient := ev.Traffic.insufficient(prevBurning)
+	severity := ev.Severity
+	budget := ev.Budget
+	tier := ev.Tier
+	history := prev.FiringHistory
+	if insufficient {
+		// Hold whatever the last meaningful evaluation decided.
+		severity = severityNone
+		if prevBurning {
+			severity = burnSeverity(prev.CurrentSeverity)
+		}
+		budget.Exhausted = hasPrev && prev.IsBreached
+		budget.ETASeconds = 0
+	} else {
+		sustain := severityNone
+		if ev.Sustained {
+			sustain = burnSeverity(ev.Sustain.Severity)
+		}
+		var burningSince time.Time
+		if prev.LastTransitionAt.Valid {
+			burningSince = prev.LastTransitionAt.Time
+		}
+		severity, history = e.cfg.Hysteresis.apply(transitionInput{
+			Now:          now,
+			Raw:          ev.Severity,
+			Sustain:      sustain,
+			PrevBurning:  prevBurning,
+			PrevSeverity: burnSeverity(prev.CurrentSeverity),
+			BurningSince: burningSince,
+			History:      history,
+		})
+		if !ev.Firing && ev.Sustained && severity == sustain {
+			tier = ev.Sustain
+		}
+		if ev.HoldStarts && !prevBurning {
+			// Burns that begin during maintenance, or that only the downtime's errors still drive, are not
+			// started; one still burning once the burn windows are past the downtime starts then.
+			severity = severityNone
+		}
+	}
+	burnRate := ev.BurnRate
 
 	lastContinuedAt := time.Time{}
 	if hasPrev && prev.LastContinuedAt.Valid {
@@ -167,25 +916,43 @@ func (e *Evaluator) persistEvaluation(
 		SeverityNow:      severity,
 		ContinueInterval: e.cfg.ContinueInterval,
 		HasPrevState:     hasPrev,
-		PrevIsBurning:    hasPrev && prev.IsBurning,
+		PrevIsBurning:    prevBurning,
 		PrevSeverity:     burnSeverity(prev.CurrentSeverity),
 		LastContinuedAt:  lastContinuedAt,
+		InsufficientData: insufficient,
+		PrevInsufficient: hasPrev && prev.InsufficientData,
+		Maintenance:      ev.Maintenance,
 	})
 
 	next := store.BurnState{
 		SLOID:             slo.ID,
+		Objective:         objective,
+		DimensionKey:      dim.Key,
+		Dimensions:        dim.Values,
 		IsBurning:         severity != severityNone,
+		InsufficientData:  insufficient,
+		FiringHistory:     history,
 		CurrentSeverity:   string(severity),
-		CurrentCompliance: float32(compliance),
+		CurrentCompliance: float32(ev.Sample.Compliance),
 		CurrentBurnRate:   float32(burnRate),
+		CurrentEvents:     ev.Sample.Events,
 		LastTransitionAt:  prev.LastTransitionAt,
 		LastContinuedAt:   prev.LastContinuedAt,
 		LastEvaluatedAt:   now,
 	}
-	etaSeconds := timeToExhaustionSeconds(burnRate, burnWindowMin)
-	if etaSeconds > 0 {
-		next.ETAExhaustionSec = sql.NullInt32{Valid: true, Int32: int32(etaSeconds)}
+	next.BudgetSamples = prev.BudgetSamples
+	forecast := exhaustionForecast{ETASeconds: budget.ETASeconds}
+	if !insufficient {
+		next.BudgetSamples = appendBudgetSample(prev.BudgetSamples, store.BudgetSample{At: now, Remaining: budget.Remaining}, budget.PeriodStart, e.cfg.ForecastSamples)
+		if f, ok := forecastExhaustion(next.BudgetSamples, now, e.cfg.ForecastHalfLife); ok {
+			forecast = f.capAt(now, budget.PeriodEnd)
+		}
 	}
+	etaSeconds := forecast.ETASeconds
+	next.ETAExhaustionSec = positiveNullInt32(forecast.ETASeconds)
+	next.ETALowerSec = positiveNullInt32(forecast.LowerSeconds)
+	next.ETAUpperSec = positiveNullInt32(forecast.UpperSeconds)
+	next.BudgetRemaining = float32(budget.Remaining)
 
 	if !hasPrev {
 		next.LastTransitionAt = sql.NullTime{}
@@ -193,93 +960,158 @@ func (e *Evaluator) persistEvaluation(
 		next.BreachTransitionAt = sql.NullTime{}
 	}
 
-	exhaustionThreshold := float64(slo.Target) - (1.0 - float64(slo.Target))
-	isBreached := compliance <= exhaustionThreshold
+	wasBreached := hasPrev && prev.IsBreached
+	isBreached := budget.Exhausted
+	if ev.Maintenance && !wasBreached {
+		// Like burns, a breach does not start during maintenance; it still recovers.
+		isBreached = false
+	}
 	next.IsBreached = isBreached
 	if hasPrev {
 		next.BreachTransitionAt = prev.BreachTransitionAt
 	}
 
+	plan := evaluationPlan{
+		Severity:     severity,
+		Tier:         tier.Name,
+		Insufficient: insufficient,
+		ETASeconds:   etaSeconds,
+		Action:       action,
+	}
 	if action.EmitEvent {
-		if action.EventType == "burn_started" || action.EventType == "burn_resolved" {
+		if action.EventType == events.BurnStarted || action.EventType == events.BurnResolved {
 			next.LastTransitionAt = sql.NullTime{Valid: true, Time: now}
 		}
-		if action.EventType == "burn_continued" {
+		if action.EventType == events.BurnContinued {
 			next.LastContinuedAt = sql.NullTime{Valid: true, Time: now}
 		}
 
-		idempotencyKey := buildIdempotencyKey(action.EventType, severity, slo.ID, now)
-		err = e.store.EnqueueOutbox(ctx, tx, "slo", slo.ID, action.EventType, map[string]any{
-			"serviceId":            slo.ServiceID.String(),
-			"sloId":                slo.ID.String(),
-			"eventType":            action.EventType,
-			"value":                burnRate,
-			"threshold":            burnThreshold,
-			"source":               "slo-evaluator:" + string(severity),
-			"severity":             string(severity),
-			"etaExhaustionSeconds": etaSeconds,
-			"evaluatedAt":          now.Format(time.RFC3339),
-		}, idempotencyKey)
-		if err != nil {
-			return err
-		}
-	}
-	wasBreached := hasPrev && prev.IsBreached
-	if !hasPrev {
-		wasBreached = false
+		long, short, sampled := tier.LongWindowMinutes, tier.ShortWindowMinutes, ev.Sample.Events
+		minEvents := ev.MinEvents
+		plan.Events = append(plan.Events, plannedEvent{
+			EventType: action.EventType,
+			Data: withBudget(withSeries(events.Data{
+				EventType:            action.EventType,
+				ServiceID:            slo.ServiceID,
+				SLOID:                slo.ID,
+				Value:                burnRate,
+				Threshold:            tier.Threshold,
+				Source:               "slo-evaluator:" + string(severity),
+				Severity:             string(severity),
+				BurnTier:             tier.Name,
+				LongWindowMinutes:    &long,
+				ShortWindowMinutes:   &short,
+				Events:               &sampled,
+				MinEvents:            &minEvents,
+				ETAExhaustionSeconds: etaSeconds,
+				EvaluatedAt:          now,
+			}, objective, dim), budget, forecast),
+			IdempotencyKey: buildIdempotencyKey(action.EventType, severity, slo.ID, objective, dim.Key, now),
+		})
 	}
 	if wasBreached != isBreached {
 		next.BreachTransitionAt = sql.NullTime{Valid: true, Time: now}
-		eventType := "error_budget_recovered"
+		eventType := events.ErrorBudgetRecovered
 		if isBreached {
-			eventType = "error_budget_exhausted"
-		}
-		idempotencyKey := buildIdempotencyKey(eventType, severity, slo.ID, now)
-		err = e.store.EnqueueOutbox(ctx, tx, "slo", slo.ID, eventType, map[string]any{
-			"serviceId":            slo.ServiceID.String(),
-			"sloId":                slo.ID.String(),
-			"eventType":            eventType,
-			"value":                burnRate,
-			"threshold":            burnThreshold,
-			"source":               "slo-evaluator:breach",
-			"severity":             "critical",
-			"etaExhaustionSeconds": etaSeconds,
-			"evaluatedAt":          now.Format(time.RFC3339),
-		}, idempotencyKey)
-		if err != nil {
-			return err
+			eventType = events.ErrorBudgetExhausted
 		}
+		plan.Events = append(plan.Events, plannedEvent{
+			EventType: eventType,
+			Data: withBudget(withSeries(events.Data{
+				EventType:            eventType,
+				ServiceID:            slo.ServiceID,
+				SLOID:                slo.ID,
+				Value:                burnRate,
+				Threshold:            tier.Threshold,
+				Source:               "slo-evaluator:breach",
+				Severity:             "critical",
+				ETAExhaustionSeconds: etaSeconds,
+				EvaluatedAt:          now,
+			}, objective, dim), budget, forecast),
+			IdempotencyKey: buildIdempotencyKey(eventType, severity, slo.ID, objective, dim.Key, now),
+		})
 	}
+	plan.Next = next
+	return plan
+}
 
-	if err := e.store.UpsertBurnStateTx(ctx, tx, next); err != nil {
-		return err
+// withSeries adds the objective name and the dimension of a grouped SLO to event data.
+func withSeries(data events.Data, objective string, dim sli.Dimension) events.Data {
+	data.Objective = objective
+	if dim.Key != "" {
+		data.DimensionKey = dim.Key
+		data.Dimensions = dim.Values
 	}
-	return tx.Commit()
+	return data
 }
 
-func buildIdempotencyKey(eventType string, severity burnSeverity, sloID uuid.UUID, ts time.Time) string {
-	minuteBucket := ts.UTC().Truncate(time.Minute).Unix()
-	return fmt.Sprintf("evaluator:%s:%s:%s:%d", eventType, severity, sloID.String(), minuteBucket)
+func withBudget(data events.Data, budget budgetStatus, forecast exhaustionForecast) events.Data {
+	data.BudgetRemaining = budget.Remaining
+	if forecast.LowerSeconds > 0 {
+		lower := forecast.LowerSeconds
+		data.ETAExhaustionLowerSeconds = &lower
+	}
+	if forecast.UpperSeconds > 0 {
+		upper := forecast.UpperSeconds
+		data.ETAExhaustionUpperSeconds = &upper
+	}
+	if budget.PeriodStart.IsZero() {
+		return data
+	}
+	start, end := budget.PeriodStart, budget.PeriodEnd
+	data.BudgetPeriodStart = &start
+	data.BudgetPeriodEnd = &end
+	return data
 }
 
-type sliDefinition struct {
-	SLOID         uuid.UUID
-	Route         string
-	Type          string
-	Threshold     float64
-	WindowMinutes int
-	Target        float64
+func positiveNullInt32(v int) sql.NullInt32 {
+	if v <= 0 {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Valid: true, Int32: int32(v)}
 }
 
-func parseSLIDefinition(slo store.SLO) (sliDefinition, bool) {
-	def := sliDefinition{
+func buildIdempotencyKey(eventType string, severity burnSeverity, sloID uuid.UUID, objective, dimensionKey string, ts time.Time) string {
+	parts := []string{"evaluator", eventType, string(severity), sloID.String()}
+	if objective != "" {
+		parts = append(parts, "objective:"+objective)
+	}
+	if dimensionKey != "" {
+		parts = append(parts, dimensionKey)
+	}
+	parts = append(parts, strconv.FormatInt(ts.UTC().Truncate(time.Minute).Unix(), 10))
+	return strings.Join(parts, ":")
+}
+
+func parseSLIDefinition(slo store.SLO) (sli.Definition, bool) {
+	def := sli.Definition{
 		SLOID:         slo.ID,
 		WindowMinutes: slo.WindowMinutes,
 		Target:        float64(slo.Target),
 	}
-	def.Route = strings.TrimSpace(stringFromAny(slo.Canonical["route"]))
 	def.Type = strings.TrimSpace(stringFromAny(slo.Canonical["type"]))
 	def.Threshold = numberFromAny(slo.Canonical["threshold"])
+	def.BudgetingMethod = strings.TrimSpace(stringFromAny(slo.Canonical["budgetingMethod"]))
+	if def.BudgetingMethod == "" {
+		def.BudgetingMethod = sli.BudgetingOccurrences
+	}
+	def.TimeSliceMinutes = int(numberFromAny(slo.Canonical["timeSliceMinutes"]))
+	def.TimeSliceQuantile = numberFromAny(slo.Canonical["timeSliceQuantile"])
+	def.MinSliceEvents = int(numberFromAny(slo.Canonical["minSliceEvents"]))
+	def.DatasourceURL = strings.TrimSpace(stringFromAny(slo.Canonical["datasourceUrl"]))
+	def.GoodQuery = strings.TrimSpace(stringFromAny(slo.Canonical["goodQuery"]))
+	def.BadQuery = strings.TrimSpace(stringFromAny(slo.Canonical["badQuery"]))
+	def.TotalQuery = strings.TrimSpace(stringFromAny(slo.Canonical["totalQuery"]))
+	def.SpanSelector = spanSelectorFromMap(slo.Canonical)
+	def.Good = spanSelectorFromAny(slo.Canonical["good"])
+	def.Bad = spanSelectorFromAny(slo.Canonical["bad"])
+	def.Total = spanSelectorFromAny(slo.Canonical["total"])
+	groupBy, _ := slo.Canonical["groupBy"].([]any)
+	for _, key := range groupBy {
+		if k := strings.TrimSpace(stringFromAny(key)); k != "" {
+			def.GroupBy = append(def.GroupBy, k)
+		}
+	}
 	if w := int(numberFromAny(slo.Canonical["windowMinutes"])); w > 0 {
 		def.WindowMinutes = w
 	}
@@ -287,46 +1119,12 @@ func parseSLIDefinition(slo store.SLO) (sliDefinition, bool) {
 		def.Target = t
 	}
 
-	if def.Route == "" || def.Type == "" || def.Threshold <= 0 {
-		return sliDefinition{}, false
+	if def.Type == "" {
+		return sli.Definition{}, false
 	}
 	return def, true
 }
 
-func (e *Evaluator) queryCompliance(ctx context.Context, def sliDefinition, windowMinutes int) (float64, error) {
-	route := strings.ReplaceAll(def.Route, "'", "\\'")
-	var sqlText string
-	if def.Type == "latency" {
-		sqlText = fmt.Sprintf(`SELECT
-  if(count() = 0, 1.0, 1 - (countIf(p99_ms > %f) / count())) AS compliance
-FROM (
-  SELECT quantile(0.99)(Duration / 1000000) AS p99_ms
-  FROM otel_traces
-  WHERE Timestamp >= now() - INTERVAL %d MINUTE
-    AND SpanAttributes['http.route'] = '%s'
-    AND ServiceName = 'api-gateway'
-  GROUP BY toStartOfInterval(Timestamp, INTERVAL 1 minute)
-)`, def.Threshold, windowMinutes, route)
-	} else {
-		sqlText = fmt.Sprintf(`SELECT
-  if(count() = 0, 1.0, 1 - (countIf(err_rate > %f) / count())) AS compliance
-FROM (
-  SELECT countIf(toInt32OrZero(SpanAttributes['http.status_code']) >= 500) / count() AS err_rate
-  FROM otel_traces
-  WHERE Timestamp >= now() - INTERVAL %d MINUTE
-    AND SpanAttributes['http.route'] = '%s'
-    AND ServiceName = 'api-gateway'
-  GROUP BY toStartOfInterval(Timestamp, INTERVAL 1 minute)
-)`, def.Threshold, windowMinutes, route)
-	}
-
-	var compliance float64
-	if err := e.chDB.QueryRowContext(ctx, sqlText).Scan(&compliance); err != nil {
-		return 0, err
-	}
-	return compliance, nil
-}
-
 type burnSeverity string
 
 const (
@@ -347,14 +1145,75 @@ func burnRate(compliance float64, target float64) float64 {
 	return consumed / errorBudget
 }
 
-func classifySeverity(fastRate, slowRate, fastThreshold, slowThreshold float64) burnSeverity {
-	if fastRate >= fastThreshold {
-		return severityFast
+// defaultBurnRateTiers are the global single-window fast and slow tiers used by SLOs without an AlertPolicy.
+func (e *Evaluator) defaultBurnRateTiers() []opensloparser.BurnRateTier {
+	return []opensloparser.BurnRateTier{
+		{Name: string(severityFast), Severity: string(severityFast), Threshold: e.cfg.FastBurnRateThreshold, LongWindowMinutes: e.cfg.FastWindowMinutes},
+		{Name: string(severitySlow), Severity: string(severitySlow), Threshold: e.cfg.SlowBurnRateThreshold, LongWindowMinutes: e.cfg.SlowWindowMinutes},
 	}
-	if slowRate >= slowThreshold {
-		return severitySlow
+}
+
+// selectTier returns the first tier, ordered most severe first, whose long and short window burn
+// rates both reach its threshold.
+func selectTier(tiers []opensloparser.BurnRateTier, rates map[int]float64) (opensloparser.BurnRateTier, bool) {
+	for _, t := range tiers {
+		if rates[t.LongWindowMinutes] < t.Threshold {
+			continue
+		}
+		if t.ShortWindowMinutes > 0 && rates[t.ShortWindowMinutes] < t.Threshold {
+			continue
+		}
+		return t, true
+	}
+	return opensloparser.BurnRateTier{}, false
+}
+
+// resolveTiers scales tier thresholds down to the level a burn has to fall below before it resolves.
+func resolveTiers(tiers []opensloparser.BurnRateTier, ratio float64) []opensloparser.BurnRateTier {
+	out := make([]opensloparser.BurnRateTier, 0, len(tiers))
+	for _, t := range tiers {
+		t.Threshold *= ratio
+		out = append(out, t)
+	}
+	return out
+}
+
+// tiersLackData reports whether any tier has a window below the minimum event count.
+func tiersLackData(tiers []opensloparser.BurnRateTier, sufficient map[int]bool) bool {
+	for _, t := range tiers {
+		if !sufficient[t.LongWindowMinutes] || (t.ShortWindowMinutes > 0 && !sufficient[t.ShortWindowMinutes]) {
+			return true
+		}
+	}
+	return false
+}
+
+// clampTierWindows keeps tier windows inside the SLO window and, for calendar SLOs, the elapsed period.
+func clampTierWindows(tiers []opensloparser.BurnRateTier, sloWindowMin, elapsedMin int) []opensloparser.BurnRateTier {
+	out := make([]opensloparser.BurnRateTier, 0, len(tiers))
+	for _, t := range tiers {
+		t.LongWindowMinutes = minPositive(minPositive(sloWindowMin, t.LongWindowMinutes), elapsedMin)
+		if t.ShortWindowMinutes > 0 {
+			t.ShortWindowMinutes = minPositive(t.ShortWindowMinutes, t.LongWindowMinutes)
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+func tierWindows(tiers []opensloparser.BurnRateTier) []int {
+	seen := map[int]bool{}
+	var out []int
+	for _, t := range tiers {
+		for _, w := range []int{t.ShortWindowMinutes, t.LongWindowMinutes} {
+			if w > 0 && !seen[w] {
+				seen[w] = true
+				out = append(out, w)
+			}
+		}
 	}
-	return severityNone
+	sort.Ints(out)
+	return out
 }
 
 func minPositive(a, b int) int {
@@ -370,11 +1229,13 @@ func minPositive(a, b int) int {
 	return b
 }
 
-func timeToExhaustionSeconds(burnRate float64, windowMinutes int) int {
-	if burnRate <= 0 || windowMinutes <= 0 {
+// timeToExhaustionSeconds spends the remaining budget fraction at burnRate, where a burn rate of 1
+// uses the whole budget over the SLO window.
+func timeToExhaustionSeconds(remaining, burnRate float64, windowMinutes int) int {
+	if burnRate <= 0 || windowMinutes <= 0 || remaining <= 0 {
 		return 0
 	}
-	seconds := float64(windowMinutes*60) / burnRate
+	seconds := remaining * float64(windowMinutes*60) / burnRate
 	if seconds <= 0 {
 		return 0
 	}
@@ -394,6 +1255,49 @@ func numberFromAny(v any) float64 {
 	}
 }
 
+func spanSelectorFromMap(m map[string]any) sli.SpanSelector {
+	return sli.SpanSelector{
+		Route:       strings.TrimSpace(stringFromAny(m["route"])),
+		ServiceName: strings.TrimSpace(stringFromAny(m["serviceName"])),
+		SpanKind:    strings.TrimSpace(stringFromAny(m["spanKind"])),
+		SpanName:    strings.TrimSpace(stringFromAny(m["spanName"])),
+		Attributes:  attributeMatchersFromAny(m["attributes"]),
+	}
+}
+
+func spanSelectorFromAny(v any) *sli.SpanSelector {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	sel := spanSelectorFromMap(m)
+	return &sel
+}
+
+func attributeMatchersFromAny(v any) []sli.AttributeMatcher {
+	items, _ := v.([]any)
+	out := make([]sli.AttributeMatcher, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		am := sli.AttributeMatcher{
+			Key: strings.TrimSpace(stringFromAny(m["key"])),
+			Op:  strings.TrimSpace(stringFromAny(m["op"])),
+		}
+		values, _ := m["values"].([]any)
+		for _, raw := range values {
+			am.Values = append(am.Values, stringFromAny(raw))
+		}
+		if am.Key == "" || len(am.Values) == 0 {
+			continue
+		}
+		out = append(out, am)
+	}
+	return out
+}
+
 func stringFromAny(v any) string {
 	switch s := v.(type) {
 	case string:
@@ -0,0 +1,225 @@
+package evaluator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/events"
+	opensloparser "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/openslo"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+var (
+	// ErrUnknownObjective is returned by Explain when the SLO has no objective of the requested name.
+	ErrUnknownObjective = errors.New("objective was not evaluated")
+	// ErrUnknownDimension is returned by Explain when the requested dimension was not evaluated.
+	ErrUnknownDimension = errors.New("dimension was not evaluated")
+)
+
+// Explanation shows how one evaluation of an SLO reached its result. It explains one objective and,
+// for a grouped SLO, one dimension, while the evaluation itself covers all of Objectives and
+// DimensionKeys.
+type Explanation struct {
+	SLOID         uuid.UUID
+	EvaluatedAt   time.Time
+	DryRun        bool
+	Objective     string
+	Objectives    []string
+	Dimension     sli.Dimension
+	DimensionKeys []string
+	Queries       []ExplainedQuery
+	Windows       []ExplainedWindow
+	Tiers         []ExplainedTier
+	// RawSeverity is the firing tier's severity before hysteresis and the traffic guard.
+	RawSeverity      string
+	Severity         string
+	BurnTier         string
+	Compliance       float64
+	BurnRate         float64
+	InsufficientData bool
+	BudgetRemaining  float64
+	BudgetExhausted  bool
+	ETASeconds       int
+	// InMaintenance is set while a maintenance window covers the SLO.
+	InMaintenance bool
+	// Action is what decideAction chose; EventType is empty when no burn event is due.
+	Action ExplainedAction
+	// Events are the outbox events the evaluation enqueued, or would enqueue in a dry run.
+	Events        []ExplainedEvent
+	QueryDuration time.Duration
+}
+
+type ExplainedQuery struct {
+	Objective     string
+	WindowMinutes int
+	sli.Query
+}
+
+type ExplainedWindow struct {
+	Minutes    int
+	Compliance float64
+	Events     float64
+	// Sufficient windows met the minimum traffic; only they have a burn rate.
+	Sufficient bool
+	BurnRate   float64
+}
+
+type ExplainedTier struct {
+	Name               string
+	Severity           string
+	Threshold          float64
+	ResolveThreshold   float64
+	LongWindowMinutes  int
+	ShortWindowMinutes int
+	Firing             bool
+}
+
+type ExplainedAction struct {
+	EventType string
+	EmitEvent bool
+}
+
+type ExplainedEvent struct {
+	EventType      string
+	IdempotencyKey string
+	Data           events.Data
+}
+
+// Explain evaluates one SLO now, the way EvaluateOnce does. Without dryRun the result is persisted
+// like any other evaluation; a dry run reads the burn state but writes nothing. For a grouped SLO
+// it explains the given objective and dimension key; when either is empty it explains the one
+// burning fastest.
+func (e *Evaluator) Explain(ctx context.Context, slo store.SLO, dryRun bool, objective, dimension string) (Explanation, error) {
+	tr := otel.Tracer("slo-control-plane/evaluator")
+	ctx, span := tr.Start(ctx, "evaluator.explain", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+	span.SetAttributes(attribute.Bool("slo.dry_run", dryRun))
+
+	now := time.Now().UTC()
+	exp := &Explanation{SLOID: slo.ID, EvaluatedAt: now, DryRun: dryRun, Objective: objective, Dimension: sli.Dimension{Key: dimension}}
+	started := time.Now()
+	points, queryTime, err := e.evaluateSLO(ctx, span, slo, now, exp)
+	if !dryRun && !errors.Is(err, ErrUnknownObjective) && !errors.Is(err, ErrUnknownDimension) {
+		e.recordOutcome(ctx, slo, now, time.Since(started), queryTime, err)
+		if err == nil {
+			e.appendHistory(ctx, points)
+		}
+	}
+	if err != nil {
+		return Explanation{}, err
+	}
+	exp.QueryDuration = queryTime
+	return *exp, nil
+}
+
+// ErrorStage returns the evaluation stage an Explain error came from: backend, definition, query
+// or persist. It is empty for other errors.
+func ErrorStage(err error) string {
+	var stageErr *evaluationError
+	if errors.As(err, &stageErr) {
+		return stageErr.Stage
+	}
+	return ""
+}
+
+// choose picks the measurement to explain: the requested objective and dimension, or else among
+// them the one with the highest burn rate. It returns -1 when a grouped SLO had no dimensions to
+// evaluate.
+func (x *Explanation) choose(objectives []opensloparser.Objective, ms []measurement) (int, error) {
+	found := false
+	for _, objective := range objectives {
+		x.Objectives = append(x.Objectives, objective.Name)
+		found = found || objective.Name == x.Objective
+	}
+	if x.Objective != "" && !found {
+		return -1, fmt.Errorf("%w: %q", ErrUnknownObjective, x.Objective)
+	}
+	seen := map[string]bool{}
+	chosen := -1
+	for i, m := range ms {
+		if !seen[m.Dimension.Key] {
+			seen[m.Dimension.Key] = true
+			x.DimensionKeys = append(x.DimensionKeys, m.Dimension.Key)
+		}
+		if x.Objective != "" && m.Objective.Name != x.Objective {
+			continue
+		}
+		if x.Dimension.Key != "" && m.Dimension.Key != x.Dimension.Key {
+			continue
+		}
+		if chosen < 0 || m.Eval.BurnRate > ms[chosen].Eval.BurnRate {
+			chosen = i
+		}
+	}
+	if x.Dimension.Key != "" && chosen < 0 {
+		return -1, fmt.Errorf("%w: %q", ErrUnknownDimension, x.Dimension.Key)
+	}
+	return chosen, nil
+}
+
+func (x *Explanation) addQueries(explainer sli.Explainer, objective string, def sli.Definition, windowMinutes int, end time.Time) {
+	queries, err := explainer.Explain(def, windowMinutes, end)
+	if err != nil {
+		// The same error fails the query itself.
+		return
+	}
+	for _, q := range queries {
+		x.Queries = append(x.Queries, ExplainedQuery{Objective: objective, WindowMinutes: windowMinutes, Query: q})
+	}
+}
+
+func (x *Explanation) record(resolveRatio float64, m measurement, plan evaluationPlan) {
+	x.Objective = m.Objective.Name
+	x.Dimension = m.Dimension
+	// Keep only the statements behind the explained objective.
+	queries := x.Queries[:0]
+	for _, q := range x.Queries {
+		if q.Objective == m.Objective.Name {
+			queries = append(queries, q)
+		}
+	}
+	x.Queries = queries
+	for _, window := range tierWindows(m.Tiers) {
+		x.Windows = append(x.Windows, ExplainedWindow{
+			Minutes:    window,
+			Compliance: m.Samples[window].Compliance,
+			Events:     m.Samples[window].Events,
+			Sufficient: m.Sufficient[window],
+			BurnRate:   m.Rates[window],
+		})
+	}
+	resolve := resolveTiers(m.Tiers, resolveRatio)
+	for i, t := range m.Tiers {
+		_, firing := selectTier(m.Tiers[i:i+1], m.Rates)
+		x.Tiers = append(x.Tiers, ExplainedTier{
+			Name:               t.Name,
+			Severity:           t.Severity,
+			Threshold:          t.Threshold,
+			ResolveThreshold:   resolve[i].Threshold,
+			LongWindowMinutes:  t.LongWindowMinutes,
+			ShortWindowMinutes: t.ShortWindowMinutes,
+			Firing:             firing,
+		})
+	}
+	x.RawSeverity = string(m.Eval.Severity)
+	x.Compliance = m.Eval.Sample.Compliance
+	x.BurnRate = m.Eval.BurnRate
+	x.BudgetRemaining = m.Eval.Budget.Remaining
+	x.Severity = string(plan.Severity)
+	x.BurnTier = plan.Tier
+	x.InsufficientData = plan.Insufficient
+	x.BudgetExhausted = plan.Next.IsBreached
+	x.ETASeconds = plan.ETASeconds
+	x.Action = ExplainedAction{EventType: plan.Action.EventType, EmitEvent: plan.Action.EmitEvent}
+	for _, ev := range plan.Events {
+		x.Events = append(x.Events, ExplainedEvent{EventType: ev.EventType, IdempotencyKey: ev.IdempotencyKey, Data: ev.Data})
+	}
+}
@@ -0,0 +1,162 @@
+package evaluator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	opensloparser "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/openslo"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+func TestPlanEvaluationStartsBurnWithoutStore(t *testing.T) {
+	e := New(nil, nil, Config{ContinueInterval: 5 * time.Minute})
+	tiers := e.defaultBurnRateTiers()
+	slo := store.SLO{ID: uuid.New(), ServiceID: uuid.New()}
+	now := time.Unix(1700000000, 0).UTC()
+	plan := e.planEvaluation(slo, "", sli.Dimension{}, evaluation{
+		Sample:    sli.Sample{Compliance: 0.8, Events: 500},
+		Severity:  severityFast,
+		Firing:    true,
+		BurnRate:  20,
+		Tier:      tiers[0],
+		Sustain:   tiers[0],
+		Sustained: true,
+		Budget:    budgetStatus{Remaining: 0.5},
+	}, store.BurnState{}, false, now)
+	if plan.Severity != severityFast || !plan.Next.IsBurning || plan.Action.EventType != "burn_started" {
+		t.Fatalf("expected a fast burn to start, got %+v", plan)
+	}
+	if len(plan.Events) != 1 || plan.Events[0].Data.BurnTier != "fast" || plan.Events[0].IdempotencyKey == "" {
+		t.Fatalf("unexpected planned events: %+v", plan.Events)
+	}
+
+	// A burn that is already open and recently continued plans no event.
+	prev := plan.Next
+	prev.LastContinuedAt = prev.LastTransitionAt
+	plan = e.planEvaluation(slo, "", sli.Dimension{}, evaluation{
+		Sample:    sli.Sample{Compliance: 0.8, Events: 500},
+		Severity:  severityFast,
+		Firing:    true,
+		BurnRate:  20,
+		Tier:      tiers[0],
+		Sustain:   tiers[0],
+		Sustained: true,
+		Budget:    budgetStatus{Remaining: 0.49},
+	}, prev, true, now.Add(30*time.Second))
+	if plan.Action.EmitEvent || len(plan.Events) != 0 {
+		t.Fatalf("expected no event while the burn continues, got %+v", plan.Events)
+	}
+}
+
+func TestExplanationRecordsTiers(t *testing.T) {
+	e := New(nil, nil, Config{})
+	tiers := e.defaultBurnRateTiers()
+	m := measurement{
+		Eval:       evaluation{Severity: severitySlow},
+		Tiers:      tiers,
+		Samples:    map[int]sli.Sample{5: {Compliance: 0.9, Events: 3}, 60: {Compliance: 0.97, Events: 400}},
+		Sufficient: map[int]bool{60: true},
+		Rates:      map[int]float64{60: 3},
+	}
+	var exp Explanation
+	exp.record(0.5, m, evaluationPlan{Severity: severitySlow, Tier: "slow", Action: actionDecision{EventType: "burn_started", EmitEvent: true}})
+	if len(exp.Windows) != 2 || exp.Windows[0].Sufficient || !exp.Windows[1].Sufficient {
+		t.Fatalf("unexpected windows: %+v", exp.Windows)
+	}
+	if len(exp.Tiers) != 2 || exp.Tiers[0].Firing || !exp.Tiers[1].Firing || exp.Tiers[1].ResolveThreshold != 1 {
+		t.Fatalf("unexpected tiers: %+v", exp.Tiers)
+	}
+	if exp.Severity != "slow" || exp.Action.EventType != "burn_started" {
+		t.Fatalf("unexpected decision: %+v", exp)
+	}
+}
+
+func TestTopDimensionsKeepsBusiestGroups(t *testing.T) {
+	group := func(key string, events float64) sli.GroupSample {
+		return sli.GroupSample{Dimension: sli.Dimension{Key: key}, Sample: sli.Sample{Compliance: 1, Events: events}}
+	}
+	budget := map[string]sli.GroupSample{"a": group("a", 10), "b": group("b", 300), "c": group("c", 50)}
+	windows := map[int]map[string]sli.GroupSample{5: {"d": group("d", 1)}}
+	dims, dropped := topDimensions(windows, budget, 2, nil)
+	if dropped != 2 || len(dims) != 2 || dims[0].Key != "b" || dims[1].Key != "c" {
+		t.Fatalf("expected the two busiest dimensions in key order, got %+v dropped=%d", dims, dropped)
+	}
+	if dims, _ := topDimensions(windows, budget, 10, nil); len(dims) != 4 {
+		t.Fatalf("expected dimensions from every window, got %+v", dims)
+	}
+}
+
+func TestTopDimensionsKeepsOpenDimensions(t *testing.T) {
+	group := func(key string, events float64) sli.GroupSample {
+		return sli.GroupSample{Dimension: sli.Dimension{Key: key, Values: map[string]string{"k": key}}, Sample: sli.Sample{Compliance: 1, Events: events}}
+	}
+	budget := map[string]sli.GroupSample{"a": group("a", 10), "b": group("b", 300), "c": group("c", 50)}
+	windows := map[int]map[string]sli.GroupSample{5: {"b": group("b", 5)}}
+	// "a" burns but falls outside the cap; "gone" burns but no longer reports.
+	open := []sli.Dimension{{Key: "a"}, {Key: "gone", Values: map[string]string{"k": "gone"}}}
+	dims, dropped := topDimensions(windows, budget, 1, open)
+	if dropped != 1 || len(dims) != 3 || dims[0].Key != "a" || dims[1].Key != "b" || dims[2].Key != "gone" {
+		t.Fatalf("expected open dimensions beyond the cap, got %+v dropped=%d", dims, dropped)
+	}
+	if dims[0].Values["k"] != "a" {
+		t.Fatalf("expected the reported dimension values, got %+v", dims[0])
+	}
+	if !reporting(windows, budget, "a") || !reporting(windows, budget, "b") || reporting(windows, budget, "gone") {
+		t.Fatalf("unexpected reporting state")
+	}
+}
+
+func TestQuietOpenDimensionResolves(t *testing.T) {
+	e := New(nil, nil, Config{ContinueInterval: 5 * time.Minute})
+	tiers := e.defaultBurnRateTiers()
+	slo := store.SLO{ID: uuid.New(), ServiceID: uuid.New()}
+	prev := store.BurnState{SLOID: slo.ID, DimensionKey: "gone", IsBurning: true, IsBreached: true, CurrentSeverity: string(severityFast)}
+	quiet := evaluation{Sample: sli.Sample{Compliance: 1}, Severity: severityNone, Tier: tiers[1], Budget: budgetStatus{Remaining: 1}}
+	plan := e.planEvaluation(slo, "", sli.Dimension{Key: "gone"}, quiet, prev, true, time.Now())
+	if plan.Next.IsBurning || plan.Next.IsBreached || len(plan.Events) != 2 || plan.Events[0].EventType != "burn_resolved" {
+		t.Fatalf("expected the quiet dimension to resolve, got %+v", plan)
+	}
+}
+
+func TestExplanationChoosesDimension(t *testing.T) {
+	ms := []measurement{
+		{Dimension: sli.Dimension{Key: "region=eu"}, Eval: evaluation{BurnRate: 1}},
+		{Dimension: sli.Dimension{Key: "region=us"}, Eval: evaluation{BurnRate: 8}},
+	}
+	var exp Explanation
+	if i, err := exp.choose(nil, ms); err != nil || i != 1 || len(exp.DimensionKeys) != 2 {
+		t.Fatalf("expected the fastest-burning dimension, got %d err=%v", i, err)
+	}
+	exp = Explanation{Dimension: sli.Dimension{Key: "region=eu"}}
+	if i, err := exp.choose(nil, ms); err != nil || i != 0 {
+		t.Fatalf("expected the requested dimension, got %d err=%v", i, err)
+	}
+	exp = Explanation{Dimension: sli.Dimension{Key: "region=ap"}}
+	if _, err := exp.choose(nil, ms); !errors.Is(err, ErrUnknownDimension) {
+		t.Fatalf("expected ErrUnknownDimension, got %v", err)
+	}
+}
+
+func TestExplanationChoosesObjective(t *testing.T) {
+	objectives := []opensloparser.Objective{{Name: "fast"}, {Name: "slow"}}
+	ms := []measurement{
+		{Objective: objectives[0], Eval: evaluation{BurnRate: 2}},
+		{Objective: objectives[1], Eval: evaluation{BurnRate: 9}},
+	}
+	exp := Explanation{Objective: "fast"}
+	if i, err := exp.choose(objectives, ms); err != nil || i != 0 || len(exp.Objectives) != 2 || len(exp.DimensionKeys) != 1 {
+		t.Fatalf("expected the requested objective, got %d err=%v exp=%+v", i, err, exp)
+	}
+	exp = Explanation{}
+	if i, err := exp.choose(objectives, ms); err != nil || i != 1 {
+		t.Fatalf("expected the fastest-burning objective, got %d err=%v", i, err)
+	}
+	exp = Explanation{Objective: "missing"}
+	if _, err := exp.choose(objectives, ms); !errors.Is(err, ErrUnknownObjective) {
+		t.Fatalf("expected ErrUnknownObjective, got %v", err)
+	}
+}
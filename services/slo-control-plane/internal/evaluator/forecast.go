@@ -0,0 +1,124 @@
+package evaluator
+
+import (
+	"math"
+	"time"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+const (
+	// minForecastSamples is the fewest budget samples a trend is fitted to.
+	minForecastSamples = 3
+	// forecastZ gives a 95% confidence interval on the fitted slope.
+	forecastZ = 1.96
+)
+
+// exhaustionForecast is when the remaining error budget reaches zero. Zero seconds means no
+// exhaustion is forecast; a zero upper bound means the interval is open-ended.
+type exhaustionForecast struct {
+	ETASeconds   int
+	LowerSeconds int
+	UpperSeconds int
+}
+
+// appendBudgetSample adds the current remaining budget to the history, drops samples from before
+// since (a calendar period start, or zero) and keeps the newest max samples.
+func appendBudgetSample(history []store.BudgetSample, sample store.BudgetSample, since time.Time, max int) []store.BudgetSample {
+	out := make([]store.BudgetSample, 0, len(history)+1)
+	for _, s := range history {
+		if s.At.Before(since) || !s.At.Before(sample.At) {
+			continue
+		}
+		out = append(out, s)
+	}
+	out = append(out, sample)
+	if max > 0 && len(out) > max {
+		out = out[len(out)-max:]
+	}
+	return out
+}
+
+// forecastExhaustion fits remaining budget against time by weighted least squares and extrapolates
+// the latest remaining budget to zero. Samples are weighted by age with the given half-life; zero
+// weights them equally. ok is false when there are too few samples to fit a trend.
+func forecastExhaustion(samples []store.BudgetSample, now time.Time, halfLife time.Duration) (exhaustionForecast, bool) {
+	if len(samples) < minForecastSamples {
+		return exhaustionForecast{}, false
+	}
+	var sumW, sumW2, sumX, sumY float64
+	weights := make([]float64, len(samples))
+	xs := make([]float64, len(samples))
+	for i, s := range samples {
+		// Seconds relative to now keeps the fit numerically stable.
+		xs[i] = s.At.Sub(now).Seconds()
+		w := 1.0
+		if halfLife > 0 {
+			w = math.Exp2(xs[i] / halfLife.Seconds())
+		}
+		weights[i] = w
+		sumW += w
+		sumW2 += w * w
+		sumX += w * xs[i]
+		sumY += w * s.Remaining
+	}
+	meanX, meanY := sumX/sumW, sumY/sumW
+	var sxx, sxy float64
+	for i, s := range samples {
+		dx := xs[i] - meanX
+		sxx += weights[i] * dx * dx
+		sxy += weights[i] * dx * (s.Remaining - meanY)
+	}
+	if sxx <= 0 {
+		return exhaustionForecast{}, false
+	}
+	slope := sxy / sxx
+	intercept := meanY - slope*meanX
+
+	// Standard error of the slope, using the effective sample size of the weights.
+	var rss float64
+	for i, s := range samples {
+		r := s.Remaining - (intercept + slope*xs[i])
+		rss += weights[i] * r * r
+	}
+	nEff := sumW * sumW / sumW2
+	stderr := 0.0
+	if nEff > 2 {
+		stderr = math.Sqrt(rss / sumW * nEff / (nEff - 2) / sxx)
+	}
+
+	remaining := samples[len(samples)-1].Remaining
+	if remaining <= 0 {
+		return exhaustionForecast{}, true
+	}
+	eta := func(rate float64) int {
+		if rate >= 0 {
+			return 0
+		}
+		return int(math.Min(math.Ceil(remaining/-rate), math.MaxInt32))
+	}
+	return exhaustionForecast{
+		ETASeconds:   eta(slope),
+		LowerSeconds: eta(slope - forecastZ*stderr),
+		UpperSeconds: eta(slope + forecastZ*stderr),
+	}, true
+}
+
+// capAt drops forecasts that land after end, such as a calendar period end where the budget resets.
+func (f exhaustionForecast) capAt(now, end time.Time) exhaustionForecast {
+	if end.IsZero() {
+		return f
+	}
+	limit := end.Sub(now).Seconds()
+	drop := func(s int) int {
+		if float64(s) > limit {
+			return 0
+		}
+		return s
+	}
+	return exhaustionForecast{
+		ETASeconds:   drop(f.ETASeconds),
+		LowerSeconds: drop(f.LowerSeconds),
+		UpperSeconds: drop(f.UpperSeconds),
+	}
+}
@@ -0,0 +1,89 @@
+package evaluator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+func linearSamples(now time.Time, n int, step time.Duration, start, perStep float64, noise []float64) []store.BudgetSample {
+	out := make([]store.BudgetSample, 0, n)
+	for i := 0; i < n; i++ {
+		v := start + perStep*float64(i)
+		if len(noise) > 0 {
+			v += noise[i%len(noise)]
+		}
+		out = append(out, store.BudgetSample{At: now.Add(-time.Duration(n-1-i) * step), Remaining: v})
+	}
+	return out
+}
+
+func TestForecastExhaustionLinearTrend(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	// 1% of the budget per minute from 60% remaining leaves 50 minutes after the last sample.
+	samples := linearSamples(now, 11, time.Minute, 0.6, -0.01, nil)
+	for _, halfLife := range []time.Duration{0, 5 * time.Minute} {
+		got, ok := forecastExhaustion(samples, now, halfLife)
+		if !ok {
+			t.Fatalf("expected a forecast")
+		}
+		if got.ETASeconds < 2999 || got.ETASeconds > 3001 {
+			t.Fatalf("half-life %s: expected ~3000s ETA, got %+v", halfLife, got)
+		}
+		if got.LowerSeconds > got.ETASeconds || got.UpperSeconds < got.ETASeconds {
+			t.Fatalf("half-life %s: ETA outside its bounds: %+v", halfLife, got)
+		}
+	}
+}
+
+func TestForecastExhaustionNoisyTrendHasInterval(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	samples := linearSamples(now, 20, time.Minute, 0.9, -0.01, []float64{0.02, -0.015, 0.01, -0.02})
+	got, ok := forecastExhaustion(samples, now, 0)
+	if !ok || got.ETASeconds == 0 {
+		t.Fatalf("expected a forecast, got %+v", got)
+	}
+	if !(got.LowerSeconds < got.ETASeconds && got.ETASeconds < got.UpperSeconds) {
+		t.Fatalf("expected lower < eta < upper, got %+v", got)
+	}
+}
+
+func TestForecastExhaustionNoDecline(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	for name, perStep := range map[string]float64{"flat": 0, "recovering": 0.01} {
+		got, ok := forecastExhaustion(linearSamples(now, 10, time.Minute, 0.5, perStep, nil), now, 0)
+		if !ok || got.ETASeconds != 0 || got.UpperSeconds != 0 {
+			t.Fatalf("%s: expected no exhaustion, got %+v", name, got)
+		}
+	}
+	if _, ok := forecastExhaustion(linearSamples(now, 2, time.Minute, 0.5, -0.1, nil), now, 0); ok {
+		t.Fatalf("expected too few samples to fit")
+	}
+}
+
+func TestAppendBudgetSampleWindowing(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	history := linearSamples(now.Add(-time.Minute), 5, time.Minute, 1, -0.1, nil)
+	got := appendBudgetSample(history, store.BudgetSample{At: now, Remaining: 0.4}, time.Time{}, 3)
+	if len(got) != 3 || !got[2].At.Equal(now) || !got[0].At.Equal(now.Add(-2*time.Minute)) {
+		t.Fatalf("expected newest three samples, got %+v", got)
+	}
+	// A calendar period boundary forgets the previous period's samples.
+	got = appendBudgetSample(history, store.BudgetSample{At: now, Remaining: 1}, now.Add(-90*time.Second), 10)
+	if len(got) != 2 {
+		t.Fatalf("expected samples from the current period only, got %+v", got)
+	}
+}
+
+func TestExhaustionForecastCapAt(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	f := exhaustionForecast{ETASeconds: 7200, LowerSeconds: 1800, UpperSeconds: 20000}
+	got := f.capAt(now, now.Add(3*time.Hour))
+	if got.ETASeconds != 7200 || got.LowerSeconds != 1800 || got.UpperSeconds != 0 {
+		t.Fatalf("expected upper bound past the period end dropped, got %+v", got)
+	}
+	if got := f.capAt(now, time.Time{}); got != f {
+		t.Fatalf("expected rolling forecast unchanged, got %+v", got)
+	}
+}
@@ -1,6 +1,12 @@
 package evaluator
 
-import "time"
+import (
+	"math"
+	"math/bits"
+	"time"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/events"
+)
 
 type decideInput struct {
 	Now              time.Time
@@ -10,6 +16,11 @@ type decideInput struct {
 	PrevIsBurning    bool
 	PrevSeverity     burnSeverity
 	LastContinuedAt  time.Time
+	// InsufficientData is set when too few events were seen to trust the sample.
+	InsufficientData bool
+	PrevInsufficient bool
+	// Maintenance holds back burn_started and burn_continued; burns still resolve.
+	Maintenance bool
 }
 
 type actionDecision struct {
@@ -18,29 +29,203 @@ type actionDecision struct {
 }
 
 func decideAction(in decideInput) actionDecision {
+	if in.InsufficientData {
+		// Never start or resolve a burn on a statistically meaningless sample.
+		if !in.PrevInsufficient {
+			return actionDecision{EventType: events.InsufficientData, EmitEvent: true}
+		}
+		return actionDecision{}
+	}
+	action := decideBurnAction(in)
+	if in.PrevInsufficient && !action.EmitEvent {
+		return actionDecision{EventType: events.InsufficientDataResolved, EmitEvent: true}
+	}
+	return action
+}
+
+func decideBurnAction(in decideInput) actionDecision {
 	isBurningNow := in.SeverityNow != severityNone
 
 	if !in.HasPrevState {
-		if isBurningNow {
-			return actionDecision{EventType: "burn_started", EmitEvent: true}
+		if isBurningNow && !in.Maintenance {
+			return actionDecision{EventType: events.BurnStarted, EmitEvent: true}
 		}
 		return actionDecision{}
 	}
 
-	if isBurningNow && !in.PrevIsBurning {
-		return actionDecision{EventType: "burn_started", EmitEvent: true}
+	if isBurningNow && !in.PrevIsBurning && !in.Maintenance {
+		return actionDecision{EventType: events.BurnStarted, EmitEvent: true}
 	}
 	if !isBurningNow && in.PrevIsBurning {
-		return actionDecision{EventType: "burn_resolved", EmitEvent: true}
+		return actionDecision{EventType: events.BurnResolved, EmitEvent: true}
 	}
-	if isBurningNow && in.PrevIsBurning {
+	if isBurningNow && in.PrevIsBurning && !in.Maintenance {
 		// Severity transitions while already burning should be visible immediately.
 		if in.PrevSeverity != in.SeverityNow {
-			return actionDecision{EventType: "burn_continued", EmitEvent: true}
+			return actionDecision{EventType: events.BurnContinued, EmitEvent: true}
 		}
 		if in.LastContinuedAt.IsZero() || in.Now.Sub(in.LastContinuedAt) >= in.ContinueInterval {
-			return actionDecision{EventType: "burn_continued", EmitEvent: true}
+			return actionDecision{EventType: events.BurnContinued, EmitEvent: true}
 		}
 	}
 	return actionDecision{}
 }
+
+// Hysteresis damps burn state flapping around a tier threshold.
+type Hysteresis struct {
+	// ResolveRatio scales tier thresholds to get the resolve threshold; an ongoing burn continues
+	// while any tier stays above it. 1 resolves on the firing threshold.
+	ResolveRatio float64
+	// MinHold is how long a burn stays open after it started before it may resolve.
+	MinHold time.Duration
+	// A burn starts only once a tier fired in StartEvaluations of the last StartWindow evaluations.
+	StartEvaluations int
+	StartWindow      int
+}
+
+// maxStartWindow keeps the firing history inside slo_burn_state.firing_history.
+const maxStartWindow = 31
+
+func (h Hysteresis) normalize() Hysteresis {
+	if h.ResolveRatio <= 0 || h.ResolveRatio > 1 {
+		h.ResolveRatio = 1
+	}
+	if h.MinHold < 0 {
+		h.MinHold = 0
+	}
+	if h.StartEvaluations <= 0 {
+		h.StartEvaluations = 1
+	}
+	if h.StartEvaluations > maxStartWindow {
+		h.StartEvaluations = maxStartWindow
+	}
+	if h.StartWindow < h.StartEvaluations {
+		h.StartWindow = h.StartEvaluations
+	}
+	if h.StartWindow > maxStartWindow {
+		h.StartWindow = maxStartWindow
+	}
+	return h
+}
+
+type transitionInput struct {
+	Now time.Time
+	// Raw is the severity at firing thresholds, Sustain the severity at resolve thresholds.
+	Raw          burnSeverity
+	Sustain      burnSeverity
+	PrevBurning  bool
+	PrevSeverity burnSeverity
+	BurningSince time.Time
+	// History has one bit per recent evaluation, newest in bit 0, set when a tier fired.
+	History int
+}
+
+// apply returns the damped severity and the updated firing history.
+func (h Hysteresis) apply(in transitionInput) (burnSeverity, int) {
+	history := in.History << 1
+	if in.Raw != severityNone {
+		history |= 1
+	}
+	history &= 1<<h.StartWindow - 1
+
+	if !in.PrevBurning {
+		if in.Raw != severityNone && bits.OnesCount(uint(history)) >= h.StartEvaluations {
+			return in.Raw, history
+		}
+		return severityNone, history
+	}
+	switch {
+	case in.Raw != severityNone:
+		return in.Raw, history
+	case in.Sustain != severityNone:
+		return in.Sustain, history
+	case !in.BurningSince.IsZero() && in.Now.Sub(in.BurningSince) < h.MinHold:
+		return in.PrevSeverity, history
+	}
+	// A fresh streak is needed to start again, so a resolved burn cannot restart on stale history.
+	return severityNone, 0
+}
+
+// trafficCheck records which burn windows had too few events to be trusted when no tier fired.
+type trafficCheck struct {
+	// ReportingWindowLow is set when the reported (least severe tier's long) window is below the minimum.
+	ReportingWindowLow bool
+	// TierBlocked is set when some tier could not be evaluated because a window is below the minimum.
+	TierBlocked bool
+}
+
+// insufficient decides whether the burn state is held: nothing can be said without data in the
+// reporting window, and an ongoing burn is not resolved while one of its tiers cannot be evaluated.
+func (c trafficCheck) insufficient(prevBurning bool) bool {
+	return c.ReportingWindowLow || (prevBurning && c.TierBlocked)
+}
+
+type budgetStatus struct {
+	Exhausted   bool
+	ETASeconds  int
+	Remaining   float64
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+}
+
+// rollingBudget measures the budget over the whole SLO window, after maintenance exclusions: it is
+// exhausted once nothing remains, and the ETA spends it at the current burn rate; the trend forecast
+// replaces that ETA once there is enough history.
+func rollingBudget(windowCompliance, target, burnRate float64, sloWindowMin int) budgetStatus {
+	remaining := budgetRemaining(windowCompliance, target)
+	return budgetStatus{
+		Exhausted:  remaining <= 0,
+		Remaining:  remaining,
+		ETASeconds: timeToExhaustionSeconds(remaining, burnRate, sloWindowMin),
+	}
+}
+
+// budgetRemaining is the unspent fraction of the error budget given compliance over the SLO window.
+func budgetRemaining(compliance, target float64) float64 {
+	allowed := 1.0 - target
+	if allowed <= 0 {
+		return 1
+	}
+	return math.Min(1, 1-(1-compliance)/allowed)
+}
+
+type calendarBudgetInput struct {
+	Now              time.Time
+	PeriodStart      time.Time
+	PeriodEnd        time.Time
+	Target           float64
+	PeriodCompliance float64
+	BurnRate         float64
+}
+
+// calendarBudget spends the whole period's budget pro rata to elapsed time, assuming even traffic,
+// so the budget starts full at every period boundary.
+func calendarBudget(in calendarBudgetInput) budgetStatus {
+	out := budgetStatus{PeriodStart: in.PeriodStart, PeriodEnd: in.PeriodEnd, Remaining: 1}
+	period := in.PeriodEnd.Sub(in.PeriodStart).Seconds()
+	allowed := 1.0 - in.Target
+	if period <= 0 || allowed <= 0 {
+		return out
+	}
+	elapsed := in.Now.Sub(in.PeriodStart).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	bad := 1.0 - in.PeriodCompliance
+	if bad < 0 {
+		bad = 0
+	}
+	out.Remaining = 1.0 - (bad/allowed)*(elapsed/period)
+	if out.Remaining <= 0 {
+		out.Exhausted = true
+		return out
+	}
+	if in.BurnRate <= 0 {
+		return out
+	}
+	eta := out.Remaining * period / in.BurnRate
+	if in.Now.Add(time.Duration(eta * float64(time.Second))).Before(in.PeriodEnd) {
+		out.ETASeconds = int(math.Ceil(eta))
+	}
+	return out
+}
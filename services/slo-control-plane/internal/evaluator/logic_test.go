@@ -3,6 +3,12 @@ package evaluator
 import (
 	"testing"
 	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/events"
+	opensloparser "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/openslo"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
 )
 
 func TestDecideEventTransitionStarted(t *testing.T) {
@@ -65,3 +71,260 @@ func TestDecideEventContinuedOnSeverityChange(t *testing.T) {
 		t.Fatalf("expected burn_continued, got %q", action.EventType)
 	}
 }
+
+func TestParseSLIDefinitionCarriesSpanFilters(t *testing.T) {
+	def, ok := parseSLIDefinition(store.SLO{
+		WindowMinutes: 30,
+		Canonical: map[string]any{
+			"type":        "latency",
+			"threshold":   float64(250),
+			"serviceName": "payment-service",
+			"spanKind":    "server",
+			"spanName":    "Charge",
+			"attributes": []any{
+				map[string]any{"key": "tenant.id", "op": "in", "values": []any{"acme", "globex"}},
+				map[string]any{"key": "", "op": "equals", "values": []any{"ignored"}},
+			},
+		},
+	})
+	if !ok {
+		t.Fatalf("expected definition to parse")
+	}
+	if def.ServiceName != "payment-service" || def.SpanKind != "server" || def.SpanName != "Charge" {
+		t.Fatalf("unexpected span filters: %#v", def)
+	}
+	if len(def.Attributes) != 1 || def.Attributes[0].Op != "in" || len(def.Attributes[0].Values) != 2 {
+		t.Fatalf("unexpected attribute matchers: %#v", def.Attributes)
+	}
+}
+
+func TestParseSLIDefinitionCarriesRatioSources(t *testing.T) {
+	def, ok := parseSLIDefinition(store.SLO{
+		Canonical: map[string]any{
+			"type":  "ratio",
+			"good":  map[string]any{"serviceName": "queue-worker", "spanName": "job.complete"},
+			"total": map[string]any{"serviceName": "queue-worker", "spanName": "job.enqueue"},
+		},
+	})
+	if !ok {
+		t.Fatalf("expected definition to parse")
+	}
+	if def.Good == nil || def.Good.SpanName != "job.complete" || def.Total == nil || def.Total.SpanName != "job.enqueue" || def.Bad != nil {
+		t.Fatalf("unexpected ratio sources: good=%#v bad=%#v total=%#v", def.Good, def.Bad, def.Total)
+	}
+}
+
+func TestCalendarBudgetResetsAtPeriodBoundary(t *testing.T) {
+	start := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	// Half the period gone at 99.8% against a 99.9% target: the whole budget is spent.
+	got := calendarBudget(calendarBudgetInput{
+		Now:              start.Add(end.Sub(start) / 2),
+		PeriodStart:      start,
+		PeriodEnd:        end,
+		Target:           0.999,
+		PeriodCompliance: 0.998,
+		BurnRate:         2,
+	})
+	if !got.Exhausted || got.ETASeconds != 0 {
+		t.Fatalf("expected exhausted budget, got %#v", got)
+	}
+
+	// One hour into the next period the budget is nearly full again.
+	got = calendarBudget(calendarBudgetInput{
+		Now:              end.Add(time.Hour),
+		PeriodStart:      end,
+		PeriodEnd:        time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC),
+		Target:           0.999,
+		PeriodCompliance: 0.998,
+		BurnRate:         2,
+	})
+	if got.Exhausted || got.Remaining < 0.99 {
+		t.Fatalf("expected budget reset in new period, got %#v", got)
+	}
+}
+
+func TestCalendarBudgetETAStopsAtPeriodEnd(t *testing.T) {
+	start := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(30 * 24 * time.Hour)
+	in := calendarBudgetInput{
+		Now:              start.Add(24 * time.Hour),
+		PeriodStart:      start,
+		PeriodEnd:        end,
+		Target:           0.99,
+		PeriodCompliance: 1,
+		BurnRate:         60,
+	}
+	got := calendarBudget(in)
+	// Full budget over a 30d period at 60x lasts 12h.
+	if got.ETASeconds != 12*3600 {
+		t.Fatalf("expected 12h ETA, got %d", got.ETASeconds)
+	}
+	in.BurnRate = 1
+	if got := calendarBudget(in); got.ETASeconds != 0 {
+		t.Fatalf("expected no ETA when budget outlasts the period, got %d", got.ETASeconds)
+	}
+}
+
+func TestDecideEventInsufficientData(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	base := decideInput{
+		Now:              now,
+		ContinueInterval: 5 * time.Minute,
+		HasPrevState:     true,
+		PrevIsBurning:    true,
+		PrevSeverity:     severityFast,
+		LastContinuedAt:  now.Add(-10 * time.Minute),
+	}
+	cases := []struct {
+		name             string
+		severityNow      burnSeverity
+		insufficient     bool
+		prevInsufficient bool
+		want             string
+	}{
+		// A quiet window must not resolve an ongoing burn, nor keep re-announcing it.
+		{"enter while burning", severityFast, true, false, "insufficient_data"},
+		{"stay insufficient", severityFast, true, true, ""},
+		{"data back, still burning", severityFast, false, true, "burn_continued"},
+		{"data back, recovered", severityNone, false, true, "burn_resolved"},
+	}
+	for _, tc := range cases {
+		in := base
+		in.SeverityNow = tc.severityNow
+		in.InsufficientData = tc.insufficient
+		in.PrevInsufficient = tc.prevInsufficient
+		if got := decideAction(in); got.EventType != tc.want {
+			t.Fatalf("%s: got %q want %q", tc.name, got.EventType, tc.want)
+		}
+	}
+
+	// Leaving insufficient data without any burn transition is announced on its own.
+	got := decideAction(decideInput{Now: now, SeverityNow: severityNone, HasPrevState: true, PrevInsufficient: true})
+	if got.EventType != "insufficient_data_resolved" {
+		t.Fatalf("expected insufficient_data_resolved, got %q", got.EventType)
+	}
+	// A brand new SLO with no traffic never starts a burn.
+	got = decideAction(decideInput{Now: now, SeverityNow: severityNone, InsufficientData: true})
+	if got.EventType != "insufficient_data" {
+		t.Fatalf("expected insufficient_data for new slo, got %q", got.EventType)
+	}
+}
+
+func TestTrafficCheckInsufficient(t *testing.T) {
+	cases := []struct {
+		check       trafficCheck
+		prevBurning bool
+		want        bool
+	}{
+		{trafficCheck{}, true, false},
+		{trafficCheck{ReportingWindowLow: true}, false, true},
+		{trafficCheck{TierBlocked: true}, false, false},
+		{trafficCheck{TierBlocked: true}, true, true},
+	}
+	for _, tc := range cases {
+		if got := tc.check.insufficient(tc.prevBurning); got != tc.want {
+			t.Fatalf("%+v.insufficient(%v) = %v, want %v", tc.check, tc.prevBurning, got, tc.want)
+		}
+	}
+}
+
+func TestHysteresisApply(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	h := Hysteresis{ResolveRatio: 0.8, MinHold: 10 * time.Minute, StartEvaluations: 3, StartWindow: 5}.normalize()
+	cases := []struct {
+		name        string
+		in          transitionInput
+		wantSev     burnSeverity
+		wantHistory int
+	}{
+		{"first crossing does not start", transitionInput{Raw: severityFast}, severityNone, 0b1},
+		{"two of five does not start", transitionInput{Raw: severityFast, History: 0b0100}, severityNone, 0b1001},
+		{"three of five starts", transitionInput{Raw: severityFast, History: 0b0101}, severityFast, 0b1011},
+		{"not firing now does not start", transitionInput{Raw: severityNone, History: 0b1111}, severityNone, 0b11110},
+		{"old crossings age out", transitionInput{Raw: severityFast, History: 0b110000}, severityNone, 0b00001},
+		{"burning continues above threshold", transitionInput{Raw: severitySlow, PrevBurning: true, PrevSeverity: severityFast, BurningSince: now.Add(-time.Hour), History: 0b11111}, severitySlow, 0b11111},
+		{"burning continues inside resolve band", transitionInput{Sustain: severityFast, PrevBurning: true, PrevSeverity: severityFast, BurningSince: now.Add(-time.Hour), History: 0b11111}, severityFast, 0b11110},
+		{"burning held for minimum time", transitionInput{PrevBurning: true, PrevSeverity: severityFast, BurningSince: now.Add(-5 * time.Minute), History: 0b111}, severityFast, 0b1110},
+		{"resolves below band after hold", transitionInput{PrevBurning: true, PrevSeverity: severityFast, BurningSince: now.Add(-15 * time.Minute), History: 0b111}, severityNone, 0},
+	}
+	for _, tc := range cases {
+		tc.in.Now = now
+		if tc.in.Raw == "" {
+			tc.in.Raw = severityNone
+		}
+		if tc.in.Sustain == "" {
+			tc.in.Sustain = severityNone
+		}
+		sev, history := h.apply(tc.in)
+		if sev != tc.wantSev || history != tc.wantHistory {
+			t.Fatalf("%s: got %q/%b want %q/%b", tc.name, sev, history, tc.wantSev, tc.wantHistory)
+		}
+	}
+}
+
+func TestHysteresisZeroValueFollowsThreshold(t *testing.T) {
+	h := Hysteresis{}.normalize()
+	if sev, _ := h.apply(transitionInput{Raw: severityFast, Sustain: severityFast}); sev != severityFast {
+		t.Fatalf("expected immediate start, got %q", sev)
+	}
+	now := time.Unix(1700000000, 0).UTC()
+	if sev, _ := h.apply(transitionInput{Now: now, Raw: severityNone, Sustain: severityNone, PrevBurning: true, PrevSeverity: severityFast, BurningSince: now}); sev != severityNone {
+		t.Fatalf("expected immediate resolve, got %q", sev)
+	}
+}
+
+func TestHysteresisFlappingRateStartsOnce(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	h := Hysteresis{ResolveRatio: 0.8, StartEvaluations: 2, StartWindow: 3}.normalize()
+	tiers := []opensloparser.BurnRateTier{{Name: "fast", Severity: string(severityFast), Threshold: 14.4, LongWindowMinutes: 5}}
+	sustainTiers := resolveTiers(tiers, h.ResolveRatio)
+
+	var (
+		severity burnSeverity = severityNone
+		history  int
+		since    time.Time
+		events   []string
+	)
+	for i, rate := range []float64{14.5, 14.3, 14.6, 14.2, 14.5, 13.9, 14.4, 12, 11} {
+		ts := now.Add(time.Duration(i) * time.Minute)
+		raw, sustain := severityNone, severityNone
+		if _, ok := selectTier(tiers, map[int]float64{5: rate}); ok {
+			raw = severityFast
+		}
+		if _, ok := selectTier(sustainTiers, map[int]float64{5: rate}); ok {
+			sustain = severityFast
+		}
+		prevBurning := severity != severityNone
+		next, nextHistory := h.apply(transitionInput{Now: ts, Raw: raw, Sustain: sustain, PrevBurning: prevBurning, PrevSeverity: severity, BurningSince: since, History: history})
+		action := decideAction(decideInput{Now: ts, SeverityNow: next, ContinueInterval: time.Hour, HasPrevState: i > 0, PrevIsBurning: prevBurning, PrevSeverity: severity, LastContinuedAt: since})
+		if action.EventType == "burn_started" {
+			since = ts
+		}
+		if action.EventType == "burn_started" || action.EventType == "burn_resolved" {
+			events = append(events, action.EventType)
+		}
+		severity, history = next, nextHistory
+	}
+	if len(events) != 2 || events[0] != "burn_started" || events[1] != "burn_resolved" {
+		t.Fatalf("expected one start and one resolve, got %v", events)
+	}
+}
+
+func TestRetirementEventsCloseRemovedObjective(t *testing.T) {
+	slo := store.SLO{ID: uuid.New(), ServiceID: uuid.New()}
+	now := time.Unix(1700000000, 0).UTC()
+	prev := store.BurnState{SLOID: slo.ID, Objective: "p95", DimensionKey: "region=eu", Dimensions: map[string]string{"region": "eu"}, IsBurning: true, IsBreached: true}
+	got := retirementEvents(slo, prev, now)
+	if len(got) != 2 || got[0].EventType != events.BurnResolved || got[1].EventType != events.ErrorBudgetRecovered {
+		t.Fatalf("expected burn_resolved and error_budget_recovered, got %+v", got)
+	}
+	if got[0].Data.Objective != "p95" || got[0].Data.DimensionKey != "region=eu" || got[0].IdempotencyKey == got[1].IdempotencyKey {
+		t.Fatalf("unexpected event series: %+v", got[0])
+	}
+	prev.IsBreached = false
+	if got := retirementEvents(slo, prev, now); len(got) != 1 {
+		t.Fatalf("expected only burn_resolved for a burn without breach, got %+v", got)
+	}
+}
@@ -0,0 +1,78 @@
+package evaluator
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/maintenance"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+type MaintenanceSource interface {
+	ListMaintenanceWindowsForSLO(ctx context.Context, sloID uuid.UUID) ([]store.MaintenanceWindow, error)
+}
+
+func (e *Evaluator) maintenanceWindows(ctx context.Context, slo store.SLO) ([]store.MaintenanceWindow, error) {
+	if e.cfg.Maintenance == nil {
+		return nil, nil
+	}
+	return e.cfg.Maintenance.ListMaintenanceWindowsForSLO(ctx, slo.ID)
+}
+
+func inMaintenance(windows []store.MaintenanceWindow, now time.Time) bool {
+	for _, w := range windows {
+		if w.Schedule.ActiveAt(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// maintenanceBetween reports whether an occurrence of any window overlaps [from, to).
+func maintenanceBetween(windows []store.MaintenanceWindow, from, to time.Time) bool {
+	for _, w := range windows {
+		if len(w.Schedule.Occurrences(from, to)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// budgetExclusions returns the merged spans of [from, to) that windows excluding their minutes from
+// budget accounting cover.
+func budgetExclusions(windows []store.MaintenanceWindow, from, to time.Time) []maintenance.Interval {
+	var out []maintenance.Interval
+	for _, w := range windows {
+		if w.ExcludeFromBudget {
+			out = append(out, w.Schedule.Occurrences(from, to)...)
+		}
+	}
+	return maintenance.Merge(out)
+}
+
+// excludeSamples removes the events measured during excluded spans from budget samples, per
+// dimension: good and total events are subtracted and compliance recomputed from what is left.
+// Timeslices samples weigh slice compliance by events, so for them the result is an estimate.
+func excludeSamples(budget map[string]sli.GroupSample, excluded []map[string]sli.GroupSample) map[string]sli.GroupSample {
+	out := make(map[string]sli.GroupSample, len(budget))
+	for key, g := range budget {
+		good, events := g.Compliance*g.Events, g.Events
+		for _, groups := range excluded {
+			if m, ok := groups[key]; ok && m.Events > 0 {
+				good -= m.Compliance * m.Events
+				events -= m.Events
+			}
+		}
+		if events <= 0 {
+			g.Sample = sli.Sample{Compliance: 1}
+		} else if events < g.Events {
+			g.Sample = sli.Sample{Compliance: math.Min(math.Max(good/events, 0), 1), Events: events}
+		}
+		out[key] = g
+	}
+	return out
+}
@@ -0,0 +1,139 @@
+package evaluator
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/events"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/maintenance"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+func TestPlanEvaluationHoldsBurnsDuringMaintenance(t *testing.T) {
+	e := New(nil, nil, Config{ContinueInterval: 5 * time.Minute})
+	tiers := e.defaultBurnRateTiers()
+	slo := store.SLO{ID: uuid.New(), ServiceID: uuid.New()}
+	now := time.Unix(1700000000, 0).UTC()
+	burning := evaluation{
+		Sample:      sli.Sample{Compliance: 0.8, Events: 500},
+		Severity:    severityFast,
+		Firing:      true,
+		BurnRate:    20,
+		Tier:        tiers[0],
+		Sustain:     tiers[0],
+		Sustained:   true,
+		Budget:      budgetStatus{Remaining: 0.5},
+		Maintenance: true,
+		HoldStarts:  true,
+	}
+	plan := e.planEvaluation(slo, "", sli.Dimension{}, burning, store.BurnState{}, false, now)
+	if plan.Next.IsBurning || len(plan.Events) != 0 {
+		t.Fatalf("expected no burn to start during maintenance, got %+v", plan)
+	}
+
+	// A burn that was open before the window stays open without burn_continued events.
+	prev := store.BurnState{SLOID: slo.ID, IsBurning: true, CurrentSeverity: string(severityFast)}
+	plan = e.planEvaluation(slo, "", sli.Dimension{}, burning, prev, true, now)
+	if !plan.Next.IsBurning || len(plan.Events) != 0 {
+		t.Fatalf("expected the open burn to be held without events, got %+v", plan)
+	}
+
+	// Right after the window its errors are still in the burn windows, so the burn is held back.
+	burning.Maintenance = false
+	plan = e.planEvaluation(slo, "", sli.Dimension{}, burning, store.BurnState{}, false, now.Add(time.Minute))
+	if plan.Next.IsBurning || len(plan.Events) != 0 {
+		t.Fatalf("expected no burn to start while the burn windows overlap maintenance, got %+v", plan)
+	}
+
+	// Once the burn windows are past the window the burn starts as usual.
+	burning.HoldStarts = false
+	plan = e.planEvaluation(slo, "", sli.Dimension{}, burning, store.BurnState{}, false, now.Add(time.Hour))
+	if plan.Action.EventType != "burn_started" {
+		t.Fatalf("expected burn_started after maintenance, got %q", plan.Action.EventType)
+	}
+}
+
+func TestPlanEvaluationHoldsBreachDuringMaintenance(t *testing.T) {
+	e := New(nil, nil, Config{ContinueInterval: 5 * time.Minute})
+	slo := store.SLO{ID: uuid.New(), ServiceID: uuid.New()}
+	now := time.Unix(1700000000, 0).UTC()
+	// The tier windows burned hard, but the SLO window has budget left once maintenance is excluded.
+	quiet := evaluation{
+		Sample:      sli.Sample{Compliance: 0.5, Events: 500},
+		Severity:    severityNone,
+		Tier:        e.defaultBurnRateTiers()[0],
+		Budget:      rollingBudget(0.995, 0.99, 50, 60),
+		Maintenance: true,
+		HoldStarts:  true,
+	}
+	plan := e.planEvaluation(slo, "", sli.Dimension{}, quiet, store.BurnState{}, false, now)
+	if plan.Next.IsBreached || len(plan.Events) != 0 {
+		t.Fatalf("expected no breach while the budget after exclusions remains, got %+v", plan)
+	}
+
+	// Even a spent budget does not start a breach during maintenance.
+	spent := quiet
+	spent.Budget = rollingBudget(0.95, 0.99, 50, 60)
+	plan = e.planEvaluation(slo, "", sli.Dimension{}, spent, store.BurnState{}, false, now)
+	if plan.Next.IsBreached || len(plan.Events) != 0 {
+		t.Fatalf("expected no breach during maintenance, got %+v", plan)
+	}
+
+	// A breach from before the window still recovers during it.
+	prev := store.BurnState{SLOID: slo.ID, IsBreached: true}
+	plan = e.planEvaluation(slo, "", sli.Dimension{}, quiet, prev, true, now)
+	if plan.Next.IsBreached || len(plan.Events) != 1 || plan.Events[0].EventType != events.ErrorBudgetRecovered {
+		t.Fatalf("expected the breach to recover during maintenance, got %+v", plan)
+	}
+
+	// After the window the spent budget breaches.
+	spent.Maintenance, spent.HoldStarts = false, false
+	plan = e.planEvaluation(slo, "", sli.Dimension{}, spent, store.BurnState{}, false, now.Add(time.Hour))
+	if !plan.Next.IsBreached || len(plan.Events) != 1 || plan.Events[0].EventType != events.ErrorBudgetExhausted {
+		t.Fatalf("expected error_budget_exhausted after maintenance, got %+v", plan)
+	}
+}
+
+func TestExcludeSamplesSubtractsMaintenanceEvents(t *testing.T) {
+	budget := map[string]sli.GroupSample{
+		"":  {Sample: sli.Sample{Compliance: 0.9, Events: 1000}},
+		"a": {Sample: sli.Sample{Compliance: 0.5, Events: 100}},
+	}
+	excluded := []map[string]sli.GroupSample{
+		{"": {Sample: sli.Sample{Compliance: 0.5, Events: 200}}},
+		{"a": {Sample: sli.Sample{Compliance: 0.5, Events: 100}}},
+	}
+	out := excludeSamples(budget, excluded)
+	// 900 good of 1000, less 100 good of 200, leaves 800 good of 800.
+	if got := out[""]; got.Events != 800 || math.Abs(got.Compliance-1) > 1e-9 {
+		t.Fatalf("unexpected sample after exclusion: %+v", got)
+	}
+	if got := out["a"]; got.Events != 0 || got.Compliance != 1 {
+		t.Fatalf("expected a fully excluded dimension to be compliant with no events, got %+v", got)
+	}
+}
+
+func TestBudgetExclusionsOnlyCountsExcludingWindows(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	windows := []store.MaintenanceWindow{
+		{ExcludeFromBudget: true, Schedule: maintenance.Schedule{StartsAt: from.Add(time.Hour), EndsAt: from.Add(2 * time.Hour)}},
+		{ExcludeFromBudget: true, Schedule: maintenance.Schedule{StartsAt: from.Add(90 * time.Minute), EndsAt: from.Add(3 * time.Hour)}},
+		{Schedule: maintenance.Schedule{StartsAt: from.Add(5 * time.Hour), EndsAt: from.Add(6 * time.Hour)}},
+	}
+	got := budgetExclusions(windows, from, from.Add(24*time.Hour))
+	if len(got) != 1 || !got[0].Start.Equal(from.Add(time.Hour)) || !got[0].End.Equal(from.Add(3*time.Hour)) {
+		t.Fatalf("unexpected exclusions: %+v", got)
+	}
+	if !inMaintenance(windows, from.Add(5*time.Hour)) || inMaintenance(windows, from.Add(4*time.Hour)) {
+		t.Fatalf("unexpected maintenance state")
+	}
+	// An hour-long burn window still holds the errors of a window that ended half an hour ago.
+	if !maintenanceBetween(windows, from.Add(6*time.Hour+30*time.Minute-time.Hour), from.Add(6*time.Hour+30*time.Minute)) ||
+		maintenanceBetween(windows, from.Add(7*time.Hour), from.Add(8*time.Hour)) {
+		t.Fatalf("unexpected maintenance overlap")
+	}
+}
@@ -0,0 +1,66 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/burn"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/events"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+// maxReplaySteps bounds one replay; each step runs every burn-window query.
+const maxReplaySteps = 50000
+
+// Replay evaluates an SLO as of each step from from to to, feeding the results through the burn
+// state machine from a clean state. It neither reads nor writes the live burn state. Events carry
+// the burn.ReplaySource prefix and IDs derived from their idempotency keys, so a re-run with the
+// same arguments yields the same events.
+func (e *Evaluator) Replay(ctx context.Context, slo store.SLO, from, to time.Time, step time.Duration) ([]burn.Event, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("replay step must be positive")
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("replay from must be before to")
+	}
+	if steps := to.Sub(from) / step; steps > maxReplaySteps {
+		return nil, fmt.Errorf("replay of %d steps exceeds the limit of %d; use a larger step", steps, maxReplaySteps)
+	}
+	maint, err := e.maintenanceWindows(ctx, slo)
+	if err != nil {
+		return nil, fmt.Errorf("maintenance windows: %w", err)
+	}
+	// Each objective and dimension has its own burn state, as in live evaluation.
+	prev := map[string]store.BurnState{}
+	var out []burn.Event
+	for at := from.UTC(); !at.After(to); at = at.Add(step) {
+		qctx, cancel := context.WithTimeout(ctx, e.cfg.SLOTimeout)
+		ms, _, err := e.measure(qctx, slo, maint, nil, at, at, nil)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("replay at %s: %w", at.Format(time.RFC3339), err)
+		}
+		for _, m := range ms {
+			key := m.Objective.Name + "\x00" + m.Dimension.Key
+			state, hasPrev := prev[key]
+			plan := e.planEvaluation(slo, m.Objective.Name, m.Dimension, m.Eval, state, hasPrev, at)
+			prev[key] = plan.Next
+			for _, ev := range plan.Events {
+				out = append(out, replayEvent(ev))
+			}
+		}
+	}
+	return out, nil
+}
+
+// replayEvent keeps the event time, the evaluated step, as its observed time.
+func replayEvent(ev plannedEvent) burn.Event {
+	key := "replay:" + strings.TrimPrefix(ev.IdempotencyKey, "evaluator:")
+	b := burn.FromEvent(events.New(uuid.NewSHA1(uuid.NameSpaceURL, []byte(key)), ev.Data), key)
+	b.Source = burn.ReplaySource + strings.TrimPrefix(ev.Data.Source, "slo-evaluator:")
+	return b
+}
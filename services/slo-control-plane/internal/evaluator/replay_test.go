@@ -0,0 +1,187 @@
+package evaluator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+// outageBackend reports an outage for windows ending inside [start, end).
+type outageBackend struct {
+	start, end time.Time
+	ends       []time.Time
+}
+
+func (b *outageBackend) Compliance(_ context.Context, _ sli.Definition, _ int, end time.Time) (sli.Sample, error) {
+	b.ends = append(b.ends, end)
+	if !end.Before(b.start) && end.Before(b.end) {
+		return sli.Sample{Compliance: 0.5, Events: 100}, nil
+	}
+	return sli.Sample{Compliance: 1, Events: 100}, nil
+}
+
+func (b *outageBackend) Close() error { return nil }
+
+func TestReplayRunsStateMachineAsOfEachStep(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	backend := &outageBackend{start: from.Add(10 * time.Minute), end: from.Add(20 * time.Minute)}
+	reg := sli.NewRegistry()
+	if err := reg.Register("fake", backend); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	e := New(nil, reg, Config{ContinueInterval: time.Hour})
+	slo := store.SLO{
+		ID:             uuid.New(),
+		ServiceID:      uuid.New(),
+		Target:         0.99,
+		WindowMinutes:  60,
+		DatasourceType: "fake",
+		Canonical:      map[string]any{"type": "error_rate"},
+	}
+
+	events, err := e.Replay(context.Background(), slo, from, from.Add(30*time.Minute), 5*time.Minute)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if len(backend.ends) == 0 || !backend.ends[0].Equal(from) {
+		t.Fatalf("expected queries to end at the replayed time, got %v", backend.ends)
+	}
+	var types []string
+	for _, ev := range events {
+		types = append(types, ev.EventType)
+		if !strings.HasPrefix(ev.Source, "slo-replay:") || !strings.HasPrefix(ev.IdempotencyKey, "replay:") {
+			t.Fatalf("expected a replay source and key, got %+v", ev)
+		}
+	}
+	if len(events) < 2 || events[0].EventType != "burn_started" || !events[0].ObservedAt.Equal(from.Add(10*time.Minute)) {
+		t.Fatalf("expected the burn to start at the outage, got %v", types)
+	}
+
+	again, err := e.Replay(context.Background(), slo, from, from.Add(30*time.Minute), 5*time.Minute)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if len(again) != len(events) || again[0].ID != events[0].ID || again[0].IdempotencyKey != events[0].IdempotencyKey {
+		t.Fatalf("expected a re-run to produce the same events")
+	}
+}
+
+func TestReplayRejectsBadRanges(t *testing.T) {
+	e := New(nil, sli.NewRegistry(), Config{})
+	now := time.Now()
+	if _, err := e.Replay(context.Background(), store.SLO{}, now, now.Add(-time.Hour), time.Minute); err == nil {
+		t.Fatalf("expected from after to to be rejected")
+	}
+	if _, err := e.Replay(context.Background(), store.SLO{}, now.Add(-365*24*time.Hour), now, time.Second); err == nil {
+		t.Fatalf("expected too many steps to be rejected")
+	}
+}
+
+// regionBackend reports an outage in one region only.
+type regionBackend struct {
+	failing string
+}
+
+func (b *regionBackend) Compliance(context.Context, sli.Definition, int, time.Time) (sli.Sample, error) {
+	return sli.Sample{Compliance: 1, Events: 200}, nil
+}
+
+func (b *regionBackend) GroupedCompliance(_ context.Context, def sli.Definition, _ int, _ time.Time) ([]sli.GroupSample, error) {
+	var out []sli.GroupSample
+	for _, region := range []string{"eu", "us"} {
+		sample := sli.Sample{Compliance: 1, Events: 100}
+		if region == b.failing {
+			sample.Compliance = 0.5
+		}
+		out = append(out, sli.GroupSample{Dimension: sli.NewDimension(def.GroupBy, []string{region}), Sample: sample})
+	}
+	return out, nil
+}
+
+func (b *regionBackend) Close() error { return nil }
+
+func TestReplayKeepsBurnStatePerDimension(t *testing.T) {
+	reg := sli.NewRegistry()
+	if err := reg.Register("fake", &regionBackend{failing: "us"}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	e := New(nil, reg, Config{ContinueInterval: time.Hour})
+	slo := store.SLO{
+		ID:             uuid.New(),
+		ServiceID:      uuid.New(),
+		Target:         0.99,
+		WindowMinutes:  60,
+		DatasourceType: "fake",
+		Canonical:      map[string]any{"type": "error_rate", "groupBy": []any{"region"}},
+	}
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	events, err := e.Replay(context.Background(), slo, from, from.Add(10*time.Minute), 5*time.Minute)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if len(events) == 0 || events[0].EventType != "burn_started" {
+		t.Fatalf("expected a burn to start, got %+v", events)
+	}
+	for _, ev := range events {
+		if ev.DimensionKey != "region=us" || ev.Dimensions["region"] != "us" {
+			t.Fatalf("expected events for the failing region only, got %+v", ev)
+		}
+	}
+	if !strings.Contains(events[0].IdempotencyKey, ":region=us:") {
+		t.Fatalf("expected the dimension in the idempotency key, got %q", events[0].IdempotencyKey)
+	}
+}
+
+// thresholdBackend fails every request slower than 200ms.
+type thresholdBackend struct{}
+
+func (thresholdBackend) Compliance(_ context.Context, def sli.Definition, _ int, _ time.Time) (sli.Sample, error) {
+	if def.Threshold < 200 {
+		return sli.Sample{Compliance: 0.5, Events: 100}, nil
+	}
+	return sli.Sample{Compliance: 1, Events: 100}, nil
+}
+
+func (thresholdBackend) Close() error { return nil }
+
+func TestReplayKeepsBurnStatePerObjective(t *testing.T) {
+	reg := sli.NewRegistry()
+	if err := reg.Register("fake", thresholdBackend{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	e := New(nil, reg, Config{ContinueInterval: time.Hour})
+	slo := store.SLO{
+		ID:             uuid.New(),
+		ServiceID:      uuid.New(),
+		Target:         0.99,
+		WindowMinutes:  60,
+		DatasourceType: "fake",
+		Canonical: map[string]any{
+			"type":      "latency",
+			"threshold": 300,
+			"objectives": []any{
+				map[string]any{"name": "typical", "target": 0.9, "threshold": 100},
+				map[string]any{"name": "tail", "target": 0.99, "threshold": 1000},
+			},
+		},
+	}
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	events, err := e.Replay(context.Background(), slo, from, from.Add(10*time.Minute), 5*time.Minute)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if len(events) == 0 || events[0].EventType != "burn_started" {
+		t.Fatalf("expected a burn to start, got %+v", events)
+	}
+	for _, ev := range events {
+		if ev.Objective != "typical" || !strings.Contains(ev.IdempotencyKey, ":objective:typical:") {
+			t.Fatalf("expected events for the breached objective only, got %+v", ev)
+		}
+	}
+}
@@ -1,25 +1,94 @@
 package evaluator
 
-import "testing"
+import (
+	"testing"
 
-func TestClassifySeverityFastWins(t *testing.T) {
-	sev := classifySeverity(16.0, 3.0, 14.4, 2.0)
-	if sev != severityFast {
-		t.Fatalf("expected fast severity, got %q", sev)
+	opensloparser "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/openslo"
+)
+
+func defaultTiers() []opensloparser.BurnRateTier {
+	e := New(nil, nil, Config{})
+	return e.defaultBurnRateTiers()
+}
+
+func TestSelectTierFastWins(t *testing.T) {
+	tier, ok := selectTier(defaultTiers(), map[int]float64{5: 16.0, 60: 3.0})
+	if !ok || burnSeverity(tier.Severity) != severityFast {
+		t.Fatalf("expected fast severity, got %q", tier.Severity)
 	}
 }
 
-func TestClassifySeveritySlow(t *testing.T) {
-	sev := classifySeverity(4.0, 2.5, 14.4, 2.0)
-	if sev != severitySlow {
-		t.Fatalf("expected slow severity, got %q", sev)
+func TestSelectTierSlow(t *testing.T) {
+	tier, ok := selectTier(defaultTiers(), map[int]float64{5: 4.0, 60: 2.5})
+	if !ok || burnSeverity(tier.Severity) != severitySlow {
+		t.Fatalf("expected slow severity, got %q", tier.Severity)
 	}
 }
 
-func TestClassifySeverityNone(t *testing.T) {
-	sev := classifySeverity(1.0, 1.5, 14.4, 2.0)
-	if sev != severityNone {
-		t.Fatalf("expected none severity, got %q", sev)
+func TestSelectTierNone(t *testing.T) {
+	if tier, ok := selectTier(defaultTiers(), map[int]float64{5: 1.0, 60: 1.5}); ok {
+		t.Fatalf("expected no tier, got %q", tier.Severity)
+	}
+}
+
+func TestSelectTierMultiWindow(t *testing.T) {
+	tiers := []opensloparser.BurnRateTier{
+		{Name: "page-1h", Severity: "page", Threshold: 14.4, LongWindowMinutes: 60, ShortWindowMinutes: 5},
+		{Name: "page-6h", Severity: "page", Threshold: 6, LongWindowMinutes: 360, ShortWindowMinutes: 30},
+		{Name: "ticket-3d", Severity: "ticket", Threshold: 1, LongWindowMinutes: 4320, ShortWindowMinutes: 360},
+	}
+	cases := []struct {
+		name  string
+		rates map[int]float64
+		want  string
+	}{
+		{"fast page", map[int]float64{5: 20, 60: 15, 30: 8, 360: 7, 4320: 2}, "page-1h"},
+		// The 1h window still burns hot but the 5m window has recovered, so the 1h tier resets.
+		{"short window recovered", map[int]float64{5: 2, 60: 15, 30: 8, 360: 7, 4320: 2}, "page-6h"},
+		{"slow ticket", map[int]float64{5: 1, 60: 1, 30: 1.5, 360: 1.5, 4320: 1.2}, "ticket-3d"},
+		{"none", map[int]float64{5: 30, 60: 0.5, 30: 0.5, 360: 0.5, 4320: 0.5}, ""},
+	}
+	for _, tc := range cases {
+		tier, _ := selectTier(tiers, tc.rates)
+		if tier.Name != tc.want {
+			t.Fatalf("%s: got tier %q want %q", tc.name, tier.Name, tc.want)
+		}
+	}
+}
+
+func TestSelectTierSkipsLowTrafficWindows(t *testing.T) {
+	tiers := []opensloparser.BurnRateTier{
+		{Name: "page-1h", Severity: "page", Threshold: 14.4, LongWindowMinutes: 60, ShortWindowMinutes: 5},
+		{Name: "ticket-6h", Severity: "ticket", Threshold: 6, LongWindowMinutes: 360, ShortWindowMinutes: 30},
+	}
+	// The 5m window had too few events, so it has no rate and the page tier cannot fire.
+	sufficient := map[int]bool{30: true, 60: true, 360: true}
+	tier, ok := selectTier(tiers, map[int]float64{60: 20, 30: 8, 360: 7})
+	if !ok || tier.Name != "ticket-6h" {
+		t.Fatalf("expected ticket tier, got %q", tier.Name)
+	}
+	if !tiersLackData(tiers, sufficient) {
+		t.Fatalf("expected page tier to lack data")
+	}
+	sufficient[5] = true
+	if tiersLackData(tiers, sufficient) {
+		t.Fatalf("expected all tiers to have data")
+	}
+}
+
+func TestClampTierWindows(t *testing.T) {
+	tiers := clampTierWindows([]opensloparser.BurnRateTier{
+		{LongWindowMinutes: 4320, ShortWindowMinutes: 360},
+		{LongWindowMinutes: 60, ShortWindowMinutes: 5},
+	}, 43200, 120)
+	if tiers[0].LongWindowMinutes != 120 || tiers[0].ShortWindowMinutes != 120 {
+		t.Fatalf("expected windows clamped to elapsed period, got %#v", tiers[0])
+	}
+	if tiers[1].LongWindowMinutes != 60 || tiers[1].ShortWindowMinutes != 5 {
+		t.Fatalf("expected short tier unchanged, got %#v", tiers[1])
+	}
+	if got := tierWindows(tiers); len(got) != 3 || got[0] != 5 || got[2] != 120 {
+		t.Fatalf("unexpected distinct windows %v", got)
 	}
 }
 
@@ -31,15 +100,22 @@ func TestBurnRate(t *testing.T) {
 }
 
 func TestTimeToExhaustionSeconds(t *testing.T) {
-	got := timeToExhaustionSeconds(2.0, 60)
+	got := timeToExhaustionSeconds(1, 2.0, 60)
 	if got != 1800 {
 		t.Fatalf("expected 1800 seconds, got %d", got)
 	}
+	// Only the remaining budget is left to spend.
+	if got := timeToExhaustionSeconds(0.25, 2.0, 60); got != 450 {
+		t.Fatalf("expected 450 seconds, got %d", got)
+	}
 }
 
 func TestTimeToExhaustionSecondsZeroRate(t *testing.T) {
-	got := timeToExhaustionSeconds(0, 60)
+	got := timeToExhaustionSeconds(1, 0, 60)
 	if got != 0 {
 		t.Fatalf("expected 0 seconds, got %d", got)
 	}
+	if got := timeToExhaustionSeconds(0, 2.0, 60); got != 0 {
+		t.Fatalf("expected 0 seconds with no budget left, got %d", got)
+	}
 }
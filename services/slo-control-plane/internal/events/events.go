@@ -0,0 +1,156 @@
+// Package events is the typed model of the SLO events the control plane emits, serialized as
+// CloudEvents 1.0. The evaluator produces it and every sink consumes it.
+package events
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	BurnStarted              = "burn_started"
+	BurnContinued            = "burn_continued"
+	BurnResolved             = "burn_resolved"
+	ErrorBudgetExhausted     = "error_budget_exhausted"
+	ErrorBudgetRecovered     = "error_budget_recovered"
+	InsufficientData         = "insufficient_data"
+	InsufficientDataResolved = "insufficient_data_resolved"
+)
+
+// Types lists every event type.
+var Types = []string{
+	BurnStarted, BurnContinued, BurnResolved,
+	ErrorBudgetExhausted, ErrorBudgetRecovered,
+	InsufficientData, InsufficientDataResolved,
+}
+
+const (
+	SpecVersion = "1.0"
+	// TypePrefix is prepended to the event type to form the CloudEvents type attribute.
+	TypePrefix = "io.github.jsimonovski.heatmap.slo."
+	// StructuredContentType is the content type of a CloudEvent in structured mode.
+	StructuredContentType = "application/cloudevents+json"
+	DataContentType       = "application/json"
+)
+
+// Data is the payload of an SLO event. Its JSON field names are the outbox payload keys.
+type Data struct {
+	EventType    string            `json:"eventType"`
+	ServiceID    uuid.UUID         `json:"serviceId"`
+	SLOID        uuid.UUID         `json:"sloId"`
+	Objective    string            `json:"objective,omitempty"`
+	DimensionKey string            `json:"dimensionKey,omitempty"`
+	Dimensions   map[string]string `json:"dimensions,omitempty"`
+	// Value is the burn rate and Threshold the firing threshold of the tier that decided the event.
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+	Source    string  `json:"source"`
+	Severity  string  `json:"severity"`
+	// Burn and insufficient-data events describe the deciding tier and sample; breach events do not.
+	BurnTier           string     `json:"burnTier,omitempty"`
+	LongWindowMinutes  *int       `json:"longWindowMinutes,omitempty"`
+	ShortWindowMinutes *int       `json:"shortWindowMinutes,omitempty"`
+	Events             *float64   `json:"events,omitempty"`
+	MinEvents          *int       `json:"minEvents,omitempty"`
+	EvaluatedAt        time.Time  `json:"evaluatedAt"`
+	BudgetRemaining    float64    `json:"budgetRemaining"`
+	BudgetPeriodStart  *time.Time `json:"budgetPeriodStart,omitempty"`
+	BudgetPeriodEnd    *time.Time `json:"budgetPeriodEnd,omitempty"`
+	// ETAExhaustionSeconds is 0 when no exhaustion is forecast; the bounds are null then.
+	ETAExhaustionSeconds      int  `json:"etaExhaustionSeconds"`
+	ETAExhaustionLowerSeconds *int `json:"etaExhaustionLowerSeconds"`
+	ETAExhaustionUpperSeconds *int `json:"etaExhaustionUpperSeconds"`
+}
+
+// Event is a CloudEvent carrying Data. ID stays the same across redeliveries, so consumers can
+// deduplicate on it together with Source.
+type Event struct {
+	ID     uuid.UUID
+	Source string
+	Type   string
+	Time   time.Time
+	Data   Data
+}
+
+// New wraps data in a CloudEvent that occurred when the data was evaluated.
+func New(id uuid.UUID, data Data) Event {
+	return Event{
+		ID:     id,
+		Source: "/services/" + data.ServiceID.String() + "/slos/" + data.SLOID.String(),
+		Type:   TypePrefix + data.EventType,
+		Time:   data.EvaluatedAt.UTC(),
+		Data:   data,
+	}
+}
+
+type envelope struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            Data      `json:"data"`
+}
+
+// MarshalJSON encodes the event in structured content mode.
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(envelope{
+		SpecVersion:     SpecVersion,
+		ID:              e.ID.String(),
+		Source:          e.Source,
+		Type:            e.Type,
+		Time:            e.Time,
+		DataContentType: DataContentType,
+		Data:            e.Data,
+	})
+}
+
+func (e *Event) UnmarshalJSON(b []byte) error {
+	var env envelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return err
+	}
+	if env.SpecVersion != SpecVersion {
+		return fmt.Errorf("unsupported CloudEvents specversion %q", env.SpecVersion)
+	}
+	if !strings.HasPrefix(env.Type, TypePrefix) {
+		return fmt.Errorf("unknown event type %q", env.Type)
+	}
+	id, err := uuid.Parse(env.ID)
+	if err != nil {
+		return fmt.Errorf("event id: %w", err)
+	}
+	*e = Event{ID: id, Source: env.Source, Type: env.Type, Time: env.Time, Data: env.Data}
+	return nil
+}
+
+// Binary encodes the event in binary content mode: attributes as ce- headers, data as the body.
+func (e Event) Binary() (http.Header, []byte, error) {
+	body, err := json.Marshal(e.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	h := http.Header{}
+	h.Set("ce-specversion", SpecVersion)
+	h.Set("ce-id", e.ID.String())
+	h.Set("ce-source", e.Source)
+	h.Set("ce-type", e.Type)
+	h.Set("ce-time", e.Time.Format(time.RFC3339Nano))
+	h.Set("Content-Type", DataContentType)
+	return h, body, nil
+}
+
+//go:embed schema.json
+var schema []byte
+
+// Schema returns the JSON Schema of a structured-mode event.
+func Schema() []byte {
+	return schema
+}
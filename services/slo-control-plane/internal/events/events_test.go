@@ -0,0 +1,105 @@
+package events
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func testEvent() Event {
+	eta := 3600
+	return New(uuid.New(), Data{
+		EventType:                 BurnStarted,
+		ServiceID:                 uuid.New(),
+		SLOID:                     uuid.New(),
+		Value:                     14.4,
+		Threshold:                 14.4,
+		Source:                    "slo-evaluator:fast",
+		Severity:                  "fast",
+		BurnTier:                  "fast",
+		EvaluatedAt:               time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("x", 3600)),
+		BudgetRemaining:           0.4,
+		ETAExhaustionSeconds:      eta,
+		ETAExhaustionLowerSeconds: &eta,
+	})
+}
+
+func TestStructuredRoundTrip(t *testing.T) {
+	e := testEvent()
+	if e.Type != TypePrefix+"burn_started" || e.Source != "/services/"+e.Data.ServiceID.String()+"/slos/"+e.Data.SLOID.String() {
+		t.Fatalf("unexpected attributes: %+v", e)
+	}
+	if e.Time.Location() != time.UTC {
+		t.Fatalf("expected the event time in UTC, got %v", e.Time)
+	}
+	blob, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var raw map[string]any
+	_ = json.Unmarshal(blob, &raw)
+	if raw["specversion"] != "1.0" || raw["datacontenttype"] != "application/json" || raw["id"] != e.ID.String() {
+		t.Fatalf("unexpected envelope: %s", blob)
+	}
+	var got Event
+	if err := json.Unmarshal(blob, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != e.ID || !got.Time.Equal(e.Time) || got.Data.SLOID != e.Data.SLOID || *got.Data.ETAExhaustionLowerSeconds != 3600 {
+		t.Fatalf("round trip mismatch: %+v", got)
+	}
+
+	bad := strings.Replace(string(blob), `"specversion":"1.0"`, `"specversion":"0.3"`, 1)
+	if err := json.Unmarshal([]byte(bad), &got); err == nil {
+		t.Fatalf("expected an unsupported specversion to be rejected")
+	}
+}
+
+func TestBinaryHeaders(t *testing.T) {
+	e := testEvent()
+	h, body, err := e.Binary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h.Get("ce-specversion") != SpecVersion || h.Get("ce-id") != e.ID.String() || h.Get("ce-type") != e.Type ||
+		h.Get("ce-source") != e.Source || h.Get("ce-time") != "2026-03-01T11:00:00Z" || h.Get("Content-Type") != DataContentType {
+		t.Fatalf("unexpected headers: %v", h)
+	}
+	var data Data
+	if err := json.Unmarshal(body, &data); err != nil || data.SLOID != e.Data.SLOID {
+		t.Fatalf("body is not the event data: %s", body)
+	}
+}
+
+// The published schema must describe every field Data serializes.
+func TestSchemaCoversData(t *testing.T) {
+	var schema struct {
+		Defs struct {
+			Data struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"data"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(Schema(), &schema); err != nil {
+		t.Fatalf("schema is not JSON: %v", err)
+	}
+	var fields []string
+	typ := reflect.TypeOf(Data{})
+	for i := 0; i < typ.NumField(); i++ {
+		fields = append(fields, strings.Split(typ.Field(i).Tag.Get("json"), ",")[0])
+	}
+	var props []string
+	for name := range schema.Defs.Data.Properties {
+		props = append(props, name)
+	}
+	sort.Strings(fields)
+	sort.Strings(props)
+	if !reflect.DeepEqual(fields, props) {
+		t.Fatalf("schema properties %v do not match data fields %v", props, fields)
+	}
+}
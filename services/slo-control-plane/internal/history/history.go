@@ -0,0 +1,204 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/google/uuid"
+)
+
+// Point is one evaluator pass over one SLO.
+type Point struct {
+	SLOID            uuid.UUID
+	ServiceID        uuid.UUID
+	Objective        string
+	DimensionKey     string
+	EvaluatedAt      time.Time
+	Severity         string
+	BurnTier         string
+	Compliance       float64
+	BurnRate         float64
+	BudgetRemaining  float64
+	Events           float64
+	InsufficientData bool
+	ETASeconds       int
+	QueryDurationMs  int
+	Windows          []Window
+}
+
+// Window is the compliance measured over one burn-rate window during an evaluation.
+type Window struct {
+	Minutes    int
+	Compliance float64
+	Events     float64
+}
+
+type Store struct {
+	db        *sql.DB
+	retention time.Duration
+}
+
+func New(clickhouseDSN string, retention time.Duration) (*Store, error) {
+	if retention <= 0 {
+		return nil, fmt.Errorf("evaluation history retention must be positive")
+	}
+	db, err := sql.Open("clickhouse", clickhouseDSN)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db, retention: retention}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// EnsureTable creates slo_evaluation_history and applies the configured retention as its TTL, so a
+// changed retention also takes effect on an existing table. The TTL is only modified when it
+// changed, because modifying it rewrites the whole table.
+func (s *Store) EnsureTable(ctx context.Context) error {
+	ttl := "toDateTime(evaluated_at) + toIntervalSecond(" + strconv.FormatInt(int64(s.retention.Seconds()), 10) + ")"
+	ddl := `
+CREATE TABLE IF NOT EXISTS slo_evaluation_history (
+  slo_id UUID,
+  objective String,
+  dimension_key String,
+  service_id UUID,
+  evaluated_at DateTime64(3),
+  severity LowCardinality(String),
+  burn_tier String,
+  compliance Float64,
+  burn_rate Float64,
+  budget_remaining Float64,
+  events Float64,
+  insufficient_data Bool,
+  eta_exhaustion_seconds UInt32,
+  query_duration_ms UInt32,
+  window_compliance Map(UInt32, Float64),
+  window_events Map(UInt32, Float64)
+)
+ENGINE = MergeTree
+PARTITION BY toYYYYMM(evaluated_at)
+ORDER BY (slo_id, evaluated_at)
+TTL ` + ttl
+	if _, err := s.db.ExecContext(ctx, ddl); err != nil {
+		return err
+	}
+	if _, err := s.db.ExecContext(ctx, `ALTER TABLE slo_evaluation_history ADD COLUMN IF NOT EXISTS objective String AFTER slo_id`); err != nil {
+		return err
+	}
+	if _, err := s.db.ExecContext(ctx, `ALTER TABLE slo_evaluation_history ADD COLUMN IF NOT EXISTS dimension_key String AFTER objective`); err != nil {
+		return err
+	}
+	var engine string
+	if err := s.db.QueryRowContext(ctx, `
+		SELECT engine_full FROM system.tables
+		WHERE database = currentDatabase() AND name = 'slo_evaluation_history'
+	`).Scan(&engine); err != nil {
+		return err
+	}
+	if strings.Contains(engine, "TTL "+ttl) {
+		return nil
+	}
+	_, err := s.db.ExecContext(ctx, `ALTER TABLE slo_evaluation_history MODIFY TTL `+ttl)
+	return err
+}
+
+// Append writes one evaluator pass as a single batch.
+func (s *Store) Append(ctx context.Context, points []Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO slo_evaluation_history (
+			slo_id, objective, dimension_key, service_id, evaluated_at, severity, burn_tier, compliance, burn_rate, budget_remaining,
+			events, insufficient_data, eta_exhaustion_seconds, query_duration_ms, window_compliance, window_events
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, p := range points {
+		compliance := make(map[uint32]float64, len(p.Windows))
+		events := make(map[uint32]float64, len(p.Windows))
+		for _, w := range p.Windows {
+			compliance[uint32(w.Minutes)] = w.Compliance
+			events[uint32(w.Minutes)] = w.Events
+		}
+		if _, err := stmt.ExecContext(ctx,
+			p.SLOID.String(), p.Objective, p.DimensionKey, p.ServiceID.String(), p.EvaluatedAt, p.Severity, p.BurnTier, p.Compliance, p.BurnRate,
+			p.BudgetRemaining, p.Events, p.InsufficientData, uint32(max(p.ETASeconds, 0)), uint32(max(p.QueryDurationMs, 0)),
+			compliance, events,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Series returns up to limit points for an SLO in [from, to), oldest first, with the objectives and
+// dimensions of one evaluation interleaved. truncated reports that the range held more points than limit.
+func (s *Store) Series(ctx context.Context, sloID uuid.UUID, from, to time.Time, limit int) ([]Point, bool, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT objective, dimension_key, toString(service_id), evaluated_at, severity, burn_tier, compliance, burn_rate, budget_remaining,
+		       events, insufficient_data, eta_exhaustion_seconds, query_duration_ms, window_compliance, window_events
+		FROM slo_evaluation_history
+		WHERE slo_id = ? AND evaluated_at >= ? AND evaluated_at < ?
+		ORDER BY evaluated_at, objective, dimension_key
+		LIMIT ?
+	`, sloID.String(), from, to, limit+1)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	var out []Point
+	for rows.Next() {
+		var (
+			p          = Point{SLOID: sloID}
+			serviceID  string
+			eta, query uint32
+			compliance map[uint32]float64
+			events     map[uint32]float64
+		)
+		if err := rows.Scan(
+			&p.Objective, &p.DimensionKey, &serviceID, &p.EvaluatedAt, &p.Severity, &p.BurnTier, &p.Compliance, &p.BurnRate, &p.BudgetRemaining,
+			&p.Events, &p.InsufficientData, &eta, &query, &compliance, &events,
+		); err != nil {
+			return nil, false, err
+		}
+		p.ServiceID, _ = uuid.Parse(serviceID)
+		p.ETASeconds = int(eta)
+		p.QueryDurationMs = int(query)
+		p.Windows = windowsFromMaps(compliance, events)
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+	if len(out) > limit {
+		return out[:limit], true, nil
+	}
+	return out, false, nil
+}
+
+func windowsFromMaps(compliance, events map[uint32]float64) []Window {
+	out := make([]Window, 0, len(compliance))
+	for minutes, c := range compliance {
+		out = append(out, Window{Minutes: int(minutes), Compliance: c, Events: events[minutes]})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Minutes < out[j].Minutes })
+	return out
+}
@@ -0,0 +1,120 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeConn records statements and answers every query with one engine_full row.
+type fakeConn struct {
+	engine string
+	execs  []string
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.execs = append(c.execs, strings.Join(strings.Fields(query), " "))
+	return driver.RowsAffected(0), nil
+}
+
+func (c *fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &engineRows{engine: c.engine}, nil
+}
+
+type engineRows struct {
+	engine string
+	done   bool
+}
+
+func (r *engineRows) Columns() []string { return []string{"engine_full"} }
+func (r *engineRows) Close() error      { return nil }
+
+func (r *engineRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.engine
+	return nil
+}
+
+type fakeConnector struct{ conn *fakeConn }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+func (c fakeConnector) Driver() driver.Driver                        { return fakeDriver{c.conn} }
+
+type fakeDriver struct{ conn *fakeConn }
+
+func (d fakeDriver) Open(string) (driver.Conn, error) { return d.conn, nil }
+
+func TestEnsureTableOnlyModifiesAChangedTTL(t *testing.T) {
+	const engine = "MergeTree PARTITION BY toYYYYMM(evaluated_at) ORDER BY (slo_id, evaluated_at) "
+	cases := []struct {
+		name   string
+		engine string
+		modify bool
+	}{
+		{"same retention", engine + "TTL toDateTime(evaluated_at) + toIntervalSecond(2592000) SETTINGS index_granularity = 8192", false},
+		{"shorter retention", engine + "TTL toDateTime(evaluated_at) + toIntervalSecond(259200) SETTINGS index_granularity = 8192", true},
+		{"longer retention", engine + "TTL toDateTime(evaluated_at) + toIntervalSecond(25920000) SETTINGS index_granularity = 8192", true},
+		{"no TTL", engine + "SETTINGS index_granularity = 8192", true},
+	}
+	for _, tc := range cases {
+		conn := &fakeConn{engine: tc.engine}
+		s := &Store{db: sql.OpenDB(fakeConnector{conn}), retention: 30 * 24 * time.Hour}
+		if err := s.EnsureTable(context.Background()); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		modified := false
+		for _, q := range conn.execs {
+			if strings.Contains(q, "MODIFY TTL") {
+				modified = true
+				if !strings.HasSuffix(q, "MODIFY TTL toDateTime(evaluated_at) + toIntervalSecond(2592000)") {
+					t.Fatalf("%s: unexpected TTL statement %q", tc.name, q)
+				}
+			}
+		}
+		if modified != tc.modify {
+			t.Fatalf("%s: modified TTL = %v, want %v", tc.name, modified, tc.modify)
+		}
+		_ = s.Close()
+	}
+}
+
+func TestWindowsFromMaps(t *testing.T) {
+	cases := []struct {
+		name       string
+		compliance map[uint32]float64
+		events     map[uint32]float64
+		want       []Window
+	}{
+		{"empty", nil, nil, []Window{}},
+		{
+			"sorted by minutes",
+			map[uint32]float64{60: 0.98, 5: 0.9, 30: 0.95},
+			map[uint32]float64{60: 1200, 5: 100, 30: 600},
+			[]Window{{Minutes: 5, Compliance: 0.9, Events: 100}, {Minutes: 30, Compliance: 0.95, Events: 600}, {Minutes: 60, Compliance: 0.98, Events: 1200}},
+		},
+		{
+			"missing events",
+			map[uint32]float64{5: 1},
+			nil,
+			[]Window{{Minutes: 5, Compliance: 1}},
+		},
+	}
+	for _, tc := range cases {
+		if got := windowsFromMaps(tc.compliance, tc.events); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%s: windowsFromMaps() = %+v, want %+v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package httpapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apiv1 "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/api"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/maintenance"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+func (s *Server) ListMaintenanceWindows(w http.ResponseWriter, r *http.Request, params apiv1.ListMaintenanceWindowsParams) {
+	page, size := pagination(params.Page, params.PageSize)
+	if (params.ScopeType == nil) != (params.ScopeId == nil) {
+		writeProblem(w, http.StatusBadRequest, "invalid_scope", "scopeType and scopeId must be given together")
+		return
+	}
+	scopeType := ""
+	var scopeID *uuid.UUID
+	if params.ScopeType != nil {
+		scopeType = string(*params.ScopeType)
+		id := uuid.UUID(*params.ScopeId)
+		scopeID = &id
+	}
+	items, pg, err := s.store.ListMaintenanceWindows(r.Context(), page, size, scopeType, scopeID)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "list_maintenance_windows_failed", err.Error())
+		return
+	}
+	now := time.Now().UTC()
+	resp := apiv1.MaintenanceWindowListResponse{
+		Items: make([]apiv1.MaintenanceWindow, 0, len(items)),
+		Page:  apiv1.Pagination{Page: pg.Page, PageSize: pg.PageSize, Total: pg.Total},
+	}
+	for _, mw := range items {
+		resp.Items = append(resp.Items, maintenanceWindowToAPI(mw, now))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) CreateMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+	var req apiv1.MaintenanceWindowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_body", "invalid JSON body")
+		return
+	}
+	mw, err := s.maintenanceWindowFromAPI(r.Context(), uuid.New(), req)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_maintenance_window", err.Error())
+		return
+	}
+	created, err := s.store.CreateMaintenanceWindow(r.Context(), mw)
+	if err != nil {
+		writeProblem(w, statusFromError(err), "create_maintenance_window_failed", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, maintenanceWindowToAPI(created, time.Now().UTC()))
+}
+
+func (s *Server) GetMaintenanceWindow(w http.ResponseWriter, r *http.Request, maintenanceWindowId apiv1.MaintenanceWindowId) {
+	mw, err := s.store.GetMaintenanceWindow(r.Context(), uuid.UUID(maintenanceWindowId))
+	if err != nil {
+		writeProblem(w, statusFromError(err), "maintenance_window_not_found", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, maintenanceWindowToAPI(mw, time.Now().UTC()))
+}
+
+func (s *Server) UpdateMaintenanceWindow(w http.ResponseWriter, r *http.Request, maintenanceWindowId apiv1.MaintenanceWindowId) {
+	var req apiv1.MaintenanceWindowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_body", "invalid JSON body")
+		return
+	}
+	id := uuid.UUID(maintenanceWindowId)
+	if _, err := s.store.GetMaintenanceWindow(r.Context(), id); err != nil {
+		writeProblem(w, statusFromError(err), "maintenance_window_not_found", err.Error())
+		return
+	}
+	mw, err := s.maintenanceWindowFromAPI(r.Context(), id, req)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_maintenance_window", err.Error())
+		return
+	}
+	updated, err := s.store.UpdateMaintenanceWindow(r.Context(), mw)
+	if err != nil {
+		writeProblem(w, statusFromError(err), "update_maintenance_window_failed", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, maintenanceWindowToAPI(updated, time.Now().UTC()))
+}
+
+func (s *Server) DeleteMaintenanceWindow(w http.ResponseWriter, r *http.Request, maintenanceWindowId apiv1.MaintenanceWindowId) {
+	if err := s.store.DeleteMaintenanceWindow(r.Context(), uuid.UUID(maintenanceWindowId)); err != nil {
+		writeProblem(w, statusFromError(err), "delete_maintenance_window_failed", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// maintenanceWindowFromAPI validates a request, including that the team, service or SLO it is
+// scoped to exists.
+func (s *Server) maintenanceWindowFromAPI(ctx context.Context, id uuid.UUID, req apiv1.MaintenanceWindowRequest) (store.MaintenanceWindow, error) {
+	mw, err := parseMaintenanceWindow(id, req)
+	if err != nil {
+		return store.MaintenanceWindow{}, err
+	}
+	switch mw.ScopeType {
+	case maintenance.ScopeTeam:
+		_, err = s.store.GetTeam(ctx, mw.ScopeID)
+	case maintenance.ScopeService:
+		_, err = s.store.GetService(ctx, mw.ScopeID)
+	default:
+		_, err = s.store.GetSLO(ctx, mw.ScopeID)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return store.MaintenanceWindow{}, fmt.Errorf("%s %s does not exist", mw.ScopeType, mw.ScopeID)
+	}
+	return mw, err
+}
+
+func parseMaintenanceWindow(id uuid.UUID, req apiv1.MaintenanceWindowRequest) (store.MaintenanceWindow, error) {
+	mw := store.MaintenanceWindow{
+		ID:        id,
+		Name:      strings.TrimSpace(req.Name),
+		ScopeType: string(req.ScopeType),
+		ScopeID:   uuid.UUID(req.ScopeId),
+		Schedule: maintenance.Schedule{
+			StartsAt: req.StartsAt.UTC(),
+			EndsAt:   req.EndsAt.UTC(),
+		},
+	}
+	if mw.Name == "" {
+		return mw, fmt.Errorf("name is required")
+	}
+	if !req.ScopeType.Valid() {
+		return mw, fmt.Errorf("scopeType %q must be one of team, service, slo", req.ScopeType)
+	}
+	if req.Description != nil {
+		mw.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.TimeZone != nil {
+		mw.Schedule.TimeZone = strings.TrimSpace(*req.TimeZone)
+	}
+	if req.ExcludeFromBudget != nil {
+		mw.ExcludeFromBudget = *req.ExcludeFromBudget
+	}
+	if rec := req.Recurrence; rec != nil {
+		mw.Schedule.Recurrence = string(rec.Frequency)
+		mw.Schedule.Interval = 1
+		if rec.Interval != nil {
+			mw.Schedule.Interval = *rec.Interval
+		}
+		if mw.Schedule.Interval < 1 {
+			return mw, fmt.Errorf("recurrence interval must be at least 1")
+		}
+		if rec.Until != nil {
+			mw.Schedule.Until = rec.Until.UTC()
+		}
+	}
+	return mw, mw.Schedule.Validate()
+}
+
+func maintenanceWindowToAPI(mw store.MaintenanceWindow, now time.Time) apiv1.MaintenanceWindow {
+	out := apiv1.MaintenanceWindow{
+		Id:                mw.ID,
+		Name:              mw.Name,
+		Description:       optionalString(mw.Description),
+		ScopeType:         apiv1.MaintenanceScopeType(mw.ScopeType),
+		ScopeId:           mw.ScopeID,
+		StartsAt:          mw.Schedule.StartsAt,
+		EndsAt:            mw.Schedule.EndsAt,
+		TimeZone:          mw.Schedule.TimeZone,
+		ExcludeFromBudget: mw.ExcludeFromBudget,
+		Active:            mw.Schedule.ActiveAt(now),
+		CreatedAt:         mw.CreatedAt,
+		UpdatedAt:         mw.UpdatedAt,
+	}
+	if out.TimeZone == "" {
+		out.TimeZone = "UTC"
+	}
+	if mw.Schedule.Recurrence != "" {
+		interval := mw.Schedule.Interval
+		rec := apiv1.MaintenanceRecurrence{Frequency: apiv1.MaintenanceRecurrenceFrequency(mw.Schedule.Recurrence), Interval: &interval}
+		if !mw.Schedule.Until.IsZero() {
+			until := mw.Schedule.Until
+			rec.Until = &until
+		}
+		out.Recurrence = &rec
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package httpapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	apiv1 "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/api"
+)
+
+func TestParseMaintenanceWindow(t *testing.T) {
+	start := time.Date(2026, 3, 28, 22, 0, 0, 0, time.UTC)
+	zone := "Europe/Berlin"
+	req := apiv1.MaintenanceWindowRequest{
+		Name:       " nightly deploy ",
+		ScopeType:  apiv1.MaintenanceScopeTypeService,
+		ScopeId:    uuid.New(),
+		StartsAt:   start,
+		EndsAt:     start.Add(time.Hour),
+		TimeZone:   &zone,
+		Recurrence: &apiv1.MaintenanceRecurrence{Frequency: apiv1.Daily},
+	}
+	mw, err := parseMaintenanceWindow(uuid.New(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mw.Name != "nightly deploy" || mw.Schedule.Recurrence != "daily" || mw.Schedule.Interval != 1 {
+		t.Fatalf("unexpected window: %+v", mw)
+	}
+	api := maintenanceWindowToAPI(mw, start.Add(30*time.Minute))
+	if !api.Active || api.Recurrence == nil || *api.Recurrence.Interval != 1 || api.TimeZone != zone {
+		t.Fatalf("unexpected api window: %+v", api)
+	}
+
+	bad := req
+	bad.EndsAt = start.Add(25 * time.Hour)
+	if _, err := parseMaintenanceWindow(uuid.New(), bad); err == nil {
+		t.Fatalf("expected a daily window longer than a day to be rejected")
+	}
+	bad = req
+	bad.ScopeType = "region"
+	if _, err := parseMaintenanceWindow(uuid.New(), bad); err == nil {
+		t.Fatalf("expected an unknown scope type to be rejected")
+	}
+	zero := 0
+	bad = req
+	bad.Recurrence = &apiv1.MaintenanceRecurrence{Frequency: apiv1.Weekly, Interval: &zero}
+	if _, err := parseMaintenanceWindow(uuid.New(), bad); err == nil {
+		t.Fatalf("expected a zero interval to be rejected")
+	}
+}
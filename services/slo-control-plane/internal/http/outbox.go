@@ -0,0 +1,103 @@
+package httpapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	apiv1 "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/api"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+func (s *Server) ListDeadLetterEvents(w http.ResponseWriter, r *http.Request, params apiv1.ListDeadLetterEventsParams) {
+	page, size := pagination(params.Page, params.PageSize)
+	items, pg, err := s.store.ListDeadLetterOutbox(r.Context(), page, size)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "list_dead_letter_events_failed", err.Error())
+		return
+	}
+	resp := apiv1.DeadLetterEventListResponse{
+		Items: make([]apiv1.DeadLetterEvent, 0, len(items)),
+		Page:  apiv1.Pagination{Page: pg.Page, PageSize: pg.PageSize, Total: pg.Total},
+	}
+	for _, ev := range items {
+		resp.Items = append(resp.Items, deadLetterEventToAPI(ev, nil))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) GetDeadLetterEvent(w http.ResponseWriter, r *http.Request, outboxEventId apiv1.OutboxEventId) {
+	id := uuid.UUID(outboxEventId)
+	ev, err := s.store.GetDeadLetterOutbox(r.Context(), id)
+	if err != nil {
+		writeProblem(w, statusFromError(err), "dead_letter_event_not_found", err.Error())
+		return
+	}
+	attempts, err := s.store.ListOutboxDeliveryAttempts(r.Context(), id)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "list_delivery_attempts_failed", err.Error())
+		return
+	}
+	if attempts == nil {
+		attempts = []store.DeliveryAttempt{}
+	}
+	writeJSON(w, http.StatusOK, deadLetterEventToAPI(ev, attempts))
+}
+
+func (s *Server) RequeueDeadLetterEvent(w http.ResponseWriter, r *http.Request, outboxEventId apiv1.OutboxEventId) {
+	if err := s.store.RequeueDeadLetterOutbox(r.Context(), uuid.UUID(outboxEventId)); err != nil {
+		writeProblem(w, statusFromError(err), "requeue_dead_letter_event_failed", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) PurgeDeadLetterEvent(w http.ResponseWriter, r *http.Request, outboxEventId apiv1.OutboxEventId) {
+	if err := s.store.PurgeDeadLetterOutbox(r.Context(), uuid.UUID(outboxEventId)); err != nil {
+		writeProblem(w, statusFromError(err), "purge_dead_letter_event_failed", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) PurgeDeadLetterEvents(w http.ResponseWriter, r *http.Request, params apiv1.PurgeDeadLetterEventsParams) {
+	var before time.Time
+	if params.Before != nil {
+		before = params.Before.UTC()
+	}
+	n, err := s.store.PurgeDeadLetterOutboxBefore(r.Context(), before)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "purge_dead_letter_events_failed", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, apiv1.PurgeDeadLetterEventsResponse{Purged: int(n)})
+}
+
+// deadLetterEventToAPI maps an event; attempts are only set when non-nil.
+func deadLetterEventToAPI(ev store.DeadLetterEvent, attempts []store.DeliveryAttempt) apiv1.DeadLetterEvent {
+	payload := ev.Payload
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	out := apiv1.DeadLetterEvent{
+		Id:             ev.ID,
+		AggregateType:  ev.AggregateType,
+		AggregateId:    ev.AggregateID,
+		EventType:      ev.EventType,
+		Payload:        payload,
+		RetryCount:     ev.RetryCount,
+		IdempotencyKey: ev.IdempotencyKey,
+		LastError:      ev.LastError,
+		CreatedAt:      ev.CreatedAt,
+		DeadLetteredAt: ev.DeadLetteredAt,
+	}
+	if attempts != nil {
+		items := make([]apiv1.DeliveryAttempt, 0, len(attempts))
+		for _, a := range attempts {
+			items = append(items, apiv1.DeliveryAttempt{AttemptNo: a.AttemptNo, Error: a.Error, AttemptedAt: a.AttemptedAt})
+		}
+		out.Attempts = &items
+	}
+	return out
+}
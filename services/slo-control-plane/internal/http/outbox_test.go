@@ -0,0 +1,27 @@
+package httpapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+func TestDeadLetterEventToAPIIncludesAttemptsOnlyWhenGiven(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	ev := store.DeadLetterEvent{
+		OutboxEvent:    store.OutboxEvent{ID: uuid.New(), AggregateID: uuid.New(), EventType: "burn_started", RetryCount: 11},
+		LastError:      "clickhouse: connection refused",
+		DeadLetteredAt: now,
+	}
+	api := deadLetterEventToAPI(ev, nil)
+	if api.Attempts != nil || api.Payload == nil || api.RetryCount != 11 || api.LastError != ev.LastError {
+		t.Fatalf("unexpected list item: %+v", api)
+	}
+	api = deadLetterEventToAPI(ev, []store.DeliveryAttempt{{AttemptNo: 1, Error: "timeout", AttemptedAt: now}})
+	if api.Attempts == nil || len(*api.Attempts) != 1 || (*api.Attempts)[0].Error != "timeout" {
+		t.Fatalf("unexpected attempts: %+v", api.Attempts)
+	}
+}
@@ -1,8 +1,11 @@
 package httpapi
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -12,29 +15,67 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	apiv1 "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/api"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/evaluator"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/events"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/history"
 	opensloparser "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/openslo"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/telemetry"
 )
 
+type Config struct {
+	// EvaluationStaleAfter is how long an SLO may go without an evaluator attempt before /ready counts it as stale.
+	EvaluationStaleAfter time.Duration
+	// History serves the evaluation series; nil answers 503.
+	History HistoryReader
+	// Evaluator runs on-demand evaluations; nil answers 503.
+	Evaluator SLOEvaluator
+	// WebhookAllowedHosts may receive webhooks even though they are loopback, link-local or private.
+	WebhookAllowedHosts []string
+}
+
+type SLOEvaluator interface {
+	Explain(ctx context.Context, slo store.SLO, dryRun bool, objective, dimension string) (evaluator.Explanation, error)
+}
+
+type HistoryReader interface {
+	Series(ctx context.Context, sloID uuid.UUID, from, to time.Time, limit int) ([]history.Point, bool, error)
+}
+
 type Server struct {
 	store *store.Store
+	cfg   Config
 }
 
-func NewServer(st *store.Store) *Server {
-	return &Server{store: st}
+func NewServer(st *store.Store, cfg Config) *Server {
+	if cfg.EvaluationStaleAfter <= 0 {
+		cfg.EvaluationStaleAfter = 5 * time.Minute
+	}
+	return &Server{store: st, cfg: cfg}
 }
 
 func (s *Server) GetHealth(w http.ResponseWriter, _ *http.Request) {
-	writeJSON(w, http.StatusOK, apiv1.HealthResponse{Status: apiv1.Ok})
+	writeJSON(w, http.StatusOK, apiv1.HealthResponse{Status: apiv1.HealthResponseStatusOk})
 }
 
-func (s *Server) GetReady(w http.ResponseWriter, _ *http.Request) {
+// GetReady only fails on the database; evaluation health is reported as diagnostics because a
+// broken SLO definition should not take the API out of rotation.
+func (s *Server) GetReady(w http.ResponseWriter, r *http.Request) {
 	if err := s.store.DB().Ping(); err != nil {
 		writeProblem(w, http.StatusServiceUnavailable, "db_unavailable", "database unavailable")
 		return
 	}
-	writeJSON(w, http.StatusOK, apiv1.ReadyResponse{Status: apiv1.Ready})
+	resp := apiv1.ReadyResponse{Status: apiv1.Ready}
+	if summary, err := s.store.SummarizeEvaluationHealth(r.Context(), s.cfg.EvaluationStaleAfter); err == nil {
+		resp.Evaluation = &apiv1.EvaluationSummary{
+			SloCount:        summary.SLOCount,
+			FailingCount:    summary.FailingCount,
+			StaleCount:      summary.StaleCount,
+			LastEvaluatedAt: nullTimeToPtr(summary.LastEvaluatedAt),
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
 }
 
 func (s *Server) ListTeams(w http.ResponseWriter, r *http.Request, params apiv1.ListTeamsParams) {
@@ -264,6 +305,10 @@ func (s *Server) CreateSLO(w http.ResponseWriter, r *http.Request, _ apiv1.Creat
 		attribute.Int("slo.window_minutes", bundle.Runtime.WindowMinutes),
 	)
 	telemetry.SetPayloadAttributes(span, "slo.openslo", req.Openslo)
+	if err := s.validateComposite(ctx, bundle.Runtime, slo.ID); err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_openslo", err.Error())
+		return
+	}
 	tx, err := s.store.BeginTx(ctx)
 	if err != nil {
 		writeProblem(w, http.StatusInternalServerError, "tx_begin_failed", err.Error())
@@ -311,6 +356,10 @@ func (s *Server) UpdateSLO(w http.ResponseWriter, r *http.Request, sloId apiv1.S
 	span := trace.SpanFromContext(ctx)
 	span.SetAttributes(attribute.String("slo.id", uuid.UUID(sloId).String()))
 	telemetry.SetPayloadAttributes(span, "slo.openslo", req.Openslo)
+	if err := s.validateComposite(ctx, bundle.Runtime, uuid.UUID(sloId)); err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_openslo", err.Error())
+		return
+	}
 	tx, err := s.store.BeginTx(ctx)
 	if err != nil {
 		writeProblem(w, http.StatusInternalServerError, "tx_begin_failed", err.Error())
@@ -353,6 +402,30 @@ func (s *Server) UpdateSLO(w http.ResponseWriter, r *http.Request, sloId apiv1.S
 	writeJSON(w, http.StatusOK, sloToAPI(updated))
 }
 
+// validateComposite checks that every component of a composite SLO is another existing SLO and not
+// a composite itself.
+func (s *Server) validateComposite(ctx context.Context, rt opensloparser.Runtime, self uuid.UUID) error {
+	if rt.Composite == nil {
+		return nil
+	}
+	for _, c := range rt.Composite.Components {
+		if c.SLOID == self {
+			return fmt.Errorf("composite SLO cannot include itself")
+		}
+		child, err := s.store.GetSLO(ctx, c.SLOID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("composite component %s does not exist", c.SLOID)
+		}
+		if err != nil {
+			return err
+		}
+		if child.DatasourceType == opensloparser.DatasourceComposite {
+			return fmt.Errorf("composite component %s is itself a composite SLO", c.SLOID)
+		}
+	}
+	return nil
+}
+
 func (s *Server) DeleteSLO(w http.ResponseWriter, r *http.Request, sloId apiv1.SloId) {
 	span := trace.SpanFromContext(r.Context())
 	span.SetAttributes(attribute.String("slo.id", uuid.UUID(sloId).String()))
@@ -401,6 +474,262 @@ func (s *Server) GetSLOAlertStatus(w http.ResponseWriter, r *http.Request, sloId
 	writeJSON(w, http.StatusOK, resp)
 }
 
+func (s *Server) GetSLOEvaluationHealth(w http.ResponseWriter, r *http.Request, sloId apiv1.SloId) {
+	id := uuid.UUID(sloId)
+	health, ok, err := s.store.GetEvaluationHealth(r.Context(), id)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "get_slo_evaluation_health_failed", err.Error())
+		return
+	}
+	if !ok {
+		if _, err := s.store.GetSLO(r.Context(), id); err != nil {
+			writeProblem(w, statusFromError(err), "slo_not_found", err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, apiv1.EvaluationHealth{SloId: id, Status: apiv1.EvaluationHealthStatusPending})
+		return
+	}
+	writeJSON(w, http.StatusOK, evaluationHealthToAPI(health))
+}
+
+func (s *Server) EvaluateSLO(w http.ResponseWriter, r *http.Request, sloId apiv1.SloId, params apiv1.EvaluateSLOParams) {
+	slo, err := s.store.GetSLO(r.Context(), uuid.UUID(sloId))
+	if err != nil {
+		writeProblem(w, statusFromError(err), "slo_not_found", err.Error())
+		return
+	}
+	if s.cfg.Evaluator == nil {
+		writeProblem(w, http.StatusServiceUnavailable, "evaluator_unavailable", "on-demand evaluation is not configured")
+		return
+	}
+	dryRun := params.DryRun != nil && *params.DryRun
+	objective, dimension := "", ""
+	if params.Objective != nil {
+		objective = *params.Objective
+	}
+	if params.Dimension != nil {
+		dimension = *params.Dimension
+	}
+	exp, err := s.cfg.Evaluator.Explain(r.Context(), slo, dryRun, objective, dimension)
+	if err != nil {
+		if errors.Is(err, evaluator.ErrUnknownObjective) {
+			writeProblem(w, http.StatusNotFound, "objective_not_found", err.Error())
+			return
+		}
+		if errors.Is(err, evaluator.ErrUnknownDimension) {
+			writeProblem(w, http.StatusNotFound, "dimension_not_found", err.Error())
+			return
+		}
+		switch evaluator.ErrorStage(err) {
+		case "backend", "definition":
+			writeProblem(w, http.StatusUnprocessableEntity, "slo_not_evaluable", err.Error())
+		case "query":
+			writeProblem(w, http.StatusBadGateway, "sli_query_failed", err.Error())
+		default:
+			writeProblem(w, http.StatusInternalServerError, "evaluate_slo_failed", err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, explanationToAPI(exp))
+}
+
+func explanationToAPI(exp evaluator.Explanation) apiv1.EvaluationExplanation {
+	out := apiv1.EvaluationExplanation{
+		SloId:            exp.SLOID,
+		EvaluatedAt:      exp.EvaluatedAt,
+		DryRun:           exp.DryRun,
+		Objective:        optionalString(exp.Objective),
+		Queries:          make([]apiv1.EvaluationQuery, 0, len(exp.Queries)),
+		Windows:          make([]apiv1.EvaluationWindowResult, 0, len(exp.Windows)),
+		Tiers:            make([]apiv1.EvaluationTier, 0, len(exp.Tiers)),
+		RawSeverity:      exp.RawSeverity,
+		Severity:         exp.Severity,
+		BurnTier:         exp.BurnTier,
+		Compliance:       exp.Compliance,
+		BurnRate:         exp.BurnRate,
+		InsufficientData: exp.InsufficientData,
+		BudgetRemaining:  exp.BudgetRemaining,
+		BudgetExhausted:  exp.BudgetExhausted,
+		InMaintenance:    optionalTrue(exp.InMaintenance),
+		Action:           apiv1.EvaluationAction{EmitEvent: exp.Action.EmitEvent},
+		Events:           make([]apiv1.EvaluationEvent, 0, len(exp.Events)),
+		QueryDurationMs:  int(exp.QueryDuration.Milliseconds()),
+	}
+	if exp.ETASeconds > 0 {
+		eta := exp.ETASeconds
+		out.EtaExhaustionSeconds = &eta
+	}
+	if len(exp.Objectives) > 1 {
+		objectives := exp.Objectives
+		out.Objectives = &objectives
+	}
+	if exp.Dimension.Key != "" {
+		keys := exp.DimensionKeys
+		out.DimensionKey = &exp.Dimension.Key
+		out.Dimensions = optionalStringMap(exp.Dimension.Values)
+		out.DimensionKeys = &keys
+	}
+	if exp.Action.EventType != "" {
+		eventType := exp.Action.EventType
+		out.Action.EventType = &eventType
+	}
+	for _, q := range exp.Queries {
+		args := q.Args
+		if args == nil {
+			args = []any{}
+		}
+		out.Queries = append(out.Queries, apiv1.EvaluationQuery{WindowMinutes: q.WindowMinutes, Name: q.Name, Text: q.Text, Args: args})
+	}
+	for _, w := range exp.Windows {
+		win := apiv1.EvaluationWindowResult{WindowMinutes: w.Minutes, Compliance: w.Compliance, Events: w.Events, Sufficient: w.Sufficient}
+		if w.Sufficient {
+			rate := w.BurnRate
+			win.BurnRate = &rate
+		}
+		out.Windows = append(out.Windows, win)
+	}
+	for _, t := range exp.Tiers {
+		tier := apiv1.EvaluationTier{
+			Name:              t.Name,
+			Severity:          t.Severity,
+			Threshold:         t.Threshold,
+			ResolveThreshold:  t.ResolveThreshold,
+			LongWindowMinutes: t.LongWindowMinutes,
+			Firing:            t.Firing,
+		}
+		if t.ShortWindowMinutes > 0 {
+			short := t.ShortWindowMinutes
+			tier.ShortWindowMinutes = &short
+		}
+		out.Tiers = append(out.Tiers, tier)
+	}
+	for _, ev := range exp.Events {
+		out.Events = append(out.Events, apiv1.EvaluationEvent{EventType: ev.EventType, IdempotencyKey: ev.IdempotencyKey, Payload: eventDataToAPI(ev.Data)})
+	}
+	return out
+}
+
+// eventDataToAPI returns event data as the outbox payload object it is enqueued as.
+func eventDataToAPI(data events.Data) map[string]any {
+	blob, _ := json.Marshal(data)
+	out := map[string]any{}
+	_ = json.Unmarshal(blob, &out)
+	return out
+}
+
+const (
+	defaultEvaluationRange = 24 * time.Hour
+	defaultEvaluationLimit = 2000
+	maxEvaluationLimit     = 10000
+)
+
+func (s *Server) GetSLOEvaluations(w http.ResponseWriter, r *http.Request, sloId apiv1.SloId, params apiv1.GetSLOEvaluationsParams) {
+	id := uuid.UUID(sloId)
+	from, to, limit, err := evaluationRange(params, time.Now().UTC())
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_evaluation_range", err.Error())
+		return
+	}
+	if _, err := s.store.GetSLO(r.Context(), id); err != nil {
+		writeProblem(w, statusFromError(err), "slo_not_found", err.Error())
+		return
+	}
+	if s.cfg.History == nil {
+		writeProblem(w, http.StatusServiceUnavailable, "evaluation_history_unavailable", "evaluation history is not configured")
+		return
+	}
+	points, truncated, err := s.cfg.History.Series(r.Context(), id, from, to, limit)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "get_slo_evaluations_failed", err.Error())
+		return
+	}
+	resp := apiv1.EvaluationSeries{
+		SloId:     id,
+		From:      from,
+		To:        to,
+		Truncated: truncated,
+		Points:    make([]apiv1.EvaluationPoint, 0, len(points)),
+	}
+	for _, p := range points {
+		resp.Points = append(resp.Points, evaluationPointToAPI(p))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func evaluationRange(params apiv1.GetSLOEvaluationsParams, now time.Time) (time.Time, time.Time, int, error) {
+	to := now
+	if params.To != nil {
+		to = params.To.UTC()
+	}
+	from := to.Add(-defaultEvaluationRange)
+	if params.From != nil {
+		from = params.From.UTC()
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("from must be before to")
+	}
+	limit := defaultEvaluationLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxEvaluationLimit {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("limit must be between 1 and %d", maxEvaluationLimit)
+	}
+	return from, to, limit, nil
+}
+
+func evaluationPointToAPI(p history.Point) apiv1.EvaluationPoint {
+	out := apiv1.EvaluationPoint{
+		EvaluatedAt:      p.EvaluatedAt,
+		Objective:        optionalString(p.Objective),
+		DimensionKey:     optionalString(p.DimensionKey),
+		Severity:         p.Severity,
+		BurnTier:         p.BurnTier,
+		Compliance:       p.Compliance,
+		BurnRate:         p.BurnRate,
+		BudgetRemaining:  p.BudgetRemaining,
+		Events:           p.Events,
+		InsufficientData: p.InsufficientData,
+		QueryDurationMs:  p.QueryDurationMs,
+		Windows:          make([]apiv1.EvaluationWindow, 0, len(p.Windows)),
+	}
+	if p.ETASeconds > 0 {
+		eta := p.ETASeconds
+		out.EtaExhaustionSeconds = &eta
+	}
+	for _, w := range p.Windows {
+		out.Windows = append(out.Windows, apiv1.EvaluationWindow{WindowMinutes: w.Minutes, Compliance: w.Compliance, Events: w.Events})
+	}
+	return out
+}
+
+func evaluationHealthToAPI(h store.EvaluationHealth) apiv1.EvaluationHealth {
+	out := apiv1.EvaluationHealth{
+		SloId:               h.SLOID,
+		Status:              apiv1.EvaluationHealthStatus(h.Status),
+		LastAttemptAt:       &h.LastAttemptAt,
+		LastSuccessAt:       nullTimeToPtr(h.LastSuccessAt),
+		LastErrorAt:         nullTimeToPtr(h.LastErrorAt),
+		LastError:           optionalString(h.LastError),
+		ConsecutiveFailures: h.ConsecutiveFailures,
+		LastDurationMs:      h.LastDurationMs,
+		LastQueryDurationMs: h.LastQueryDurationMs,
+	}
+	if h.LastErrorStage != "" {
+		stage := apiv1.EvaluationHealthLastErrorStage(h.LastErrorStage)
+		out.LastErrorStage = &stage
+	}
+	return out
+}
+
+func nullTimeToPtr(v sql.NullTime) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t := v.Time
+	return &t
+}
+
 func (s *Server) ListBurnEvents(w http.ResponseWriter, r *http.Request, params apiv1.ListBurnEventsParams) {
 	page, size := pagination(params.Page, params.PageSize)
 	var serviceID *uuid.UUID
@@ -433,11 +762,21 @@ func (s *Server) ListBurnEvents(w http.ResponseWriter, r *http.Request, params a
 			ObservedAt:     ev.ObservedAt,
 			Source:         ev.Source,
 			IdempotencyKey: ev.IdempotencyKey,
+			Objective:      optionalString(ev.Objective),
+			DimensionKey:   optionalString(ev.DimensionKey),
+			Dimensions:     optionalStringMap(ev.Dimensions),
 		})
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// GetEventSchema serves the JSON Schema consumers can validate emitted events against.
+func (s *Server) GetEventSchema(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(events.Schema())
+}
+
 func writeJSON(w http.ResponseWriter, status int, body any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -494,10 +833,13 @@ func serviceToAPI(srv store.Service) apiv1.Service {
 func sloToAPI(s store.SLO) apiv1.SLO {
 	runtime := opensloparser.MapToRuntime(s.Canonical)
 	var dsType apiv1.SLORuntimeDatasourceType
-	if runtime.DatasourceType == "prometheus" {
-		dsType = apiv1.Prometheus
-	} else {
-		dsType = apiv1.Clickhouse
+	switch runtime.DatasourceType {
+	case "prometheus":
+		dsType = apiv1.SLORuntimeDatasourceTypePrometheus
+	case opensloparser.DatasourceComposite:
+		dsType = apiv1.SLORuntimeDatasourceTypeComposite
+	default:
+		dsType = apiv1.SLORuntimeDatasourceTypeClickhouse
 	}
 	var desc *string
 	if runtime.Description != "" {
@@ -507,27 +849,179 @@ func sloToAPI(s store.SLO) apiv1.SLO {
 	if runtime.UserExperience != "" {
 		ux = &runtime.UserExperience
 	}
+	var budgeting *apiv1.SLORuntimeBudgetingMethod
+	if runtime.BudgetingMethod != "" {
+		m := apiv1.SLORuntimeBudgetingMethod(runtime.BudgetingMethod)
+		budgeting = &m
+	}
+	var sliceMinutes *int
+	var sliceQuantile *float32
+	if runtime.BudgetingMethod == sli.BudgetingTimeslices {
+		q := float32(runtime.TimeSliceQuantile)
+		sliceMinutes = &runtime.TimeSliceMinutes
+		sliceQuantile = &q
+	}
 	return apiv1.SLO{
 		Id:        s.ID,
 		ServiceId: s.ServiceID,
 		Openslo:   s.OpenSLO,
 		Runtime: apiv1.SLORuntime{
-			Name:           runtime.Name,
-			Description:    desc,
-			UserExperience: ux,
-			Target:         runtime.Target,
-			WindowMinutes:  runtime.WindowMinutes,
-			Route:          runtime.Route,
-			Type:           apiv1.SLORuntimeType(runtime.Type),
-			Threshold:      runtime.Threshold,
-			DatasourceType: dsType,
-			DatasourceUid:  runtime.DatasourceUID,
+			Name:              runtime.Name,
+			Description:       desc,
+			UserExperience:    ux,
+			Target:            runtime.Target,
+			WindowMinutes:     runtime.WindowMinutes,
+			Route:             runtime.Route,
+			Type:              apiv1.SLORuntimeType(runtime.Type),
+			Threshold:         runtime.Threshold,
+			DatasourceType:    dsType,
+			DatasourceUid:     runtime.DatasourceUID,
+			DatasourceUrl:     optionalString(runtime.DatasourceURL),
+			GoodQuery:         optionalString(runtime.GoodQuery),
+			TotalQuery:        optionalString(runtime.TotalQuery),
+			ServiceName:       optionalString(runtime.ServiceName),
+			SpanKind:          optionalString(runtime.SpanKind),
+			SpanName:          optionalString(runtime.SpanName),
+			Attributes:        attributeMatchersToAPI(runtime.Attributes),
+			BadQuery:          optionalString(runtime.BadQuery),
+			Good:              spanSelectorToAPI(runtime.Good),
+			Bad:               spanSelectorToAPI(runtime.Bad),
+			Total:             spanSelectorToAPI(runtime.Total),
+			BudgetingMethod:   budgeting,
+			TimeSliceMinutes:  sliceMinutes,
+			TimeSliceQuantile: sliceQuantile,
+			Calendar:          calendarToAPI(runtime.Calendar),
+			BurnRateTiers:     burnRateTiersToAPI(runtime.BurnRateTiers),
+			MinEvents:         optionalPositiveInt(runtime.MinEvents),
+			MinSliceEvents:    optionalPositiveInt(runtime.MinSliceEvents),
+			GroupBy:           optionalStrings(runtime.GroupBy),
+			Composite:         compositeToAPI(runtime.Composite),
+			Objectives:        objectivesToAPI(runtime.Objectives),
 		},
 		CreatedAt: s.CreatedAt,
 		UpdatedAt: s.UpdatedAt,
 	}
 }
 
+func optionalString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+func optionalStrings(v []string) *[]string {
+	if len(v) == 0 {
+		return nil
+	}
+	return &v
+}
+
+func optionalStringMap(v map[string]string) *map[string]string {
+	if len(v) == 0 {
+		return nil
+	}
+	return &v
+}
+
+func optionalTrue(v bool) *bool {
+	if !v {
+		return nil
+	}
+	return &v
+}
+
+func optionalPositiveInt(v int) *int {
+	if v <= 0 {
+		return nil
+	}
+	return &v
+}
+
+func calendarToAPI(cal *opensloparser.CalendarWindow) *apiv1.CalendarWindow {
+	if cal == nil {
+		return nil
+	}
+	return &apiv1.CalendarWindow{
+		Unit:      apiv1.CalendarWindowUnit(cal.Unit),
+		Count:     cal.Count,
+		StartTime: optionalString(cal.StartTime),
+		TimeZone:  cal.TimeZone,
+	}
+}
+
+func compositeToAPI(c *opensloparser.Composite) *apiv1.CompositeSLO {
+	if c == nil {
+		return nil
+	}
+	out := &apiv1.CompositeSLO{Aggregation: apiv1.CompositeSLOAggregation(c.Aggregation), Components: []apiv1.CompositeComponent{}}
+	for _, comp := range c.Components {
+		out.Components = append(out.Components, apiv1.CompositeComponent{SloId: comp.SLOID, Weight: float32(comp.Weight)})
+	}
+	return out
+}
+
+func objectivesToAPI(objectives []opensloparser.Objective) *[]apiv1.SLOObjective {
+	if len(objectives) == 0 {
+		return nil
+	}
+	out := make([]apiv1.SLOObjective, 0, len(objectives))
+	for _, obj := range objectives {
+		o := apiv1.SLOObjective{Name: obj.Name, Target: obj.Target}
+		if obj.Threshold > 0 {
+			threshold := obj.Threshold
+			o.Threshold = &threshold
+		}
+		if obj.Op != "" {
+			op := apiv1.SLOObjectiveOp(obj.Op)
+			o.Op = &op
+		}
+		out = append(out, o)
+	}
+	return &out
+}
+
+func burnRateTiersToAPI(tiers []opensloparser.BurnRateTier) *[]apiv1.BurnRateTier {
+	if len(tiers) == 0 {
+		return nil
+	}
+	out := make([]apiv1.BurnRateTier, 0, len(tiers))
+	for _, t := range tiers {
+		out = append(out, apiv1.BurnRateTier{
+			Name:               t.Name,
+			Severity:           t.Severity,
+			Threshold:          t.Threshold,
+			LongWindowMinutes:  t.LongWindowMinutes,
+			ShortWindowMinutes: t.ShortWindowMinutes,
+		})
+	}
+	return &out
+}
+
+func spanSelectorToAPI(sel *sli.SpanSelector) *apiv1.SpanSelector {
+	if sel == nil {
+		return nil
+	}
+	return &apiv1.SpanSelector{
+		Route:       optionalString(sel.Route),
+		ServiceName: optionalString(sel.ServiceName),
+		SpanKind:    optionalString(sel.SpanKind),
+		SpanName:    optionalString(sel.SpanName),
+		Attributes:  attributeMatchersToAPI(sel.Attributes),
+	}
+}
+
+func attributeMatchersToAPI(in []sli.AttributeMatcher) *[]apiv1.SpanAttributeMatcher {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]apiv1.SpanAttributeMatcher, 0, len(in))
+	for _, am := range in {
+		out = append(out, apiv1.SpanAttributeMatcher{Key: am.Key, Op: apiv1.SpanAttributeMatcherOp(am.Op), Values: am.Values})
+	}
+	return &out
+}
+
 func toStoreObjects(objs []opensloparser.Object) []store.OpenSLOObject {
 	out := make([]store.OpenSLOObject, 0, len(objs))
 	for _, obj := range objs {
@@ -1,8 +1,16 @@
 package httpapi
 
 import (
+	"database/sql"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+
+	apiv1 "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/api"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/evaluator"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/history"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
 )
 
@@ -32,3 +40,80 @@ func TestSLOToAPIUsesRuntimeProjection(t *testing.T) {
 		t.Fatalf("runtime route/threshold mismatch: %#v", api.Runtime)
 	}
 }
+
+func TestEvaluationHealthToAPI(t *testing.T) {
+	h := store.EvaluationHealth{
+		SLOID:               uuid.New(),
+		Status:              store.EvaluationStatusFailed,
+		LastAttemptAt:       time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		LastSuccessAt:       sql.NullTime{Valid: true, Time: time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC)},
+		LastErrorAt:         sql.NullTime{Valid: true, Time: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+		LastError:           "5m window: context deadline exceeded",
+		LastErrorStage:      "query",
+		ConsecutiveFailures: 3,
+		LastDurationMs:      20000,
+		LastQueryDurationMs: 19950,
+	}
+	api := evaluationHealthToAPI(h)
+	if api.Status != apiv1.EvaluationHealthStatusFailed || api.ConsecutiveFailures != 3 {
+		t.Fatalf("unexpected status/failures: %#v", api)
+	}
+	if api.LastErrorStage == nil || *api.LastErrorStage != apiv1.Query || api.LastError == nil {
+		t.Fatalf("expected query stage error, got %#v", api)
+	}
+	if api.LastSuccessAt == nil || !api.LastSuccessAt.Equal(h.LastSuccessAt.Time) {
+		t.Fatalf("unexpected last success: %v", api.LastSuccessAt)
+	}
+}
+
+func TestEvaluationRangeDefaultsAndValidation(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	from, to, limit, err := evaluationRange(apiv1.GetSLOEvaluationsParams{}, now)
+	if err != nil || !to.Equal(now) || !from.Equal(now.Add(-24*time.Hour)) || limit != 2000 {
+		t.Fatalf("unexpected defaults from=%s to=%s limit=%d err=%v", from, to, limit, err)
+	}
+	later := now.Add(time.Hour)
+	if _, _, _, err := evaluationRange(apiv1.GetSLOEvaluationsParams{From: &later, To: &now}, now); err == nil {
+		t.Fatalf("expected from after to to be rejected")
+	}
+	tooMany := 10001
+	if _, _, _, err := evaluationRange(apiv1.GetSLOEvaluationsParams{Limit: &tooMany}, now); err == nil {
+		t.Fatalf("expected limit above the maximum to be rejected")
+	}
+}
+
+func TestEvaluationPointToAPI(t *testing.T) {
+	got := evaluationPointToAPI(history.Point{
+		EvaluatedAt:     time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Severity:        "critical",
+		BurnTier:        "fast",
+		Compliance:      0.98,
+		BurnRate:        16,
+		BudgetRemaining: 0.4,
+		QueryDurationMs: 42,
+		Windows:         []history.Window{{Minutes: 5, Compliance: 0.8, Events: 120}},
+	})
+	if got.Severity != "critical" || got.QueryDurationMs != 42 || got.EtaExhaustionSeconds != nil {
+		t.Fatalf("unexpected point: %+v", got)
+	}
+	if len(got.Windows) != 1 || got.Windows[0].WindowMinutes != 5 || got.Windows[0].Events != 120 {
+		t.Fatalf("unexpected windows: %+v", got.Windows)
+	}
+}
+
+func TestExplanationToAPIOmitsBurnRateBelowMinimum(t *testing.T) {
+	got := explanationToAPI(evaluator.Explanation{
+		Windows: []evaluator.ExplainedWindow{
+			{Minutes: 5, Compliance: 1, Events: 0},
+			{Minutes: 60, Compliance: 0.97, Events: 400, Sufficient: true, BurnRate: 3},
+		},
+		Queries: []evaluator.ExplainedQuery{{WindowMinutes: 5, Query: sli.Query{Name: "total", Text: "sum(rate(x[5m]))"}}},
+		Action:  evaluator.ExplainedAction{},
+	})
+	if got.Windows[0].BurnRate != nil || got.Windows[1].BurnRate == nil || *got.Windows[1].BurnRate != 3 {
+		t.Fatalf("unexpected windows: %+v", got.Windows)
+	}
+	if got.Queries[0].Args == nil || got.Action.EventType != nil || got.Events == nil {
+		t.Fatalf("expected empty collections and no event type, got %+v", got)
+	}
+}
@@ -0,0 +1,236 @@
+package httpapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apiv1 "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/api"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/webhook"
+)
+
+const (
+	defaultWebhookTimeout = 5 * time.Second
+	minWebhookSecretLen   = 16
+)
+
+func (s *Server) ListWebhookSubscriptions(w http.ResponseWriter, r *http.Request, params apiv1.ListWebhookSubscriptionsParams) {
+	page, size := pagination(params.Page, params.PageSize)
+	if (params.ScopeType == nil) != (params.ScopeId == nil) {
+		writeProblem(w, http.StatusBadRequest, "invalid_scope", "scopeType and scopeId must be given together")
+		return
+	}
+	scopeType := ""
+	var scopeID *uuid.UUID
+	if params.ScopeType != nil {
+		scopeType = string(*params.ScopeType)
+		id := uuid.UUID(*params.ScopeId)
+		scopeID = &id
+	}
+	items, pg, err := s.store.ListWebhookSubscriptions(r.Context(), page, size, scopeType, scopeID)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "list_webhook_subscriptions_failed", err.Error())
+		return
+	}
+	resp := apiv1.WebhookSubscriptionListResponse{
+		Items: make([]apiv1.WebhookSubscription, 0, len(items)),
+		Page:  apiv1.Pagination{Page: pg.Page, PageSize: pg.PageSize, Total: pg.Total},
+	}
+	for _, sub := range items {
+		resp.Items = append(resp.Items, webhookSubscriptionToAPI(sub))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) CreateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	var req apiv1.WebhookSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_body", "invalid JSON body")
+		return
+	}
+	if req.Secret == nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_webhook_subscription", "secret is required")
+		return
+	}
+	sub, err := s.webhookSubscriptionFromAPI(r.Context(), uuid.New(), req, "")
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_webhook_subscription", err.Error())
+		return
+	}
+	created, err := s.store.CreateWebhookSubscription(r.Context(), sub)
+	if err != nil {
+		writeProblem(w, statusFromError(err), "create_webhook_subscription_failed", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, webhookSubscriptionToAPI(created))
+}
+
+func (s *Server) GetWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookSubscriptionId apiv1.WebhookSubscriptionId) {
+	sub, err := s.store.GetWebhookSubscription(r.Context(), uuid.UUID(webhookSubscriptionId))
+	if err != nil {
+		writeProblem(w, statusFromError(err), "webhook_subscription_not_found", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, webhookSubscriptionToAPI(sub))
+}
+
+func (s *Server) UpdateWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookSubscriptionId apiv1.WebhookSubscriptionId) {
+	var req apiv1.WebhookSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_body", "invalid JSON body")
+		return
+	}
+	id := uuid.UUID(webhookSubscriptionId)
+	current, err := s.store.GetWebhookSubscription(r.Context(), id)
+	if err != nil {
+		writeProblem(w, statusFromError(err), "webhook_subscription_not_found", err.Error())
+		return
+	}
+	sub, err := s.webhookSubscriptionFromAPI(r.Context(), id, req, current.Secret)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "invalid_webhook_subscription", err.Error())
+		return
+	}
+	updated, err := s.store.UpdateWebhookSubscription(r.Context(), sub)
+	if err != nil {
+		writeProblem(w, statusFromError(err), "update_webhook_subscription_failed", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, webhookSubscriptionToAPI(updated))
+}
+
+func (s *Server) DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookSubscriptionId apiv1.WebhookSubscriptionId) {
+	if err := s.store.DeleteWebhookSubscription(r.Context(), uuid.UUID(webhookSubscriptionId)); err != nil {
+		writeProblem(w, statusFromError(err), "delete_webhook_subscription_failed", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// webhookSubscriptionFromAPI validates a request, including that its team or service exists.
+// currentSecret is kept when the request has none.
+func (s *Server) webhookSubscriptionFromAPI(ctx context.Context, id uuid.UUID, req apiv1.WebhookSubscriptionRequest, currentSecret string) (store.WebhookSubscription, error) {
+	sub, err := parseWebhookSubscription(id, req, currentSecret, s.cfg.WebhookAllowedHosts)
+	if err != nil {
+		return store.WebhookSubscription{}, err
+	}
+	if sub.ScopeType == string(apiv1.WebhookScopeTypeTeam) {
+		_, err = s.store.GetTeam(ctx, sub.ScopeID)
+	} else {
+		_, err = s.store.GetService(ctx, sub.ScopeID)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return store.WebhookSubscription{}, fmt.Errorf("%s %s does not exist", sub.ScopeType, sub.ScopeID)
+	}
+	return sub, err
+}
+
+func parseWebhookSubscription(id uuid.UUID, req apiv1.WebhookSubscriptionRequest, currentSecret string, allowedHosts []string) (store.WebhookSubscription, error) {
+	sub := store.WebhookSubscription{
+		ID:          id,
+		Name:        strings.TrimSpace(req.Name),
+		ScopeType:   string(req.ScopeType),
+		ScopeID:     uuid.UUID(req.ScopeId),
+		URL:         strings.TrimSpace(req.Url),
+		Secret:      currentSecret,
+		ContentMode: webhook.ContentModeStructured,
+		Timeout:     defaultWebhookTimeout,
+		Enabled:     true,
+	}
+	if sub.Name == "" {
+		return sub, fmt.Errorf("name is required")
+	}
+	if !req.ScopeType.Valid() {
+		return sub, fmt.Errorf("scopeType %q must be one of team, service", req.ScopeType)
+	}
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return sub, fmt.Errorf("url must be an absolute http or https URL")
+	}
+	if !publicWebhookHost(u.Hostname(), allowedHosts) {
+		return sub, fmt.Errorf("url host %q is a loopback, link-local or private address", u.Hostname())
+	}
+	if req.Secret != nil {
+		sub.Secret = *req.Secret
+	}
+	if len(sub.Secret) < minWebhookSecretLen {
+		return sub, fmt.Errorf("secret must be at least %d characters", minWebhookSecretLen)
+	}
+	if req.EventTypes != nil {
+		for _, t := range *req.EventTypes {
+			if !t.Valid() {
+				return sub, fmt.Errorf("unknown event type %q", t)
+			}
+			sub.EventTypes = append(sub.EventTypes, string(t))
+		}
+	}
+	if req.ContentMode != nil {
+		if !req.ContentMode.Valid() {
+			return sub, fmt.Errorf("contentMode %q must be one of structured, binary", *req.ContentMode)
+		}
+		sub.ContentMode = string(*req.ContentMode)
+	}
+	if req.TimeoutMs != nil {
+		if *req.TimeoutMs < 100 || *req.TimeoutMs > 30000 {
+			return sub, fmt.Errorf("timeoutMs must be between 100 and 30000")
+		}
+		sub.Timeout = time.Duration(*req.TimeoutMs) * time.Millisecond
+	}
+	if req.Enabled != nil {
+		sub.Enabled = *req.Enabled
+	}
+	return sub, nil
+}
+
+// publicWebhookHost keeps webhooks away from the control plane's own network: localhost and
+// loopback, link-local, private and unspecified addresses are refused unless allowed lists the host.
+// Host names are not resolved.
+func publicWebhookHost(host string, allowed []string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, a := range allowed {
+		if strings.EqualFold(host, a) {
+			return true
+		}
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return true
+	}
+	ip = ip.Unmap()
+	return !(ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsPrivate() || ip.IsUnspecified())
+}
+
+// webhookSubscriptionToAPI never exposes the secret.
+func webhookSubscriptionToAPI(sub store.WebhookSubscription) apiv1.WebhookSubscription {
+	eventTypes := sub.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return apiv1.WebhookSubscription{
+		Id:          sub.ID,
+		Name:        sub.Name,
+		ScopeType:   apiv1.WebhookScopeType(sub.ScopeType),
+		ScopeId:     sub.ScopeID,
+		Url:         sub.URL,
+		EventTypes:  eventTypes,
+		ContentMode: apiv1.WebhookContentMode(sub.ContentMode),
+		TimeoutMs:   int(sub.Timeout.Milliseconds()),
+		Enabled:     sub.Enabled,
+		CreatedAt:   sub.CreatedAt,
+		UpdatedAt:   sub.UpdatedAt,
+	}
+}
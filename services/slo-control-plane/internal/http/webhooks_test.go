@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	apiv1 "github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/api"
+)
+
+func TestParseWebhookSubscription(t *testing.T) {
+	secret := "0123456789abcdef"
+	types := []apiv1.WebhookSubscriptionRequestEventTypes{apiv1.WebhookSubscriptionRequestEventTypesBurnStarted}
+	req := apiv1.WebhookSubscriptionRequest{
+		Name:       "pager",
+		ScopeType:  apiv1.WebhookScopeTypeTeam,
+		ScopeId:    uuid.New(),
+		Url:        "https://hooks.example.com/slo",
+		Secret:     &secret,
+		EventTypes: &types,
+	}
+	sub, err := parseWebhookSubscription(uuid.New(), req, "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !sub.Enabled || sub.Timeout != 5*time.Second || sub.ContentMode != "structured" || !sub.Wants("burn_started") || sub.Wants("burn_resolved") {
+		t.Fatalf("unexpected subscription: %+v", sub)
+	}
+
+	// Updates without a secret keep the current one.
+	req.Secret = nil
+	if sub, err = parseWebhookSubscription(sub.ID, req, secret, nil); err != nil || sub.Secret != secret {
+		t.Fatalf("expected the current secret to be kept, got %q, %v", sub.Secret, err)
+	}
+	if _, err := parseWebhookSubscription(sub.ID, req, "", nil); err == nil {
+		t.Fatalf("expected a missing secret to be rejected")
+	}
+
+	bad := req
+	bad.Url = "ftp://hooks.example.com"
+	if _, err := parseWebhookSubscription(sub.ID, bad, secret, nil); err == nil {
+		t.Fatalf("expected a non-http url to be rejected")
+	}
+	bad.Url = "http://127.0.0.1:8080/hook"
+	if _, err := parseWebhookSubscription(sub.ID, bad, secret, nil); err == nil {
+		t.Fatalf("expected a loopback url to be rejected")
+	}
+	if _, err := parseWebhookSubscription(sub.ID, bad, secret, []string{"127.0.0.1"}); err != nil {
+		t.Fatalf("expected an allowed loopback url to be accepted, got %v", err)
+	}
+	mode := apiv1.WebhookContentMode("batched")
+	bad = req
+	bad.ContentMode = &mode
+	if _, err := parseWebhookSubscription(sub.ID, bad, secret, nil); err == nil {
+		t.Fatalf("expected an unknown content mode to be rejected")
+	}
+	timeout := 50
+	bad = req
+	bad.TimeoutMs = &timeout
+	if _, err := parseWebhookSubscription(sub.ID, bad, secret, nil); err == nil {
+		t.Fatalf("expected a too short timeout to be rejected")
+	}
+}
+
+func TestPublicWebhookHost(t *testing.T) {
+	cases := []struct {
+		host    string
+		allowed []string
+		want    bool
+	}{
+		{"hooks.example.com", nil, true},
+		{"203.0.113.10", nil, true},
+		{"2001:db8::1", nil, true},
+		{"localhost", nil, false},
+		{"api.localhost.", nil, false},
+		{"127.0.0.1", nil, false},
+		{"::1", nil, false},
+		{"::ffff:127.0.0.1", nil, false},
+		{"0.0.0.0", nil, false},
+		{"169.254.169.254", nil, false},
+		{"fe80::1", nil, false},
+		{"10.1.2.3", nil, false},
+		{"172.16.0.1", nil, false},
+		{"192.168.1.1", nil, false},
+		{"fd00::1", nil, false},
+		{"10.1.2.3", []string{"10.1.2.3"}, true},
+		{"LOCALHOST", []string{"localhost"}, true},
+	}
+	for _, tc := range cases {
+		if got := publicWebhookHost(tc.host, tc.allowed); got != tc.want {
+			t.Fatalf("publicWebhookHost(%q, %v) = %v, want %v", tc.host, tc.allowed, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package leader
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/telemetry"
+)
+
+const DefaultLeaseName = "slo-evaluator"
+
+type LeaseStore interface {
+	AcquireLease(ctx context.Context, name, holderID string, ttl time.Duration) (bool, error)
+	ReleaseLease(ctx context.Context, name, holderID string) error
+}
+
+type Config struct {
+	LeaseName     string
+	HolderID      string
+	TTL           time.Duration
+	RenewInterval time.Duration
+}
+
+// Elector holds a Postgres lease so only one replica evaluates at a time. A standby takes over
+// at most TTL+RenewInterval after the leader stops renewing.
+type Elector struct {
+	store LeaseStore
+	cfg   Config
+	now   func() time.Time
+
+	mu         sync.Mutex
+	leader     bool
+	validUntil time.Time
+}
+
+func New(st LeaseStore, cfg Config) *Elector {
+	if cfg.LeaseName == "" {
+		cfg.LeaseName = DefaultLeaseName
+	}
+	if cfg.HolderID == "" {
+		cfg.HolderID = DefaultHolderID()
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 30 * time.Second
+	}
+	if cfg.RenewInterval <= 0 || cfg.RenewInterval >= cfg.TTL {
+		cfg.RenewInterval = cfg.TTL / 3
+	}
+	return &Elector{store: st, cfg: cfg, now: time.Now}
+}
+
+// DefaultHolderID is the hostname (the pod name on Kubernetes) plus a random suffix, so a
+// restarted process never mistakes its predecessor's lease for its own.
+func DefaultHolderID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "slo-evaluator"
+	}
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	return host + "-" + hex.EncodeToString(b[:])
+}
+
+func (e *Elector) HolderID() string {
+	return e.cfg.HolderID
+}
+
+// IsLeader reports whether this replica holds an unexpired lease. Leadership lapses locally at
+// the lease expiry even if renewals keep failing, before any standby can acquire it.
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leader && e.now().Before(e.validUntil)
+}
+
+// Tick acquires or renews the lease once and returns the resulting leadership state.
+func (e *Elector) Tick(ctx context.Context) bool {
+	tr := otel.Tracer("slo-control-plane/leader")
+	ctx, span := tr.Start(ctx, "leader.tick", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("leader.lease", e.cfg.LeaseName),
+		attribute.String("leader.holder", e.cfg.HolderID),
+	)
+
+	// Expiry is measured from before the round trip so the local view never outlives the row.
+	started := e.now()
+	acquired, err := e.store.AcquireLease(ctx, e.cfg.LeaseName, e.cfg.HolderID, e.cfg.TTL)
+	wasLeader := e.IsLeader()
+	if err != nil {
+		telemetry.RecordSpanError(span, err)
+		log.Printf("leader lease renew failed lease=%s holder=%s: %v", e.cfg.LeaseName, e.cfg.HolderID, err)
+	} else {
+		e.mu.Lock()
+		e.leader = acquired
+		if acquired {
+			e.validUntil = started.Add(e.cfg.TTL)
+		}
+		e.mu.Unlock()
+	}
+	isLeader := e.IsLeader()
+	span.SetAttributes(attribute.Bool("leader.is_leader", isLeader))
+	switch {
+	case isLeader && !wasLeader:
+		log.Printf("leadership acquired lease=%s holder=%s ttl=%s", e.cfg.LeaseName, e.cfg.HolderID, e.cfg.TTL)
+	case !isLeader && wasLeader:
+		log.Printf("leadership lost lease=%s holder=%s", e.cfg.LeaseName, e.cfg.HolderID)
+	}
+	return isLeader
+}
+
+// Run keeps the lease renewed until ctx is done, then releases it so a standby can take over
+// without waiting for expiry.
+func (e *Elector) Run(ctx context.Context) {
+	e.Tick(ctx)
+	t := time.NewTicker(e.cfg.RenewInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			e.Release(context.Background())
+			return
+		case <-t.C:
+			e.Tick(ctx)
+		}
+	}
+}
+
+func (e *Elector) Release(ctx context.Context) {
+	e.mu.Lock()
+	wasLeader := e.leader
+	e.leader = false
+	e.mu.Unlock()
+	if !wasLeader {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := e.store.ReleaseLease(ctx, e.cfg.LeaseName, e.cfg.HolderID); err != nil {
+		log.Printf("leader lease release failed lease=%s holder=%s: %v", e.cfg.LeaseName, e.cfg.HolderID, err)
+		return
+	}
+	log.Printf("leadership released lease=%s holder=%s", e.cfg.LeaseName, e.cfg.HolderID)
+}
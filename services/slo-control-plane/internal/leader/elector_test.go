@@ -0,0 +1,111 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeLeaseStore struct {
+	holder    string
+	expiresAt time.Time
+	now       *time.Time
+	err       error
+}
+
+func (f *fakeLeaseStore) AcquireLease(_ context.Context, _, holderID string, ttl time.Duration) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	if f.holder != "" && f.holder != holderID && f.now.Before(f.expiresAt) {
+		return false, nil
+	}
+	f.holder = holderID
+	f.expiresAt = f.now.Add(ttl)
+	return true, nil
+}
+
+func (f *fakeLeaseStore) ReleaseLease(_ context.Context, _, holderID string) error {
+	if f.holder == holderID {
+		f.holder = ""
+	}
+	return nil
+}
+
+func newTestElector(st *fakeLeaseStore, holder string) *Elector {
+	e := New(st, Config{HolderID: holder, TTL: 30 * time.Second, RenewInterval: 10 * time.Second})
+	e.now = func() time.Time { return *st.now }
+	return e
+}
+
+func TestElectorSingleLeader(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	st := &fakeLeaseStore{now: &now}
+	a := newTestElector(st, "a")
+	b := newTestElector(st, "b")
+
+	if !a.Tick(context.Background()) {
+		t.Fatalf("expected first replica to acquire the lease")
+	}
+	if b.Tick(context.Background()) {
+		t.Fatalf("expected standby not to acquire a held lease")
+	}
+	now = now.Add(10 * time.Second)
+	if !a.Tick(context.Background()) || b.Tick(context.Background()) {
+		t.Fatalf("expected leader to renew and standby to stay standby")
+	}
+}
+
+func TestElectorStandbyTakesOverAfterExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	st := &fakeLeaseStore{now: &now}
+	a := newTestElector(st, "a")
+	b := newTestElector(st, "b")
+	a.Tick(context.Background())
+
+	// a stops renewing; b polls every RenewInterval.
+	now = now.Add(20 * time.Second)
+	if b.Tick(context.Background()) {
+		t.Fatalf("expected standby to wait for lease expiry")
+	}
+	now = now.Add(10 * time.Second)
+	if a.IsLeader() {
+		t.Fatalf("expected stale leader to stop leading at lease expiry")
+	}
+	if !b.Tick(context.Background()) {
+		t.Fatalf("expected standby to take over within ttl+renew interval")
+	}
+}
+
+func TestElectorKeepsLeadershipUntilExpiryOnRenewError(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	st := &fakeLeaseStore{now: &now}
+	a := newTestElector(st, "a")
+	a.Tick(context.Background())
+
+	st.err = errors.New("connection reset")
+	now = now.Add(10 * time.Second)
+	if !a.Tick(context.Background()) {
+		t.Fatalf("expected leadership to survive a failed renewal while the lease is valid")
+	}
+	now = now.Add(20 * time.Second)
+	if a.Tick(context.Background()) {
+		t.Fatalf("expected leadership to lapse at lease expiry")
+	}
+}
+
+func TestElectorReleaseHandsOver(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	st := &fakeLeaseStore{now: &now}
+	a := newTestElector(st, "a")
+	b := newTestElector(st, "b")
+	a.Tick(context.Background())
+	a.Release(context.Background())
+	if a.IsLeader() {
+		t.Fatalf("expected released elector not to lead")
+	}
+	if !b.Tick(context.Background()) {
+		t.Fatalf("expected standby to acquire a released lease immediately")
+	}
+}
@@ -0,0 +1,192 @@
+// Package maintenance computes when planned-downtime windows are in effect.
+package maintenance
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	ScopeTeam    = "team"
+	ScopeService = "service"
+	ScopeSLO     = "slo"
+
+	RecurrenceDaily   = "daily"
+	RecurrenceWeekly  = "weekly"
+	RecurrenceMonthly = "monthly"
+)
+
+// maxOccurrences bounds the occurrences walked for one lookup.
+const maxOccurrences = 10000
+
+// Schedule is when a maintenance window is in effect: once from StartsAt to EndsAt, or, with a
+// Recurrence, again every Interval days, weeks or months from StartsAt in TimeZone, so a nightly
+// window keeps its wall-clock time across DST changes. Occurrences start no later than Until when
+// it is set.
+type Schedule struct {
+	StartsAt   time.Time
+	EndsAt     time.Time
+	Recurrence string
+	Interval   int
+	Until      time.Time
+	TimeZone   string
+}
+
+// Interval is a [Start, End) span of time.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (s Schedule) Validate() error {
+	if !s.StartsAt.Before(s.EndsAt) {
+		return fmt.Errorf("startsAt must be before endsAt")
+	}
+	loc, err := s.location()
+	if err != nil {
+		return err
+	}
+	if s.Recurrence == "" {
+		if s.Interval != 0 || !s.Until.IsZero() {
+			return fmt.Errorf("interval and until need a recurrence")
+		}
+		return nil
+	}
+	if s.Interval < 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	switch s.Recurrence {
+	case RecurrenceDaily, RecurrenceWeekly:
+	case RecurrenceMonthly:
+		if s.StartsAt.In(loc).Day() > 28 {
+			return fmt.Errorf("monthly windows must start on or before the 28th")
+		}
+	default:
+		return fmt.Errorf("recurrence %q must be one of daily, weekly, monthly", s.Recurrence)
+	}
+	// Occurrences must not overlap, even across a DST change.
+	if shortest, _ := s.period(); s.EndsAt.Sub(s.StartsAt) >= shortest {
+		return fmt.Errorf("a %s window must be shorter than its recurrence", s.Recurrence)
+	}
+	if !s.Until.IsZero() && s.Until.Before(s.StartsAt) {
+		return fmt.Errorf("until must not be before startsAt")
+	}
+	return nil
+}
+
+// ActiveAt reports whether an occurrence covers t.
+func (s Schedule) ActiveAt(t time.Time) bool {
+	return len(s.Occurrences(t, t.Add(time.Nanosecond))) > 0
+}
+
+// Occurrences returns the occurrences overlapping [from, to), clipped to it, in order. An invalid
+// schedule has none.
+func (s Schedule) Occurrences(from, to time.Time) []Interval {
+	loc, err := s.location()
+	if err != nil || !from.Before(to) {
+		return nil
+	}
+	duration := s.EndsAt.Sub(s.StartsAt)
+	if s.Recurrence == "" {
+		return clip([]Interval{{Start: s.StartsAt, End: s.EndsAt}}, from, to)
+	}
+	start := s.StartsAt.In(loc)
+	// Skip the occurrences that certainly end before from.
+	k := 0
+	if skip := from.Sub(start) - duration; skip > 0 {
+		_, longest := s.period()
+		k = int(skip / longest)
+	}
+	var out []Interval
+	for n := 0; n < maxOccurrences; n, k = n+1, k+1 {
+		occStart := s.occurrence(start, k)
+		if !occStart.Before(to) || (!s.Until.IsZero() && occStart.After(s.Until)) {
+			break
+		}
+		out = append(out, Interval{Start: occStart, End: occStart.Add(duration)})
+	}
+	return clip(out, from, to)
+}
+
+func (s Schedule) occurrence(start time.Time, k int) time.Time {
+	n := k * s.interval()
+	switch s.Recurrence {
+	case RecurrenceMonthly:
+		return start.AddDate(0, n, 0)
+	case RecurrenceWeekly:
+		return start.AddDate(0, 0, 7*n)
+	default:
+		return start.AddDate(0, 0, n)
+	}
+}
+
+// period returns the shortest and longest time between two occurrences; days are 23 to 25 hours
+// long around DST changes.
+func (s Schedule) period() (time.Duration, time.Duration) {
+	days := 1
+	switch s.Recurrence {
+	case RecurrenceWeekly:
+		days = 7
+	case RecurrenceMonthly:
+		return time.Duration(s.interval()) * (28*24 - 1) * time.Hour, time.Duration(s.interval()) * (31*24 + 1) * time.Hour
+	}
+	n := time.Duration(s.interval() * days)
+	return n*24*time.Hour - time.Hour, n*24*time.Hour + time.Hour
+}
+
+func (s Schedule) interval() int {
+	if s.Interval <= 0 {
+		return 1
+	}
+	return s.Interval
+}
+
+func (s Schedule) location() (*time.Location, error) {
+	if s.TimeZone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeZone %q: %w", s.TimeZone, err)
+	}
+	return loc, nil
+}
+
+func clip(in []Interval, from, to time.Time) []Interval {
+	var out []Interval
+	for _, iv := range in {
+		if !iv.End.After(from) || !iv.Start.Before(to) {
+			continue
+		}
+		if iv.Start.Before(from) {
+			iv.Start = from
+		}
+		if iv.End.After(to) {
+			iv.End = to
+		}
+		out = append(out, iv)
+	}
+	return out
+}
+
+// Merge sorts intervals and joins the ones that overlap or touch.
+func Merge(in []Interval) []Interval {
+	if len(in) == 0 {
+		return nil
+	}
+	sorted := append([]Interval(nil), in...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+	out := []Interval{sorted[0]}
+	for _, iv := range sorted[1:] {
+		last := &out[len(out)-1]
+		if iv.Start.After(last.End) {
+			out = append(out, iv)
+			continue
+		}
+		if iv.End.After(last.End) {
+			last.End = iv.End
+		}
+	}
+	return out
+}
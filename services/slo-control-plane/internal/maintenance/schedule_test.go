@@ -0,0 +1,91 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleOnce(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	s := Schedule{StartsAt: start, EndsAt: start.Add(2 * time.Hour)}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if !s.ActiveAt(start) || !s.ActiveAt(start.Add(time.Hour)) || s.ActiveAt(start.Add(2*time.Hour)) || s.ActiveAt(start.Add(-time.Second)) {
+		t.Fatalf("expected the window to cover [start, end)")
+	}
+	got := s.Occurrences(start.Add(time.Hour), start.Add(5*time.Hour))
+	if len(got) != 1 || !got[0].Start.Equal(start.Add(time.Hour)) || !got[0].End.Equal(start.Add(2*time.Hour)) {
+		t.Fatalf("expected the occurrence clipped to the range, got %+v", got)
+	}
+}
+
+func TestScheduleDailyKeepsWallClockAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no tzdata: %v", err)
+	}
+	start := time.Date(2026, 3, 27, 2, 30, 0, 0, loc)
+	s := Schedule{StartsAt: start, EndsAt: start.Add(30 * time.Minute), Recurrence: RecurrenceDaily, TimeZone: "Europe/Berlin"}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	// DST starts on 2026-03-29; the window stays at 02:30 local time once it exists again.
+	if at := time.Date(2026, 3, 31, 2, 45, 0, 0, loc); !s.ActiveAt(at) {
+		t.Fatalf("expected the window to be active at %s", at)
+	}
+	if at := time.Date(2026, 3, 31, 3, 15, 0, 0, loc); s.ActiveAt(at) {
+		t.Fatalf("expected the window to be over at %s", at)
+	}
+	if got := s.Occurrences(start, start.AddDate(0, 0, 10)); len(got) != 10 {
+		t.Fatalf("expected 10 daily occurrences, got %d", len(got))
+	}
+	if far := start.AddDate(3, 0, 0).Add(10 * time.Minute); !s.ActiveAt(far) {
+		t.Fatalf("expected the window to still recur at %s", far)
+	}
+}
+
+func TestScheduleWeeklyUntil(t *testing.T) {
+	start := time.Date(2026, 3, 2, 22, 0, 0, 0, time.UTC)
+	s := Schedule{
+		StartsAt:   start,
+		EndsAt:     start.Add(4 * time.Hour),
+		Recurrence: RecurrenceWeekly,
+		Interval:   2,
+		Until:      start.AddDate(0, 0, 28),
+	}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	got := s.Occurrences(start, start.AddDate(0, 2, 0))
+	if len(got) != 3 || !got[1].Start.Equal(start.AddDate(0, 0, 14)) {
+		t.Fatalf("expected occurrences every two weeks until the end date, got %+v", got)
+	}
+	if s.ActiveAt(start.AddDate(0, 0, 7).Add(time.Hour)) {
+		t.Fatalf("expected no occurrence in the off week")
+	}
+}
+
+func TestScheduleValidate(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for name, s := range map[string]Schedule{
+		"empty":          {StartsAt: start, EndsAt: start},
+		"long daily":     {StartsAt: start, EndsAt: start.Add(24 * time.Hour), Recurrence: RecurrenceDaily},
+		"unknown":        {StartsAt: start, EndsAt: start.Add(time.Hour), Recurrence: "hourly"},
+		"late monthly":   {StartsAt: start.AddDate(0, 0, 29), EndsAt: start.AddDate(0, 0, 29).Add(time.Hour), Recurrence: RecurrenceMonthly},
+		"bad zone":       {StartsAt: start, EndsAt: start.Add(time.Hour), TimeZone: "Mars/Olympus"},
+		"until w/o rule": {StartsAt: start, EndsAt: start.Add(time.Hour), Until: start.AddDate(0, 1, 0)},
+	} {
+		if err := s.Validate(); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2026, 3, 1, h, 0, 0, 0, time.UTC) }
+	got := Merge([]Interval{{at(5), at(6)}, {at(1), at(3)}, {at(2), at(4)}, {at(4), at(5)}, {at(8), at(9)}})
+	if len(got) != 2 || !got[0].Start.Equal(at(1)) || !got[0].End.Equal(at(6)) || !got[1].Start.Equal(at(8)) {
+		t.Fatalf("unexpected merge: %+v", got)
+	}
+}
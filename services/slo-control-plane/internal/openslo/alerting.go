@@ -0,0 +1,167 @@
+package openslo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BurnRateTier is one multi-window burn-rate alert: it fires with Severity when the burn rate over
+// both the long and the short window reaches Threshold. ShortWindowMinutes 0 checks the long window only.
+type BurnRateTier struct {
+	Name               string
+	Severity           string
+	Threshold          float64
+	LongWindowMinutes  int
+	ShortWindowMinutes int
+}
+
+// compileBurnRateTiers resolves the SLO's alertPolicies (every AlertPolicy in the bundle when the SLO
+// lists none) into burn-rate tiers, most severe threshold first.
+func compileBurnRateTiers(sloDoc map[string]any, policies, conditions map[string]map[string]any) ([]BurnRateTier, error) {
+	spec, _ := sloDoc["spec"].(map[string]any)
+	var selected []map[string]any
+	if refs, ok := spec["alertPolicies"].([]any); ok && len(refs) > 0 {
+		for _, ref := range refs {
+			policy, err := resolveAlertPolicy(ref, policies)
+			if err != nil {
+				return nil, err
+			}
+			selected = append(selected, policy)
+		}
+	} else {
+		names := make([]string, 0, len(policies))
+		for name := range policies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			selected = append(selected, policies[name])
+		}
+	}
+
+	var tiers []BurnRateTier
+	for _, policy := range selected {
+		policyName := metadataName(policy)
+		policySpec, _ := policy["spec"].(map[string]any)
+		items, _ := policySpec["conditions"].([]any)
+		if len(items) == 0 {
+			return nil, fmt.Errorf("alertPolicy %q must list at least one condition", policyName)
+		}
+		for _, item := range items {
+			cond, err := resolveAlertCondition(policyName, item, conditions)
+			if err != nil {
+				return nil, err
+			}
+			tier, err := parseBurnRateTier(cond)
+			if err != nil {
+				return nil, err
+			}
+			tiers = append(tiers, tier)
+		}
+	}
+	sort.SliceStable(tiers, func(i, j int) bool { return tiers[i].Threshold > tiers[j].Threshold })
+	return tiers, nil
+}
+
+func resolveAlertPolicy(ref any, policies map[string]map[string]any) (map[string]any, error) {
+	name := toString(ref)
+	if m, ok := ref.(map[string]any); ok {
+		if _, inline := m["spec"]; inline {
+			return m, nil
+		}
+		name = toString(m["alertPolicyRef"])
+	}
+	name = strings.TrimSpace(name)
+	policy, ok := policies[name]
+	if !ok {
+		return nil, fmt.Errorf("slo references unknown AlertPolicy %q", name)
+	}
+	return policy, nil
+}
+
+func resolveAlertCondition(policyName string, item any, conditions map[string]map[string]any) (map[string]any, error) {
+	m, _ := item.(map[string]any)
+	if _, inline := m["spec"]; inline {
+		return m, nil
+	}
+	name := strings.TrimSpace(toString(m["conditionRef"]))
+	cond, ok := conditions[name]
+	if !ok {
+		return nil, fmt.Errorf("alertPolicy %q references unknown AlertCondition %q", policyName, name)
+	}
+	return cond, nil
+}
+
+func parseBurnRateTier(cond map[string]any) (BurnRateTier, error) {
+	tier := BurnRateTier{Name: metadataName(cond)}
+	spec, _ := cond["spec"].(map[string]any)
+	tier.Severity = strings.TrimSpace(toString(spec["severity"]))
+	if tier.Severity == "" || strings.EqualFold(tier.Severity, "none") {
+		return BurnRateTier{}, fmt.Errorf("alertCondition %q severity is required and cannot be none", tier.Name)
+	}
+	c, _ := spec["condition"].(map[string]any)
+	if kind := strings.ToLower(toString(c["kind"])); kind != "burnrate" {
+		return BurnRateTier{}, fmt.Errorf("alertCondition %q condition.kind %q is not supported; use burnrate", tier.Name, kind)
+	}
+	switch strings.ToLower(toString(c["op"])) {
+	case "", "gte", "gt":
+	default:
+		return BurnRateTier{}, fmt.Errorf("alertCondition %q burnrate op must be gt or gte", tier.Name)
+	}
+	tier.Threshold = toFloat64(c["threshold"])
+	if tier.Threshold <= 0 {
+		return BurnRateTier{}, fmt.Errorf("alertCondition %q burnrate threshold must be positive", tier.Name)
+	}
+	long, err := durationToMinutes(strings.TrimSpace(toString(c["lookbackWindow"])))
+	if err != nil {
+		return BurnRateTier{}, fmt.Errorf("alertCondition %q lookbackWindow: %w", tier.Name, err)
+	}
+	tier.LongWindowMinutes = long
+	// The short window defaults to 1/12 of the long one (1h/5m, 6h/30m, 3d/6h).
+	tier.ShortWindowMinutes = long / 12
+	if raw := strings.TrimSpace(toString(c["shortWindow"])); raw != "" {
+		short, err := durationToMinutes(raw)
+		if err != nil {
+			return BurnRateTier{}, fmt.Errorf("alertCondition %q shortWindow: %w", tier.Name, err)
+		}
+		tier.ShortWindowMinutes = short
+	}
+	if tier.ShortWindowMinutes >= tier.LongWindowMinutes {
+		return BurnRateTier{}, fmt.Errorf("alertCondition %q shortWindow must be shorter than lookbackWindow", tier.Name)
+	}
+	return tier, nil
+}
+
+func burnRateTiersToAny(tiers []BurnRateTier) []any {
+	out := make([]any, 0, len(tiers))
+	for _, t := range tiers {
+		out = append(out, map[string]any{
+			"name":               t.Name,
+			"severity":           t.Severity,
+			"threshold":          t.Threshold,
+			"longWindowMinutes":  t.LongWindowMinutes,
+			"shortWindowMinutes": t.ShortWindowMinutes,
+		})
+	}
+	return out
+}
+
+func burnRateTiersFromAny(v any) []BurnRateTier {
+	items, _ := v.([]any)
+	var out []BurnRateTier
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		out = append(out, BurnRateTier{
+			Name:               toString(m["name"]),
+			Severity:           toString(m["severity"]),
+			Threshold:          toFloat64(m["threshold"]),
+			LongWindowMinutes:  int(toFloat64(m["longWindowMinutes"])),
+			ShortWindowMinutes: int(toFloat64(m["shortWindowMinutes"])),
+		})
+	}
+	return out
+}
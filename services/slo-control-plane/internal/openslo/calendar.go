@@ -0,0 +1,146 @@
+package openslo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CalendarWeek    = "week"
+	CalendarMonth   = "month"
+	CalendarQuarter = "quarter"
+)
+
+// CalendarWindow is a non-rolling SLO window whose error budget resets at each period boundary.
+type CalendarWindow struct {
+	Unit      string
+	Count     int
+	StartTime string
+	TimeZone  string
+}
+
+var calendarStartLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+	"2006-01-02",
+}
+
+func parseCalendarWindow(duration string, calendar map[string]any) (CalendarWindow, error) {
+	cal := CalendarWindow{
+		StartTime: toString(calendar["startTime"]),
+		TimeZone:  toString(calendar["timeZone"]),
+	}
+	if cal.TimeZone == "" {
+		cal.TimeZone = "UTC"
+	}
+	raw := strings.TrimSpace(duration)
+	if len(raw) < 2 {
+		return CalendarWindow{}, fmt.Errorf("invalid calendar timeWindow duration %q", duration)
+	}
+	n, err := strconv.Atoi(raw[:len(raw)-1])
+	if err != nil || n <= 0 {
+		return CalendarWindow{}, fmt.Errorf("invalid calendar timeWindow duration %q", duration)
+	}
+	cal.Count = n
+	switch raw[len(raw)-1] {
+	case 'w':
+		cal.Unit = CalendarWeek
+	case 'M':
+		cal.Unit = CalendarMonth
+	case 'Q':
+		cal.Unit = CalendarQuarter
+	default:
+		return CalendarWindow{}, fmt.Errorf("calendar timeWindow duration %q must use w, M or Q", duration)
+	}
+	if _, _, err := cal.Period(time.Now()); err != nil {
+		return CalendarWindow{}, err
+	}
+	return cal, nil
+}
+
+// NominalMinutes is the approximate period length, used where a single window size is needed.
+func (c CalendarWindow) NominalMinutes() int {
+	days := 7
+	switch c.Unit {
+	case CalendarMonth:
+		days = 30
+	case CalendarQuarter:
+		days = 91
+	}
+	return c.Count * days * 24 * 60
+}
+
+// Period returns the [start, end) budget period that contains now.
+func (c CalendarWindow) Period(now time.Time) (time.Time, time.Time, error) {
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid calendar timeZone %q: %w", c.TimeZone, err)
+	}
+	anchor, err := c.anchor(loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	count := c.Count
+	if count <= 0 {
+		count = 1
+	}
+	now = now.In(loc)
+
+	step := func(t time.Time, k int) time.Time {
+		switch c.Unit {
+		case CalendarMonth:
+			return t.AddDate(0, k*count, 0)
+		case CalendarQuarter:
+			return t.AddDate(0, 3*k*count, 0)
+		default:
+			return t.AddDate(0, 0, 7*k*count)
+		}
+	}
+
+	var k int
+	switch c.Unit {
+	case CalendarMonth, CalendarQuarter:
+		months := (now.Year()-anchor.Year())*12 + int(now.Month()-anchor.Month())
+		size := count
+		if c.Unit == CalendarQuarter {
+			size = 3 * count
+		}
+		k = floorDiv(months, size)
+	case CalendarWeek:
+		k = floorDiv(int(now.Sub(anchor).Hours()/24), 7*count)
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("unsupported calendar unit %q", c.Unit)
+	}
+	// DST shifts and month lengths can leave k off by one; settle on the period containing now.
+	for step(anchor, k).After(now) {
+		k--
+	}
+	for !step(anchor, k+1).After(now) {
+		k++
+	}
+	return step(anchor, k).UTC(), step(anchor, k+1).UTC(), nil
+}
+
+func (c CalendarWindow) anchor(loc *time.Location) (time.Time, error) {
+	if c.StartTime != "" {
+		for _, layout := range calendarStartLayouts {
+			if t, err := time.ParseInLocation(layout, c.StartTime, loc); err == nil {
+				return t.In(loc), nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid calendar startTime %q", c.StartTime)
+	}
+	// 2024-01-01 is a Monday, so the default anchor also starts weeks on Monday.
+	return time.Date(2024, time.January, 1, 0, 0, 0, 0, loc), nil
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,51 @@
+package openslo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarWindowMonthInTimeZone(t *testing.T) {
+	cal := CalendarWindow{Unit: CalendarMonth, Count: 1, StartTime: "2024-01-01 00:00:00", TimeZone: "Europe/Berlin"}
+	start, end, err := cal.Period(time.Date(2025, time.March, 31, 23, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Period() error = %v", err)
+	}
+	// 23:30 UTC on Mar 31 is already April 1 in Berlin (CEST, UTC+2).
+	if want := time.Date(2025, time.March, 31, 22, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Fatalf("start = %s, want %s", start, want)
+	}
+	if want := time.Date(2025, time.April, 30, 22, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Fatalf("end = %s, want %s", end, want)
+	}
+}
+
+func TestCalendarWindowQuarterAndWeek(t *testing.T) {
+	now := time.Date(2025, time.May, 14, 12, 0, 0, 0, time.UTC)
+	start, end, err := CalendarWindow{Unit: CalendarQuarter, Count: 1, TimeZone: "UTC"}.Period(now)
+	if err != nil {
+		t.Fatalf("Period() error = %v", err)
+	}
+	if !start.Equal(time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected quarter period %s - %s", start, end)
+	}
+	start, end, err = CalendarWindow{Unit: CalendarWeek, Count: 1, TimeZone: "UTC"}.Period(now)
+	if err != nil {
+		t.Fatalf("Period() error = %v", err)
+	}
+	if start.Weekday() != time.Monday || !start.Equal(time.Date(2025, time.May, 12, 0, 0, 0, 0, time.UTC)) || end.Sub(start) != 7*24*time.Hour {
+		t.Fatalf("unexpected week period %s - %s", start, end)
+	}
+}
+
+func TestCalendarWindowRejectsBadInput(t *testing.T) {
+	if _, err := parseCalendarWindow("1M", map[string]any{"timeZone": "Mars/Olympus"}); err == nil {
+		t.Fatalf("expected error for unknown time zone")
+	}
+	if _, err := parseCalendarWindow("30d", map[string]any{}); err == nil {
+		t.Fatalf("expected error for non-calendar unit")
+	}
+	if _, err := parseCalendarWindow("1M", map[string]any{"startTime": "next tuesday"}); err == nil {
+		t.Fatalf("expected error for invalid start time")
+	}
+}
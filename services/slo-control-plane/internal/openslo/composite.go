@@ -0,0 +1,118 @@
+package openslo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
+)
+
+const (
+	// TypeComposite and DatasourceComposite mark an SLO measured from its component SLOs.
+	TypeComposite       = "composite"
+	DatasourceComposite = "composite"
+
+	AggregationWeightedAverage = "weightedAverage"
+	AggregationMin             = "min"
+	AggregationAnd             = "and"
+)
+
+// Composite derives an SLO's compliance from other SLOs, for example the steps of a user journey.
+type Composite struct {
+	Aggregation string
+	Components  []CompositeComponent
+}
+
+type CompositeComponent struct {
+	SLOID  uuid.UUID
+	Weight float64
+}
+
+func compositeDoc(sloDoc map[string]any) (map[string]any, bool) {
+	spec, _ := sloDoc["spec"].(map[string]any)
+	v, ok := spec["composite"]
+	if !ok {
+		return nil, false
+	}
+	composite, _ := v.(map[string]any)
+	return composite, true
+}
+
+// compileComposite reads spec.composite: an aggregation and the component SLOs by ID, each
+// weighted 1 unless it says otherwise.
+func compileComposite(rt *Runtime, doc map[string]any) error {
+	if doc == nil {
+		return fmt.Errorf("spec.composite must be an object")
+	}
+	if rt.BudgetingMethod != sli.BudgetingOccurrences {
+		return fmt.Errorf("budgetingMethod %s is not supported for composite SLOs", rt.BudgetingMethod)
+	}
+	composite := Composite{}
+	switch raw := toString(doc["aggregation"]); strings.ToLower(raw) {
+	case "", strings.ToLower(AggregationWeightedAverage):
+		composite.Aggregation = AggregationWeightedAverage
+	case AggregationMin:
+		composite.Aggregation = AggregationMin
+	case AggregationAnd:
+		composite.Aggregation = AggregationAnd
+	default:
+		return fmt.Errorf("unsupported composite aggregation %q", raw)
+	}
+	items, _ := doc["components"].([]any)
+	if len(items) == 0 {
+		return fmt.Errorf("spec.composite.components must list at least one SLO")
+	}
+	seen := map[uuid.UUID]bool{}
+	for i, item := range items {
+		entry, _ := item.(map[string]any)
+		id, err := uuid.Parse(toString(entry["sloId"]))
+		if err != nil {
+			return fmt.Errorf("composite components[%d].sloId must be an SLO id", i)
+		}
+		if seen[id] {
+			return fmt.Errorf("composite component %s is listed twice", id)
+		}
+		seen[id] = true
+		weight := 1.0
+		if v, ok := entry["weight"]; ok {
+			weight = toFloat64(v)
+			if weight <= 0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+				return fmt.Errorf("composite components[%d].weight must be a positive number", i)
+			}
+		}
+		composite.Components = append(composite.Components, CompositeComponent{SLOID: id, Weight: weight})
+	}
+	rt.Type = TypeComposite
+	rt.DatasourceType = DatasourceComposite
+	rt.Composite = &composite
+	return nil
+}
+
+func compositeToMap(c Composite) map[string]any {
+	components := make([]any, 0, len(c.Components))
+	for _, comp := range c.Components {
+		components = append(components, map[string]any{"sloId": comp.SLOID.String(), "weight": comp.Weight})
+	}
+	return map[string]any{"aggregation": c.Aggregation, "components": components}
+}
+
+func compositeFromAny(v any) *Composite {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	c := Composite{Aggregation: toString(m["aggregation"])}
+	items, _ := m["components"].([]any)
+	for _, item := range items {
+		entry, _ := item.(map[string]any)
+		id, err := uuid.Parse(toString(entry["sloId"]))
+		if err != nil {
+			continue
+		}
+		c.Components = append(c.Components, CompositeComponent{SLOID: id, Weight: toFloat64(entry["weight"])})
+	}
+	return &c
+}
@@ -0,0 +1,125 @@
+package openslo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
+)
+
+// Objective is one target of an SLO, such as "99% under 300ms". Name is the objective's
+// displayName; at most one objective may be unnamed. Threshold and Op only apply to indicators
+// compared against a threshold (ClickHouse latency and Timeslices); Threshold defaults to the
+// metric source threshold and an empty Op means lte.
+type Objective struct {
+	Name      string
+	Target    float32
+	Threshold float32
+	Op        string
+}
+
+const (
+	OpLT  = "lt"
+	OpLTE = "lte"
+	OpGT  = "gt"
+	OpGTE = "gte"
+)
+
+// parseObjectives reads spec.objectives. Thresholds are resolved later by resolveObjectiveThresholds,
+// once the indicator is known.
+func parseObjectives(sloDoc map[string]any) ([]Objective, error) {
+	spec, _ := sloDoc["spec"].(map[string]any)
+	items, _ := spec["objectives"].([]any)
+	if len(items) == 0 {
+		return nil, fmt.Errorf("slo objective target is required")
+	}
+	seen := map[string]bool{}
+	out := make([]Objective, 0, len(items))
+	for i, item := range items {
+		doc, _ := item.(map[string]any)
+		obj := Objective{
+			Name:      toString(doc["displayName"]),
+			Target:    toFloat32(doc["target"]),
+			Threshold: toFloat32(doc["value"]),
+			Op:        strings.ToLower(toString(doc["op"])),
+		}
+		if seen[obj.Name] {
+			if obj.Name == "" {
+				return nil, fmt.Errorf("objectives[%d] needs a displayName: only one objective may be unnamed", i)
+			}
+			return nil, fmt.Errorf("objective displayName %q is repeated", obj.Name)
+		}
+		seen[obj.Name] = true
+		if obj.Target <= 0 || obj.Target >= 1 {
+			return nil, fmt.Errorf("slo target must be between 0 and 1")
+		}
+		switch obj.Op {
+		case "", OpLT, OpLTE, OpGT, OpGTE:
+		default:
+			return nil, fmt.Errorf("objectives[%d].op %q must be one of lt, lte, gt, gte", i, obj.Op)
+		}
+		if _, ok := doc["value"]; ok && obj.Threshold <= 0 {
+			return nil, fmt.Errorf("objectives[%d].value must be positive", i)
+		}
+		out = append(out, obj)
+	}
+	return out, nil
+}
+
+// resolveObjectiveThresholds fills each objective's threshold from the metric source, or rejects
+// value and op when the indicator has no threshold to compare against.
+func resolveObjectiveThresholds(rt *Runtime) error {
+	compared := rt.DatasourceType != "prometheus" && rt.Type != TypeRatio && rt.Type != TypeComposite &&
+		(rt.Type == TypeLatency || rt.BudgetingMethod == sli.BudgetingTimeslices)
+	for i := range rt.Objectives {
+		obj := &rt.Objectives[i]
+		if !compared {
+			if obj.Threshold > 0 || obj.Op != "" {
+				return fmt.Errorf("objective value and op only apply to ClickHouse latency and Timeslices indicators")
+			}
+			continue
+		}
+		if obj.Threshold <= 0 {
+			obj.Threshold = rt.Threshold
+		}
+		if obj.Threshold <= 0 {
+			return fmt.Errorf("indicator metricSource.spec.threshold or objectives[%d].value must be positive for %s %s indicators", i, rt.BudgetingMethod, rt.Type)
+		}
+	}
+	return nil
+}
+
+func objectivesToAny(objectives []Objective) []any {
+	out := make([]any, 0, len(objectives))
+	for _, obj := range objectives {
+		m := map[string]any{"name": obj.Name, "target": obj.Target}
+		if obj.Threshold > 0 {
+			m["threshold"] = obj.Threshold
+		}
+		if obj.Op != "" {
+			m["op"] = obj.Op
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// objectivesFromAny reads canonical objectives. Canonical JSON written before objectives were
+// modelled has none; its single objective is the SLO target and threshold.
+func objectivesFromAny(v any, rt Runtime) []Objective {
+	items, _ := v.([]any)
+	out := make([]Objective, 0, len(items))
+	for _, item := range items {
+		m, _ := item.(map[string]any)
+		out = append(out, Objective{
+			Name:      toString(m["name"]),
+			Target:    toFloat32(m["target"]),
+			Threshold: toFloat32(m["threshold"]),
+			Op:        toString(m["op"]),
+		})
+	}
+	if len(out) == 0 {
+		out = append(out, Objective{Target: rt.Target, Threshold: rt.Threshold})
+	}
+	return out
+}
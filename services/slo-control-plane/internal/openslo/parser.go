@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
 	openslov1 "github.com/thisisibrahimd/openslo-go/pkg/openslo/v1"
 	"gopkg.in/yaml.v3"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
 )
 
 type Runtime struct {
@@ -22,8 +25,48 @@ type Runtime struct {
 	Threshold      float32
 	DatasourceType string
 	DatasourceUID  string
+	DatasourceURL  string
+	GoodQuery      string
+	TotalQuery     string
+	ServiceName    string
+	SpanKind       string
+	SpanName       string
+	Attributes     []sli.AttributeMatcher
+	BadQuery       string
+	Good           *sli.SpanSelector
+	Bad            *sli.SpanSelector
+	Total          *sli.SpanSelector
+	// BudgetingMethod is sli.BudgetingOccurrences or sli.BudgetingTimeslices; slice fields apply to the latter.
+	BudgetingMethod   string
+	TimeSliceMinutes  int
+	TimeSliceQuantile float64
+	// Calendar is set for calendar-aligned windows; nil means rolling over WindowMinutes.
+	Calendar *CalendarWindow
+	// BurnRateTiers come from the SLO's AlertPolicies; empty means the evaluator's global fast/slow tiers.
+	BurnRateTiers []BurnRateTier
+	// MinEvents and MinSliceEvents override the evaluator's minimum-traffic defaults when positive.
+	MinEvents      int
+	MinSliceEvents int
+	// GroupBy evaluates the SLO once per combination of these span attributes or Prometheus labels.
+	GroupBy []string
+	// Composite is set for SLOs measured from other SLOs instead of an indicator.
+	Composite *Composite
+	// Objectives are all of the SLO's targets, each evaluated with its own burn state. Target is the
+	// first objective's.
+	Objectives []Objective
 }
 
+const (
+	defaultTimeSliceMinutes  = 1
+	defaultTimeSliceQuantile = 0.99
+)
+
+const (
+	TypeLatency   = "latency"
+	TypeErrorRate = "error_rate"
+	TypeRatio     = "ratio"
+)
+
 type Object struct {
 	Kind string
 	Name string
@@ -39,14 +82,18 @@ type Bundle struct {
 type datasourceDef struct {
 	Type string
 	UID  string
+	URL  string
 }
 
 func ParseBundle(raw string) (Bundle, error) {
 	dec := yaml.NewDecoder(strings.NewReader(raw))
 	objects := make([]Object, 0, 8)
 	datasources := map[string]datasourceDef{}
+	alertPolicies := map[string]map[string]any{}
+	alertConditions := map[string]map[string]any{}
 
 	var slo *openslov1.Slo
+	var sloDoc map[string]any
 	for {
 		doc := map[string]any{}
 		err := dec.Decode(&doc)
@@ -76,6 +123,7 @@ func ParseBundle(raw string) (Bundle, error) {
 				return Bundle{}, fmt.Errorf("invalid SLO object: %w", err)
 			}
 			slo = &parsed
+			sloDoc = doc
 		case "SLI":
 			var parsed openslov1.Sli
 			if err := json.Unmarshal(rawJSON, &parsed); err != nil {
@@ -96,19 +144,22 @@ func ParseBundle(raw string) (Bundle, error) {
 			}
 			conn, _ := spec["connectionDetails"].(map[string]any)
 			datasources[dsName] = datasourceDef{
-				Type: strings.TrimSpace(toString(spec["type"])),
+				Type: strings.ToLower(toString(spec["type"])),
 				UID:  extractDatasourceUID(conn),
+				URL:  extractDatasourceURL(conn),
 			}
 		case "AlertPolicy":
 			var parsed openslov1.AlertPolicy
 			if err := json.Unmarshal(rawJSON, &parsed); err != nil {
 				return Bundle{}, fmt.Errorf("invalid AlertPolicy object: %w", err)
 			}
+			alertPolicies[name] = doc
 		case "AlertCondition":
 			var parsed openslov1.AlertCondition
 			if err := json.Unmarshal(rawJSON, &parsed); err != nil {
 				return Bundle{}, fmt.Errorf("invalid AlertCondition object: %w", err)
 			}
+			alertConditions[name] = doc
 		case "AlertNotificationTarget":
 			var parsed openslov1.AlertNotificationTarget
 			if err := json.Unmarshal(rawJSON, &parsed); err != nil {
@@ -127,7 +178,11 @@ func ParseBundle(raw string) (Bundle, error) {
 	if slo == nil {
 		return Bundle{}, fmt.Errorf("bundle must include exactly one SLO object")
 	}
-	rt, err := compileRuntime(*slo, datasources)
+	rt, err := compileRuntime(*slo, sloDoc, datasources)
+	if err != nil {
+		return Bundle{}, err
+	}
+	rt.BurnRateTiers, err = compileBurnRateTiers(sloDoc, alertPolicies, alertConditions)
 	if err != nil {
 		return Bundle{}, err
 	}
@@ -138,7 +193,7 @@ func ParseBundle(raw string) (Bundle, error) {
 	}, nil
 }
 
-func compileRuntime(slo openslov1.Slo, datasources map[string]datasourceDef) (Runtime, error) {
+func compileRuntime(slo openslov1.Slo, sloDoc map[string]any, datasources map[string]datasourceDef) (Runtime, error) {
 	rt := Runtime{
 		Name: strings.TrimSpace(slo.Metadata.Name),
 		Type: "latency",
@@ -152,16 +207,20 @@ func compileRuntime(slo openslov1.Slo, datasources map[string]datasourceDef) (Ru
 	if slo.Spec.Description != nil {
 		rt.Description = strings.TrimSpace(*slo.Spec.Description)
 	}
-	if len(slo.Spec.Objectives) == 0 || slo.Spec.Objectives[0].Target == nil {
-		return Runtime{}, fmt.Errorf("slo objective target is required")
-	}
-	rt.Target = *slo.Spec.Objectives[0].Target
-	if rt.Target <= 0 || rt.Target >= 1 {
-		return Runtime{}, fmt.Errorf("slo target must be between 0 and 1")
+	objectives, err := parseObjectives(sloDoc)
+	if err != nil {
+		return Runtime{}, err
 	}
+	rt.Objectives = objectives
+	rt.Target = objectives[0].Target
 
 	rt.WindowMinutes = 30
-	if len(slo.Spec.TimeWindow) > 0 && strings.TrimSpace(slo.Spec.TimeWindow[0].Duration) != "" {
+	if cal, ok, err := calendarWindowDoc(sloDoc); err != nil {
+		return Runtime{}, err
+	} else if ok {
+		rt.Calendar = &cal
+		rt.WindowMinutes = cal.NominalMinutes()
+	} else if len(slo.Spec.TimeWindow) > 0 && strings.TrimSpace(slo.Spec.TimeWindow[0].Duration) != "" {
 		minutes, err := durationToMinutes(slo.Spec.TimeWindow[0].Duration)
 		if err != nil {
 			return Runtime{}, err
@@ -172,17 +231,59 @@ func compileRuntime(slo openslov1.Slo, datasources map[string]datasourceDef) (Ru
 	if slo.Metadata.Annotations != nil {
 		ann := *slo.Metadata.Annotations
 		rt.UserExperience = strings.TrimSpace(ann["heatmap.local/userExperience"])
+		var err error
+		if rt.MinEvents, err = positiveIntAnnotation(ann, "heatmap.local/minEvents"); err != nil {
+			return Runtime{}, err
+		}
+		if rt.MinSliceEvents, err = positiveIntAnnotation(ann, "heatmap.local/minSliceEvents"); err != nil {
+			return Runtime{}, err
+		}
 	}
 
+	if err := compileBudgeting(&rt, sloDoc); err != nil {
+		return Runtime{}, err
+	}
+	if doc, ok := compositeDoc(sloDoc); ok {
+		if slo.Spec.Indicator != nil {
+			return Runtime{}, fmt.Errorf("composite SLOs must not define an indicator")
+		}
+		if err := compileComposite(&rt, doc); err != nil {
+			return Runtime{}, err
+		}
+		return rt, resolveObjectiveThresholds(&rt)
+	}
+	if ratio := ratioMetricDoc(sloDoc); ratio != nil {
+		if rt.BudgetingMethod == sli.BudgetingTimeslices {
+			return Runtime{}, fmt.Errorf("budgetingMethod Timeslices is not supported for ratioMetric indicators")
+		}
+		if err := compileRatioMetric(&rt, ratio, slo.Spec.Service, datasources); err != nil {
+			return Runtime{}, err
+		}
+		return rt, resolveObjectiveThresholds(&rt)
+	}
 	if slo.Spec.Indicator == nil || slo.Spec.Indicator.Spec == nil || slo.Spec.Indicator.Spec.ThresholdMetric == nil || slo.Spec.Indicator.Spec.ThresholdMetric.MetricSource == nil {
-		return Runtime{}, fmt.Errorf("slo indicator.thresholdMetric.metricSource is required")
+		return Runtime{}, fmt.Errorf("slo indicator.thresholdMetric.metricSource or indicator.ratioMetric is required")
 	}
 	spec := slo.Spec.Indicator.Spec.ThresholdMetric.MetricSource.Spec
-	rt.Route = toString(spec["route"])
 	rt.Type = toString(spec["type"])
 	rt.Threshold = toFloat32(spec["threshold"])
 	rt.DatasourceUID = toString(spec["datasourceUid"])
-	rt.DatasourceType = toString(spec["datasourceType"])
+	rt.DatasourceType = strings.ToLower(toString(spec["datasourceType"]))
+	rt.DatasourceURL = toString(spec["datasourceUrl"])
+	rt.GoodQuery = toString(spec["goodQuery"])
+	rt.TotalQuery = toString(spec["totalQuery"])
+	sel, err := parseSpanSelector(spec, slo.Spec.Service)
+	if err != nil {
+		return Runtime{}, err
+	}
+	rt.Route = sel.Route
+	rt.ServiceName = sel.ServiceName
+	rt.SpanKind = sel.SpanKind
+	rt.SpanName = sel.SpanName
+	rt.Attributes = sel.Attributes
+	if rt.GroupBy, err = parseGroupBy(spec["groupBy"]); err != nil {
+		return Runtime{}, err
+	}
 	if ref := strings.TrimSpace(slo.Spec.Indicator.Spec.ThresholdMetric.MetricSource.GetMetricSourceRef()); ref != "" {
 		if ds, ok := datasources[ref]; ok {
 			if rt.DatasourceType == "" {
@@ -191,17 +292,366 @@ func compileRuntime(slo openslov1.Slo, datasources map[string]datasourceDef) (Ru
 			if rt.DatasourceUID == "" {
 				rt.DatasourceUID = ds.UID
 			}
+			if rt.DatasourceURL == "" {
+				rt.DatasourceURL = ds.URL
+			}
 		}
 	}
-	if rt.Route == "" || rt.Type == "" || rt.Threshold <= 0 || rt.DatasourceUID == "" || rt.DatasourceType == "" {
-		return Runtime{}, fmt.Errorf("indicator metricSource.spec must include route,type,threshold,datasourceUid,datasourceType")
+	if rt.DatasourceType == "prometheus" {
+		if rt.Type == "" || rt.GoodQuery == "" || rt.TotalQuery == "" || rt.DatasourceUID == "" {
+			return Runtime{}, fmt.Errorf("prometheus indicator metricSource.spec must include type,goodQuery,totalQuery,datasourceUid")
+		}
+	} else {
+		if rt.Type == "" || rt.DatasourceUID == "" || rt.DatasourceType == "" {
+			return Runtime{}, fmt.Errorf("indicator metricSource.spec must include type,threshold,datasourceUid,datasourceType")
+		}
+		if rt.Route == "" && rt.SpanName == "" && len(rt.Attributes) == 0 {
+			return Runtime{}, fmt.Errorf("indicator metricSource.spec must include route, spanName or attributes to select spans")
+		}
 	}
-	if rt.Type != "latency" && rt.Type != "error_rate" {
+	if rt.Type != TypeLatency && rt.Type != TypeErrorRate {
 		return Runtime{}, fmt.Errorf("unsupported indicator type %q", rt.Type)
 	}
+	if err := validateGroupBy(rt.GroupBy, rt.DatasourceType); err != nil {
+		return Runtime{}, err
+	}
+	if rt.BudgetingMethod == sli.BudgetingTimeslices {
+		if rt.DatasourceType == "prometheus" {
+			return Runtime{}, fmt.Errorf("budgetingMethod Timeslices is not supported for prometheus indicators")
+		}
+		if q := toFloat64(spec["quantile"]); q != 0 {
+			rt.TimeSliceQuantile = q
+		}
+		if rt.TimeSliceQuantile <= 0 || rt.TimeSliceQuantile >= 1 {
+			return Runtime{}, fmt.Errorf("indicator metricSource.spec.quantile must be between 0 and 1")
+		}
+	}
+	if err := resolveObjectiveThresholds(&rt); err != nil {
+		return Runtime{}, err
+	}
+	if rt.Threshold <= 0 {
+		rt.Threshold = rt.Objectives[0].Threshold
+	}
 	return rt, nil
 }
 
+func positiveIntAnnotation(ann map[string]string, key string) (int, error) {
+	raw := strings.TrimSpace(ann[key])
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("annotation %s must be a positive integer, got %q", key, raw)
+	}
+	return n, nil
+}
+
+func compileBudgeting(rt *Runtime, sloDoc map[string]any) error {
+	spec, _ := sloDoc["spec"].(map[string]any)
+	switch method := toString(spec["budgetingMethod"]); strings.ToLower(method) {
+	case "", "occurrences":
+		rt.BudgetingMethod = sli.BudgetingOccurrences
+		return nil
+	case "timeslices":
+		rt.BudgetingMethod = sli.BudgetingTimeslices
+	default:
+		return fmt.Errorf("unsupported budgetingMethod %q", method)
+	}
+	rt.TimeSliceMinutes = defaultTimeSliceMinutes
+	rt.TimeSliceQuantile = defaultTimeSliceQuantile
+	objectives, _ := spec["objectives"].([]any)
+	if len(objectives) > 0 {
+		obj, _ := objectives[0].(map[string]any)
+		if raw := toString(obj["timeSliceWindow"]); raw != "" {
+			minutes, err := durationToMinutes(raw)
+			if err != nil {
+				return fmt.Errorf("objective timeSliceWindow: %w", err)
+			}
+			rt.TimeSliceMinutes = minutes
+		}
+	}
+	if rt.TimeSliceMinutes > rt.WindowMinutes {
+		return fmt.Errorf("objective timeSliceWindow must not exceed the SLO time window")
+	}
+	return nil
+}
+
+// calendarWindowDoc reports whether timeWindow[0] is calendar aligned (isRolling: false or a calendar block).
+func calendarWindowDoc(sloDoc map[string]any) (CalendarWindow, bool, error) {
+	spec, _ := sloDoc["spec"].(map[string]any)
+	windows, _ := spec["timeWindow"].([]any)
+	if len(windows) == 0 {
+		return CalendarWindow{}, false, nil
+	}
+	w, _ := windows[0].(map[string]any)
+	calendar, hasCalendar := w["calendar"].(map[string]any)
+	rolling, hasRolling := w["isRolling"].(bool)
+	if (hasRolling && rolling) || (!hasRolling && !hasCalendar) {
+		return CalendarWindow{}, false, nil
+	}
+	cal, err := parseCalendarWindow(toString(w["duration"]), calendar)
+	if err != nil {
+		return CalendarWindow{}, false, err
+	}
+	return cal, true, nil
+}
+
+func ratioMetricDoc(sloDoc map[string]any) map[string]any {
+	spec, _ := sloDoc["spec"].(map[string]any)
+	indicator, _ := spec["indicator"].(map[string]any)
+	indicatorSpec, _ := indicator["spec"].(map[string]any)
+	ratio, _ := indicatorSpec["ratioMetric"].(map[string]any)
+	return ratio
+}
+
+// compileRatioMetric compiles each of ratioMetric good/bad/total into its own query: PromQL for
+// Prometheus sources, a span selector for ClickHouse sources.
+func compileRatioMetric(rt *Runtime, ratio map[string]any, defaultService string, datasources map[string]datasourceDef) error {
+	sources := map[string]map[string]any{}
+	for _, role := range []string{"good", "bad", "total"} {
+		entry, ok := ratio[role].(map[string]any)
+		if !ok {
+			continue
+		}
+		ms, ok := entry["metricSource"].(map[string]any)
+		if !ok {
+			return fmt.Errorf("indicator ratioMetric.%s.metricSource is required", role)
+		}
+		sources[role] = ms
+	}
+	_, hasGood := sources["good"]
+	_, hasBad := sources["bad"]
+	if sources["total"] == nil || hasGood == hasBad {
+		return fmt.Errorf("indicator ratioMetric must include total and exactly one of good or bad")
+	}
+	rt.Type = TypeRatio
+	rt.Threshold = 0
+
+	specs := map[string]map[string]any{}
+	for _, role := range []string{"total", "good", "bad"} {
+		ms, ok := sources[role]
+		if !ok {
+			continue
+		}
+		spec, _ := ms["spec"].(map[string]any)
+		if spec == nil {
+			spec = map[string]any{}
+		}
+		specs[role] = spec
+		groupBy, err := parseGroupBy(spec["groupBy"])
+		if err != nil {
+			return fmt.Errorf("ratioMetric.%s: %w", role, err)
+		}
+		if role == "total" {
+			rt.GroupBy = groupBy
+		} else if strings.Join(groupBy, ",") != strings.Join(rt.GroupBy, ",") {
+			return fmt.Errorf("indicator ratioMetric.%s.metricSource.spec.groupBy must match total", role)
+		}
+		ds := datasourceDef{
+			Type: strings.ToLower(toString(spec["datasourceType"])),
+			UID:  toString(spec["datasourceUid"]),
+			URL:  toString(spec["datasourceUrl"]),
+		}
+		if ref, ok := datasources[toString(ms["metricSourceRef"])]; ok {
+			if ds.Type == "" {
+				ds.Type = ref.Type
+			}
+			if ds.UID == "" {
+				ds.UID = ref.UID
+			}
+			if ds.URL == "" {
+				ds.URL = ref.URL
+			}
+		}
+		if rt.DatasourceType == "" {
+			rt.DatasourceType = ds.Type
+		} else if ds.Type != "" && ds.Type != rt.DatasourceType {
+			return fmt.Errorf("indicator ratioMetric sources must share one datasource type, got %q and %q", rt.DatasourceType, ds.Type)
+		}
+		if rt.DatasourceUID == "" {
+			rt.DatasourceUID = ds.UID
+		}
+		if rt.DatasourceURL == "" {
+			rt.DatasourceURL = ds.URL
+		}
+	}
+	if rt.DatasourceType == "" || rt.DatasourceUID == "" {
+		return fmt.Errorf("indicator ratioMetric metricSource must resolve datasourceType and datasourceUid")
+	}
+	if err := validateGroupBy(rt.GroupBy, rt.DatasourceType); err != nil {
+		return err
+	}
+
+	for role, spec := range specs {
+		if rt.DatasourceType == "prometheus" {
+			query := toString(spec["query"])
+			if query == "" {
+				return fmt.Errorf("prometheus ratioMetric.%s.metricSource.spec.query is required", role)
+			}
+			switch role {
+			case "good":
+				rt.GoodQuery = query
+			case "bad":
+				rt.BadQuery = query
+			case "total":
+				rt.TotalQuery = query
+			}
+			continue
+		}
+		sel, err := parseSpanSelector(spec, defaultService)
+		if err != nil {
+			return fmt.Errorf("ratioMetric.%s: %w", role, err)
+		}
+		switch role {
+		case "good":
+			rt.Good = &sel
+		case "bad":
+			rt.Bad = &sel
+		case "total":
+			rt.Total = &sel
+		}
+	}
+	return nil
+}
+
+func parseSpanSelector(spec map[string]any, defaultService string) (sli.SpanSelector, error) {
+	sel := sli.SpanSelector{
+		Route:       toString(spec["route"]),
+		ServiceName: toString(spec["serviceName"]),
+		SpanName:    toString(spec["spanName"]),
+	}
+	if sel.ServiceName == "" {
+		sel.ServiceName = strings.TrimSpace(defaultService)
+	}
+	kind, err := normalizeSpanKind(toString(spec["spanKind"]))
+	if err != nil {
+		return sli.SpanSelector{}, err
+	}
+	sel.SpanKind = kind
+	sel.Attributes, err = parseAttributeMatchers(spec["attributes"])
+	if err != nil {
+		return sli.SpanSelector{}, err
+	}
+	return sel, nil
+}
+
+// parseGroupBy reads a metricSource's groupBy list of attribute keys.
+func parseGroupBy(v any) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	items, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("metricSource.spec.groupBy must be a list of keys")
+	}
+	seen := map[string]bool{}
+	out := make([]string, 0, len(items))
+	for i, item := range items {
+		key := strings.TrimSpace(toString(item))
+		if key == "" {
+			return nil, fmt.Errorf("groupBy[%d] must be a non-empty string", i)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("groupBy key %q is repeated", key)
+		}
+		seen[key] = true
+		out = append(out, key)
+	}
+	return out, nil
+}
+
+var promLabelPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateGroupBy checks group-by keys against what the datasource can group on: span attribute
+// keys for ClickHouse, label names for Prometheus.
+func validateGroupBy(keys []string, datasourceType string) error {
+	for _, key := range keys {
+		if datasourceType == "prometheus" {
+			if !promLabelPattern.MatchString(key) {
+				return fmt.Errorf("groupBy key %q is not a valid prometheus label name", key)
+			}
+			continue
+		}
+		if err := sli.ValidateAttributeKey(key); err != nil {
+			return fmt.Errorf("groupBy: %w", err)
+		}
+	}
+	return nil
+}
+
+func normalizeSpanKind(raw string) (string, error) {
+	kind := strings.ToLower(strings.TrimPrefix(strings.ToUpper(raw), "SPAN_KIND_"))
+	switch kind {
+	case "", "server", "client", "internal", "producer", "consumer":
+		return kind, nil
+	default:
+		return "", fmt.Errorf("unsupported spanKind %q", raw)
+	}
+}
+
+func parseAttributeMatchers(v any) ([]sli.AttributeMatcher, error) {
+	if v == nil {
+		return nil, nil
+	}
+	items, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("indicator metricSource.spec.attributes must be a list")
+	}
+	out := make([]sli.AttributeMatcher, 0, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("attributes[%d] must be an object", i)
+		}
+		am := sli.AttributeMatcher{
+			Key:    toString(m["key"]),
+			Op:     strings.ToLower(toString(m["op"])),
+			Values: toStringSlice(m["values"]),
+		}
+		if am.Op == "" {
+			am.Op = sli.MatchEquals
+		}
+		if single := toStringSlice([]any{m["value"]}); len(single) == 1 {
+			am.Values = append(single, am.Values...)
+		}
+		if err := validateAttributeMatcher(am); err != nil {
+			return nil, fmt.Errorf("attributes[%d]: %w", i, err)
+		}
+		out = append(out, am)
+	}
+	return out, nil
+}
+
+func validateAttributeMatcher(am sli.AttributeMatcher) error {
+	if am.Key == "" {
+		return fmt.Errorf("key is required")
+	}
+	if err := sli.ValidateAttributeKey(am.Key); err != nil {
+		return err
+	}
+	if len(am.Values) == 0 {
+		return fmt.Errorf("value is required for %q", am.Key)
+	}
+	switch am.Op {
+	case sli.MatchEquals, sli.MatchNotEquals:
+		if len(am.Values) != 1 {
+			return fmt.Errorf("op %s takes exactly one value", am.Op)
+		}
+	case sli.MatchIn:
+	case sli.MatchRegex:
+		if len(am.Values) != 1 {
+			return fmt.Errorf("op %s takes exactly one value", am.Op)
+		}
+		if _, err := regexp.Compile(am.Values[0]); err != nil {
+			return fmt.Errorf("invalid regex for %q: %w", am.Key, err)
+		}
+	default:
+		return fmt.Errorf("unsupported op %q (want equals, not-equals, in, regex)", am.Op)
+	}
+	return nil
+}
+
 func extractDatasourceUID(connectionDetails map[string]interface{}) string {
 	if len(connectionDetails) == 0 {
 		return ""
@@ -215,6 +665,15 @@ func extractDatasourceUID(connectionDetails map[string]interface{}) string {
 	return ""
 }
 
+func extractDatasourceURL(connectionDetails map[string]interface{}) string {
+	for _, key := range []string{"url", "address", "endpoint"} {
+		if v := toString(connectionDetails[key]); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 func metadataName(doc map[string]any) string {
 	md, ok := doc["metadata"].(map[string]any)
 	if !ok {
@@ -231,6 +690,35 @@ func toString(v any) string {
 	return ""
 }
 
+func toStringSlice(v any) []string {
+	items, ok := v.([]any)
+	if !ok {
+		return nil
+	}
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		switch x := item.(type) {
+		case string:
+			out = append(out, x)
+		case float64, int, bool:
+			out = append(out, fmt.Sprint(x))
+		}
+	}
+	return out
+}
+
+func toFloat64(v any) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case string:
+		f, _ := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f
+	default:
+		return float64(toFloat32(v))
+	}
+}
+
 func toFloat32(v any) float32 {
 	switch n := v.(type) {
 	case float64:
@@ -265,6 +753,8 @@ func durationToMinutes(raw string) (int, error) {
 		return n * 60, nil
 	case 'd':
 		return n * 24 * 60, nil
+	case 'w':
+		return n * 7 * 24 * 60, nil
 	default:
 		return 0, fmt.Errorf("unsupported timeWindow duration unit %q", string(unit))
 	}
@@ -287,9 +777,110 @@ func RuntimeToMap(rt Runtime) map[string]any {
 	if rt.UserExperience != "" {
 		m["userExperience"] = rt.UserExperience
 	}
+	if rt.DatasourceURL != "" {
+		m["datasourceUrl"] = rt.DatasourceURL
+	}
+	if rt.GoodQuery != "" {
+		m["goodQuery"] = rt.GoodQuery
+	}
+	if rt.TotalQuery != "" {
+		m["totalQuery"] = rt.TotalQuery
+	}
+	if rt.ServiceName != "" {
+		m["serviceName"] = rt.ServiceName
+	}
+	if rt.SpanKind != "" {
+		m["spanKind"] = rt.SpanKind
+	}
+	if rt.SpanName != "" {
+		m["spanName"] = rt.SpanName
+	}
+	if len(rt.Attributes) > 0 {
+		m["attributes"] = attributeMatchersToAny(rt.Attributes)
+	}
+	if rt.BadQuery != "" {
+		m["badQuery"] = rt.BadQuery
+	}
+	if rt.BudgetingMethod != "" {
+		m["budgetingMethod"] = rt.BudgetingMethod
+	}
+	if rt.BudgetingMethod == sli.BudgetingTimeslices {
+		m["timeSliceMinutes"] = rt.TimeSliceMinutes
+		m["timeSliceQuantile"] = rt.TimeSliceQuantile
+	}
+	if rt.Calendar != nil {
+		cal := map[string]any{
+			"unit":     rt.Calendar.Unit,
+			"count":    rt.Calendar.Count,
+			"timeZone": rt.Calendar.TimeZone,
+		}
+		if rt.Calendar.StartTime != "" {
+			cal["startTime"] = rt.Calendar.StartTime
+		}
+		m["calendar"] = cal
+	}
+	if len(rt.BurnRateTiers) > 0 {
+		m["burnRateTiers"] = burnRateTiersToAny(rt.BurnRateTiers)
+	}
+	if rt.MinEvents > 0 {
+		m["minEvents"] = rt.MinEvents
+	}
+	if rt.MinSliceEvents > 0 {
+		m["minSliceEvents"] = rt.MinSliceEvents
+	}
+	if len(rt.GroupBy) > 0 {
+		groupBy := make([]any, 0, len(rt.GroupBy))
+		for _, k := range rt.GroupBy {
+			groupBy = append(groupBy, k)
+		}
+		m["groupBy"] = groupBy
+	}
+	if rt.Composite != nil {
+		m["composite"] = compositeToMap(*rt.Composite)
+	}
+	if len(rt.Objectives) > 0 {
+		m["objectives"] = objectivesToAny(rt.Objectives)
+	}
+	for key, sel := range map[string]*sli.SpanSelector{"good": rt.Good, "bad": rt.Bad, "total": rt.Total} {
+		if sel != nil {
+			m[key] = spanSelectorToMap(*sel)
+		}
+	}
+	return m
+}
+
+func spanSelectorToMap(sel sli.SpanSelector) map[string]any {
+	m := map[string]any{}
+	if sel.Route != "" {
+		m["route"] = sel.Route
+	}
+	if sel.ServiceName != "" {
+		m["serviceName"] = sel.ServiceName
+	}
+	if sel.SpanKind != "" {
+		m["spanKind"] = sel.SpanKind
+	}
+	if sel.SpanName != "" {
+		m["spanName"] = sel.SpanName
+	}
+	if len(sel.Attributes) > 0 {
+		m["attributes"] = attributeMatchersToAny(sel.Attributes)
+	}
 	return m
 }
 
+func attributeMatchersToAny(in []sli.AttributeMatcher) []any {
+	out := make([]any, 0, len(in))
+	for _, am := range in {
+		values := make([]any, 0, len(am.Values))
+		for _, v := range am.Values {
+			values = append(values, v)
+		}
+		out = append(out, map[string]any{"key": am.Key, "op": am.Op, "values": values})
+	}
+	return out
+}
+
 func MapToRuntime(v map[string]any) Runtime {
 	rt := Runtime{
 		Name:           toString(v["name"]),
@@ -302,13 +893,56 @@ func MapToRuntime(v map[string]any) Runtime {
 		Threshold:      toFloat32(v["threshold"]),
 		DatasourceType: toString(v["datasourceType"]),
 		DatasourceUID:  toString(v["datasourceUid"]),
+		DatasourceURL:  toString(v["datasourceUrl"]),
+		GoodQuery:      toString(v["goodQuery"]),
+		TotalQuery:     toString(v["totalQuery"]),
+		ServiceName:    toString(v["serviceName"]),
+		SpanKind:       toString(v["spanKind"]),
+		SpanName:       toString(v["spanName"]),
+	}
+	rt.Attributes, _ = parseAttributeMatchers(v["attributes"])
+	rt.BadQuery = toString(v["badQuery"])
+	rt.BudgetingMethod = toString(v["budgetingMethod"])
+	rt.TimeSliceMinutes = int(toFloat32(v["timeSliceMinutes"]))
+	rt.TimeSliceQuantile = toFloat64(v["timeSliceQuantile"])
+	if cal, ok := v["calendar"].(map[string]any); ok {
+		rt.Calendar = &CalendarWindow{
+			Unit:      toString(cal["unit"]),
+			Count:     int(toFloat32(cal["count"])),
+			StartTime: toString(cal["startTime"]),
+			TimeZone:  toString(cal["timeZone"]),
+		}
 	}
+	rt.BurnRateTiers = burnRateTiersFromAny(v["burnRateTiers"])
+	rt.MinEvents = int(toFloat32(v["minEvents"]))
+	rt.MinSliceEvents = int(toFloat32(v["minSliceEvents"]))
+	rt.GroupBy = toStringSlice(v["groupBy"])
+	rt.Good = mapToSpanSelector(v["good"])
+	rt.Bad = mapToSpanSelector(v["bad"])
+	rt.Total = mapToSpanSelector(v["total"])
+	rt.Composite = compositeFromAny(v["composite"])
+	rt.Objectives = objectivesFromAny(v["objectives"], rt)
 	if rt.WindowMinutes <= 0 {
 		rt.WindowMinutes = 30
 	}
 	return rt
 }
 
+func mapToSpanSelector(v any) *sli.SpanSelector {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	sel := sli.SpanSelector{
+		Route:       toString(m["route"]),
+		ServiceName: toString(m["serviceName"]),
+		SpanKind:    toString(m["spanKind"]),
+		SpanName:    toString(m["spanName"]),
+	}
+	sel.Attributes, _ = parseAttributeMatchers(m["attributes"])
+	return &sel
+}
+
 func MarshalObjectsJSON(objects []Object) ([]byte, error) {
 	type outObj struct {
 		Kind string          `json:"kind"`
@@ -1,6 +1,12 @@
 package openslo
 
-import "testing"
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/sli"
+)
 
 func TestParseBundleCompilesRuntime(t *testing.T) {
 	raw := `apiVersion: openslo/v1
@@ -130,3 +136,816 @@ spec:
 		t.Fatalf("expected datasource uid from DataSource connectionDetails.uid, got %q", bundle.Runtime.DatasourceUID)
 	}
 }
+
+func TestParseBundlePrometheusGoodTotalQueries(t *testing.T) {
+	raw := `apiVersion: openslo/v1
+kind: DataSource
+metadata:
+  name: prometheus-ds
+spec:
+  type: Prometheus
+  connectionDetails:
+    uid: prometheus
+    url: http://prometheus:9090
+---
+apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: checkout-availability
+spec:
+  service: api-gateway
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.995
+  indicator:
+    metadata:
+      name: checkout-availability-indicator
+    spec:
+      thresholdMetric:
+        metricSource:
+          metricSourceRef: prometheus-ds
+          spec:
+            type: error_rate
+            goodQuery: sum(increase(http_requests_total{route="/cart/checkout",code!~"5.."}[{{.window}}]))
+            totalQuery: sum(increase(http_requests_total{route="/cart/checkout"}[{{.window}}]))
+`
+	bundle, err := ParseBundle(raw)
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	rt := bundle.Runtime
+	if rt.DatasourceType != "prometheus" || rt.DatasourceUID != "prometheus" {
+		t.Fatalf("unexpected datasource fields: uid=%s type=%s", rt.DatasourceUID, rt.DatasourceType)
+	}
+	if rt.DatasourceURL != "http://prometheus:9090" {
+		t.Fatalf("expected datasource url from DataSource connectionDetails.url, got %q", rt.DatasourceURL)
+	}
+	if rt.GoodQuery == "" || rt.TotalQuery == "" {
+		t.Fatalf("expected good/total queries, got %q / %q", rt.GoodQuery, rt.TotalQuery)
+	}
+	back := MapToRuntime(RuntimeToMap(rt))
+	if back.GoodQuery != rt.GoodQuery || back.TotalQuery != rt.TotalQuery || back.DatasourceURL != rt.DatasourceURL {
+		t.Fatalf("prometheus fields lost in canonical round trip: %#v", back)
+	}
+}
+
+func TestParseBundlePrometheusRequiresQueries(t *testing.T) {
+	raw := `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: broken-prom
+spec:
+  service: api-gateway
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.99
+  indicator:
+    metadata:
+      name: i
+    spec:
+      thresholdMetric:
+        metricSource:
+          spec:
+            type: error_rate
+            datasourceUid: prometheus
+            datasourceType: prometheus
+            goodQuery: sum(up)
+`
+	if _, err := ParseBundle(raw); err == nil {
+		t.Fatalf("expected error when totalQuery is missing")
+	}
+}
+
+func TestParseBundleSpanFilters(t *testing.T) {
+	raw := `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: payments-grpc-latency
+spec:
+  service: payment-service
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.99
+  indicator:
+    metadata:
+      name: payments-grpc-latency-indicator
+    spec:
+      thresholdMetric:
+        metricSource:
+          type: clickhouse
+          spec:
+            type: latency
+            threshold: 250
+            datasourceUid: clickhouse
+            datasourceType: clickhouse
+            spanKind: SPAN_KIND_SERVER
+            spanName: payments.v1.Payments/Charge
+            attributes:
+              - key: tenant.id
+                value: acme
+              - key: rpc.grpc.status_code
+                op: in
+                values: ["0", "5"]
+              - key: deployment.environment
+                op: not-equals
+                value: staging
+              - key: net.peer.name
+                op: regex
+                value: ^checkout-.*
+`
+	bundle, err := ParseBundle(raw)
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	rt := bundle.Runtime
+	if rt.ServiceName != "payment-service" {
+		t.Fatalf("expected service name to default to spec.service, got %q", rt.ServiceName)
+	}
+	if rt.SpanKind != "server" || rt.SpanName != "payments.v1.Payments/Charge" {
+		t.Fatalf("unexpected span kind/name: %q %q", rt.SpanKind, rt.SpanName)
+	}
+	if len(rt.Attributes) != 4 {
+		t.Fatalf("expected 4 attribute matchers, got %#v", rt.Attributes)
+	}
+	if rt.Attributes[0].Op != sli.MatchEquals || rt.Attributes[1].Op != sli.MatchIn || len(rt.Attributes[1].Values) != 2 {
+		t.Fatalf("unexpected matchers: %#v", rt.Attributes)
+	}
+	back := MapToRuntime(RuntimeToMap(rt))
+	if back.ServiceName != rt.ServiceName || back.SpanKind != rt.SpanKind || len(back.Attributes) != 4 || back.Attributes[3].Values[0] != "^checkout-.*" {
+		t.Fatalf("span filters lost in canonical round trip: %#v", back)
+	}
+}
+
+func TestParseBundleServiceNameDefaultsToSpecService(t *testing.T) {
+	doc := `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: checkout-latency
+spec:
+  service: migrated-service
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.99
+  indicator:
+    metadata:
+      name: checkout-latency-indicator
+    spec:
+      thresholdMetric:
+        metricSource:
+          type: clickhouse
+          spec:
+            route: /api/checkout
+%s            type: latency
+            threshold: 300
+            datasourceUid: clickhouse
+            datasourceType: clickhouse
+`
+	cases := []struct {
+		serviceName string
+		want        string
+	}{
+		{"", "migrated-service"},
+		{"            serviceName: api-gateway\n", "api-gateway"},
+	}
+	for _, tc := range cases {
+		bundle, err := ParseBundle(fmt.Sprintf(doc, tc.serviceName))
+		if err != nil {
+			t.Fatalf("ParseBundle failed: %v", err)
+		}
+		if bundle.Runtime.ServiceName != tc.want {
+			t.Fatalf("expected service name %q, got %q", tc.want, bundle.Runtime.ServiceName)
+		}
+		if got := MapToRuntime(RuntimeToMap(bundle.Runtime)).ServiceName; got != tc.want {
+			t.Fatalf("expected service name %q after the canonical round trip, got %q", tc.want, got)
+		}
+	}
+}
+
+func TestParseBundleRejectsInvalidAttributeMatcher(t *testing.T) {
+	base := `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: bad-matcher
+spec:
+  service: api-gateway
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.99
+  indicator:
+    metadata:
+      name: i
+    spec:
+      thresholdMetric:
+        metricSource:
+          spec:
+            type: error_rate
+            threshold: 0.01
+            datasourceUid: clickhouse
+            datasourceType: clickhouse
+            attributes:
+`
+	cases := map[string]string{
+		"unknown op":   "              - {key: tenant.id, op: contains, value: acme}\n",
+		"bad regex":    "              - {key: tenant.id, op: regex, value: \"(\"}\n",
+		"missing key":  "              - {op: equals, value: acme}\n",
+		"missing vals": "              - {key: tenant.id, op: in}\n",
+		"hostile key":  "              - {key: \"tenant'] OR 1=1 --\", op: equals, value: acme}\n",
+	}
+	for name, matcher := range cases {
+		if _, err := ParseBundle(base + matcher); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestParseBundleRatioMetricClickHouse(t *testing.T) {
+	raw := `apiVersion: openslo/v1
+kind: DataSource
+metadata:
+  name: traces
+spec:
+  type: clickhouse
+  connectionDetails:
+    uid: clickhouse
+---
+apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: queue-jobs-completed
+spec:
+  service: queue-worker
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.999
+  indicator:
+    metadata:
+      name: queue-jobs-completed-indicator
+    spec:
+      ratioMetric:
+        counter: true
+        good:
+          metricSource:
+            metricSourceRef: traces
+            spec:
+              spanName: job.complete
+        total:
+          metricSource:
+            metricSourceRef: traces
+            spec:
+              spanName: job.enqueue
+`
+	bundle, err := ParseBundle(raw)
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	rt := bundle.Runtime
+	if rt.Type != TypeRatio || rt.DatasourceType != "clickhouse" || rt.DatasourceUID != "clickhouse" {
+		t.Fatalf("unexpected runtime: type=%s ds=%s/%s", rt.Type, rt.DatasourceType, rt.DatasourceUID)
+	}
+	if rt.Good == nil || rt.Good.SpanName != "job.complete" || rt.Good.ServiceName != "queue-worker" {
+		t.Fatalf("unexpected good selector: %#v", rt.Good)
+	}
+	if rt.Total == nil || rt.Total.SpanName != "job.enqueue" || rt.Bad != nil {
+		t.Fatalf("unexpected total/bad selectors: %#v %#v", rt.Total, rt.Bad)
+	}
+	back := MapToRuntime(RuntimeToMap(rt))
+	if back.Good == nil || back.Good.SpanName != "job.complete" || back.Total == nil || back.Bad != nil {
+		t.Fatalf("ratio sources lost in canonical round trip: %#v", back)
+	}
+}
+
+func TestParseBundleRatioMetricPrometheusBad(t *testing.T) {
+	raw := `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: api-availability
+spec:
+  service: api-gateway
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.99
+  indicator:
+    metadata:
+      name: api-availability-indicator
+    spec:
+      ratioMetric:
+        counter: true
+        bad:
+          metricSource:
+            type: prometheus
+            spec:
+              datasourceType: prometheus
+              datasourceUid: prometheus
+              query: sum(increase(http_requests_total{code=~"5.."}[{{.window}}]))
+        total:
+          metricSource:
+            type: prometheus
+            spec:
+              datasourceType: prometheus
+              datasourceUid: prometheus
+              query: sum(increase(http_requests_total[{{.window}}]))
+`
+	bundle, err := ParseBundle(raw)
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	rt := bundle.Runtime
+	if rt.Type != TypeRatio || rt.BadQuery == "" || rt.TotalQuery == "" || rt.GoodQuery != "" {
+		t.Fatalf("unexpected prometheus ratio runtime: %#v", rt)
+	}
+}
+
+func TestParseBundleRatioMetricRequiresTotalAndOneOfGoodBad(t *testing.T) {
+	raw := `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: broken-ratio
+spec:
+  service: api-gateway
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.99
+  indicator:
+    metadata:
+      name: i
+    spec:
+      ratioMetric:
+        good:
+          metricSource:
+            spec: {datasourceType: clickhouse, datasourceUid: clickhouse, spanName: ok}
+`
+	if _, err := ParseBundle(raw); err == nil {
+		t.Fatalf("expected error when total is missing")
+	}
+}
+
+func TestParseBundleBudgetingMethod(t *testing.T) {
+	bundle := func(method, objectiveExtra, specExtra string) string {
+		return `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: checkout-latency
+spec:
+  service: api-gateway
+  budgetingMethod: ` + method + `
+  objectives:
+    - target: 0.99
+` + objectiveExtra + `  timeWindow:
+    - duration: 1h
+      isRolling: true
+  indicator:
+    metadata:
+      name: i
+    spec:
+      thresholdMetric:
+        metricSource:
+          spec:
+            route: /cart/checkout
+            type: latency
+            threshold: 500
+            datasourceUid: clickhouse
+            datasourceType: clickhouse
+` + specExtra
+	}
+
+	b, err := ParseBundle(bundle("Occurrences", "", ""))
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	if b.Runtime.BudgetingMethod != sli.BudgetingOccurrences || b.Runtime.TimeSliceMinutes != 0 {
+		t.Fatalf("unexpected occurrences runtime: %#v", b.Runtime)
+	}
+
+	b, err = ParseBundle(bundle("Timeslices", "      timeSliceWindow: 5m\n", "            quantile: 0.95\n"))
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	if b.Runtime.BudgetingMethod != sli.BudgetingTimeslices || b.Runtime.TimeSliceMinutes != 5 || b.Runtime.TimeSliceQuantile != 0.95 {
+		t.Fatalf("unexpected timeslices runtime: %#v", b.Runtime)
+	}
+	back := MapToRuntime(RuntimeToMap(b.Runtime))
+	if back.BudgetingMethod != sli.BudgetingTimeslices || back.TimeSliceMinutes != 5 || back.TimeSliceQuantile != 0.95 {
+		t.Fatalf("budgeting lost in canonical round trip: %#v", back)
+	}
+
+	b, err = ParseBundle(bundle("Timeslices", "", ""))
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	if b.Runtime.TimeSliceMinutes != 1 || b.Runtime.TimeSliceQuantile != 0.99 {
+		t.Fatalf("expected timeslice defaults, got %#v", b.Runtime)
+	}
+
+	if _, err := ParseBundle(bundle("RatioTimeslices", "", "")); err == nil {
+		t.Fatalf("expected error for unsupported budgeting method")
+	}
+	if _, err := ParseBundle(bundle("Timeslices", "", "            quantile: 1.5\n")); err == nil {
+		t.Fatalf("expected error for out-of-range quantile")
+	}
+}
+
+func TestParseBundleCalendarWindow(t *testing.T) {
+	raw := `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: checkout-monthly
+spec:
+  service: api-gateway
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.999
+  timeWindow:
+    - duration: 1M
+      isRolling: false
+      calendar:
+        startTime: "2024-01-01 00:00:00"
+        timeZone: America/New_York
+  indicator:
+    metadata:
+      name: i
+    spec:
+      thresholdMetric:
+        metricSource:
+          spec:
+            route: /cart/checkout
+            type: error_rate
+            threshold: 0.01
+            datasourceUid: clickhouse
+            datasourceType: clickhouse
+`
+	bundle, err := ParseBundle(raw)
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	cal := bundle.Runtime.Calendar
+	if cal == nil || cal.Unit != CalendarMonth || cal.Count != 1 || cal.TimeZone != "America/New_York" {
+		t.Fatalf("unexpected calendar window: %#v", cal)
+	}
+	if bundle.Runtime.WindowMinutes != 30*24*60 {
+		t.Fatalf("expected nominal month window, got %d", bundle.Runtime.WindowMinutes)
+	}
+	back := MapToRuntime(RuntimeToMap(bundle.Runtime))
+	if back.Calendar == nil || *back.Calendar != *cal {
+		t.Fatalf("calendar lost in canonical round trip: %#v", back.Calendar)
+	}
+}
+
+const alertPolicySLO = `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: checkout-availability
+spec:
+  service: api-gateway
+  objectives:
+    - target: 0.999
+  timeWindow:
+    - duration: 30d
+      isRolling: true
+  alertPolicies:
+    - alertPolicyRef: checkout-burn
+  indicator:
+    metadata:
+      name: checkout-errors
+    spec:
+      thresholdMetric:
+        metricSource:
+          type: clickhouse
+          spec:
+            route: /cart/checkout
+            type: error_rate
+            datasourceUid: clickhouse
+            datasourceType: clickhouse
+---
+apiVersion: openslo/v1
+kind: AlertPolicy
+metadata:
+  name: checkout-burn
+spec:
+  conditions:
+    - conditionRef: ticket-3d
+    - conditionRef: page-1h
+    - kind: AlertCondition
+      metadata:
+        name: page-6h
+      spec:
+        severity: page
+        condition:
+          kind: burnrate
+          op: gte
+          threshold: 6
+          lookbackWindow: 6h
+---
+apiVersion: openslo/v1
+kind: AlertCondition
+metadata:
+  name: page-1h
+spec:
+  severity: page
+  condition:
+    kind: burnrate
+    op: gte
+    threshold: 14.4
+    lookbackWindow: 1h
+    shortWindow: 5m
+---
+apiVersion: openslo/v1
+kind: AlertCondition
+metadata:
+  name: ticket-3d
+spec:
+  severity: ticket
+  condition:
+    kind: burnrate
+    op: gte
+    threshold: 1
+    lookbackWindow: 3d
+`
+
+func TestParseBundleBurnRateTiersFromAlertPolicy(t *testing.T) {
+	bundle, err := ParseBundle(alertPolicySLO)
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	want := []BurnRateTier{
+		{Name: "page-1h", Severity: "page", Threshold: 14.4, LongWindowMinutes: 60, ShortWindowMinutes: 5},
+		{Name: "page-6h", Severity: "page", Threshold: 6, LongWindowMinutes: 360, ShortWindowMinutes: 30},
+		{Name: "ticket-3d", Severity: "ticket", Threshold: 1, LongWindowMinutes: 4320, ShortWindowMinutes: 360},
+	}
+	got := bundle.Runtime.BurnRateTiers
+	if len(got) != len(want) {
+		t.Fatalf("expected %d tiers, got %#v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("tier %d: got %#v want %#v", i, got[i], want[i])
+		}
+	}
+	roundTrip := MapToRuntime(RuntimeToMap(bundle.Runtime)).BurnRateTiers
+	if len(roundTrip) != 3 || roundTrip[0] != want[0] || roundTrip[2] != want[2] {
+		t.Fatalf("burn-rate tiers did not round-trip: %#v", roundTrip)
+	}
+}
+
+func TestParseBundleBurnRateTiersErrors(t *testing.T) {
+	cases := map[string]struct{ from, to string }{
+		"unknown policy":    {"alertPolicyRef: checkout-burn", "alertPolicyRef: missing"},
+		"unknown condition": {"conditionRef: ticket-3d", "conditionRef: missing"},
+		"missing lookback":  {"lookbackWindow: 3d", "alertAfter: 5m"},
+		"short too long":    {"shortWindow: 5m", "shortWindow: 2h"},
+		"non burnrate":      {"kind: burnrate\n    op: gte\n    threshold: 1", "kind: threshold\n    op: gte\n    threshold: 1"},
+	}
+	for name, tc := range cases {
+		raw := strings.Replace(alertPolicySLO, tc.from, tc.to, 1)
+		if raw == alertPolicySLO {
+			t.Fatalf("%s: replacement %q not found", name, tc.from)
+		}
+		if _, err := ParseBundle(raw); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestParseBundleStandaloneAlertConditionIsNotATier(t *testing.T) {
+	raw := strings.Replace(alertPolicySLO, "  alertPolicies:\n    - alertPolicyRef: checkout-burn\n", "", 1)
+	raw = raw[:strings.Index(raw, "---\napiVersion: openslo/v1\nkind: AlertPolicy")] + raw[strings.Index(raw, "---\napiVersion: openslo/v1\nkind: AlertCondition"):]
+	bundle, err := ParseBundle(raw)
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	if len(bundle.Runtime.BurnRateTiers) != 0 {
+		t.Fatalf("expected no tiers without an AlertPolicy, got %#v", bundle.Runtime.BurnRateTiers)
+	}
+}
+
+func TestParseBundleMinimumTrafficAnnotations(t *testing.T) {
+	bundle := func(annotations string) string {
+		return `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: checkout-errors
+  annotations:
+` + annotations + `spec:
+  service: api-gateway
+  objectives:
+    - target: 0.99
+  indicator:
+    metadata:
+      name: i
+    spec:
+      thresholdMetric:
+        metricSource:
+          spec:
+            route: /cart/checkout
+            type: error_rate
+            datasourceUid: clickhouse
+            datasourceType: clickhouse
+`
+	}
+	b, err := ParseBundle(bundle("    heatmap.local/minEvents: \"50\"\n    heatmap.local/minSliceEvents: \"5\"\n"))
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	if b.Runtime.MinEvents != 50 || b.Runtime.MinSliceEvents != 5 {
+		t.Fatalf("unexpected minimum traffic: %d %d", b.Runtime.MinEvents, b.Runtime.MinSliceEvents)
+	}
+	back := MapToRuntime(RuntimeToMap(b.Runtime))
+	if back.MinEvents != 50 || back.MinSliceEvents != 5 {
+		t.Fatalf("minimum traffic lost in canonical round trip: %#v", back)
+	}
+	for _, bad := range []string{"0", "-1", "ten"} {
+		if _, err := ParseBundle(bundle("    heatmap.local/minEvents: \"" + bad + "\"\n")); err == nil {
+			t.Fatalf("expected error for minEvents %q", bad)
+		}
+	}
+}
+
+func TestParseBundleGroupBy(t *testing.T) {
+	threshold := `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: checkout-latency-by-region
+spec:
+  service: api-gateway
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.99
+  indicator:
+    metadata:
+      name: i
+    spec:
+      thresholdMetric:
+        metricSource:
+          spec:
+            route: /cart/checkout
+            type: latency
+            threshold: 500
+            datasourceUid: clickhouse
+            datasourceType: clickhouse
+            groupBy: [cloud.region, tenant.id]
+`
+	bundle, err := ParseBundle(threshold)
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	if got := strings.Join(bundle.Runtime.GroupBy, ","); got != "cloud.region,tenant.id" {
+		t.Fatalf("unexpected groupBy %q", got)
+	}
+	if back := MapToRuntime(RuntimeToMap(bundle.Runtime)); strings.Join(back.GroupBy, ",") != "cloud.region,tenant.id" {
+		t.Fatalf("groupBy lost in canonical round trip: %#v", back.GroupBy)
+	}
+	if _, err := ParseBundle(strings.Replace(threshold, "[cloud.region, tenant.id]", "[region, region]", 1)); err == nil {
+		t.Fatalf("expected repeated groupBy key to be rejected")
+	}
+
+	ratio := `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: api-availability-by-region
+spec:
+  service: api-gateway
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.99
+  indicator:
+    metadata:
+      name: i
+    spec:
+      ratioMetric:
+        good:
+          metricSource:
+            spec: {datasourceType: prometheus, datasourceUid: prometheus, query: good, groupBy: [region]}
+        total:
+          metricSource:
+            spec: {datasourceType: prometheus, datasourceUid: prometheus, query: total, groupBy: [region]}
+`
+	if bundle, err := ParseBundle(ratio); err != nil || strings.Join(bundle.Runtime.GroupBy, ",") != "region" {
+		t.Fatalf("expected ratio groupBy, got %#v err=%v", bundle.Runtime.GroupBy, err)
+	}
+	if _, err := ParseBundle(strings.Replace(ratio, "query: good, groupBy: [region]", "query: good", 1)); err == nil {
+		t.Fatalf("expected mismatched ratio groupBy to be rejected")
+	}
+	if _, err := ParseBundle(strings.ReplaceAll(ratio, "[region]", "[cloud.region]")); err == nil {
+		t.Fatalf("expected dotted prometheus label to be rejected")
+	}
+}
+
+func TestParseBundleComposite(t *testing.T) {
+	raw := `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: place-order
+spec:
+  service: checkout
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.99
+  timeWindow:
+    - duration: 1h
+      isRolling: true
+  composite:
+    aggregation: min
+    components:
+      - sloId: 5f0b2c7e-2a54-4f55-9d47-8f1d9f7a1c01
+        weight: 2
+      - sloId: 0d6c3e51-6b1a-4d0e-8a55-1f6f6c9b2e02
+`
+	bundle, err := ParseBundle(raw)
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	rt := bundle.Runtime
+	if rt.Type != TypeComposite || rt.DatasourceType != DatasourceComposite || rt.Composite == nil {
+		t.Fatalf("expected composite runtime, got %#v", rt)
+	}
+	if rt.Composite.Aggregation != AggregationMin || len(rt.Composite.Components) != 2 {
+		t.Fatalf("unexpected composite %#v", rt.Composite)
+	}
+	if rt.Composite.Components[0].Weight != 2 || rt.Composite.Components[1].Weight != 1 {
+		t.Fatalf("unexpected weights %#v", rt.Composite.Components)
+	}
+	back := MapToRuntime(RuntimeToMap(rt))
+	if back.Composite == nil || back.Composite.Aggregation != AggregationMin || back.Composite.Components[1] != rt.Composite.Components[1] {
+		t.Fatalf("composite lost in canonical round trip: %#v", back.Composite)
+	}
+
+	for name, bad := range map[string]string{
+		"aggregation": strings.Replace(raw, "aggregation: min", "aggregation: max", 1),
+		"weight":      strings.Replace(raw, "weight: 2", "weight: 0", 1),
+		"sloId":       strings.Replace(raw, "5f0b2c7e-2a54-4f55-9d47-8f1d9f7a1c01", "checkout", 1),
+		"duplicate":   strings.Replace(raw, "0d6c3e51-6b1a-4d0e-8a55-1f6f6c9b2e02", "5f0b2c7e-2a54-4f55-9d47-8f1d9f7a1c01", 1),
+		"timeslices":  strings.Replace(raw, "budgetingMethod: Occurrences", "budgetingMethod: Timeslices", 1),
+		"indicator": strings.Replace(raw, "  composite:", `  indicator:
+    metadata:
+      name: i
+    spec: {}
+  composite:`, 1),
+	} {
+		if _, err := ParseBundle(bad); err == nil {
+			t.Fatalf("%s: expected composite to be rejected", name)
+		}
+	}
+}
+
+func TestParseBundleMultipleObjectives(t *testing.T) {
+	raw := `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: checkout-latency
+spec:
+  service: api-gateway
+  budgetingMethod: Occurrences
+  objectives:
+    - displayName: fast
+      target: 0.99
+      op: lte
+      value: 300
+    - displayName: tolerable
+      target: 0.999
+      value: 1000
+  indicator:
+    metadata:
+      name: i
+    spec:
+      thresholdMetric:
+        metricSource:
+          spec:
+            route: /cart/checkout
+            type: latency
+            datasourceUid: clickhouse
+            datasourceType: clickhouse
+`
+	bundle, err := ParseBundle(raw)
+	if err != nil {
+		t.Fatalf("ParseBundle failed: %v", err)
+	}
+	rt := bundle.Runtime
+	want := []Objective{
+		{Name: "fast", Target: 0.99, Threshold: 300, Op: OpLTE},
+		{Name: "tolerable", Target: 0.999, Threshold: 1000},
+	}
+	if len(rt.Objectives) != 2 || rt.Objectives[0] != want[0] || rt.Objectives[1] != want[1] {
+		t.Fatalf("unexpected objectives %#v", rt.Objectives)
+	}
+	if rt.Target != 0.99 || rt.Threshold != 300 {
+		t.Fatalf("expected the first objective as target and threshold, got %v/%v", rt.Target, rt.Threshold)
+	}
+	back := MapToRuntime(RuntimeToMap(rt))
+	if len(back.Objectives) != 2 || back.Objectives[0] != want[0] || back.Objectives[1] != want[1] {
+		t.Fatalf("objectives lost in canonical round trip: %#v", back.Objectives)
+	}
+	if legacy := MapToRuntime(map[string]any{"target": 0.995, "threshold": 250}); len(legacy.Objectives) != 1 || legacy.Objectives[0] != (Objective{Target: 0.995, Threshold: 250}) {
+		t.Fatalf("expected canonical JSON without objectives to keep one objective, got %#v", legacy.Objectives)
+	}
+
+	for name, bad := range map[string]string{
+		"repeated name": strings.Replace(raw, "displayName: tolerable", "displayName: fast", 1),
+		"two unnamed":   strings.Replace(strings.Replace(raw, "displayName: tolerable", "op: lt", 1), "displayName: fast", "op: lt", 1),
+		"op":            strings.Replace(raw, "op: lte", "op: eq", 1),
+		"no threshold":  strings.Replace(raw, "      value: 1000\n", "", 1),
+		"target":        strings.Replace(raw, "target: 0.999", "target: 1.5", 1),
+	} {
+		if _, err := ParseBundle(bad); err == nil {
+			t.Fatalf("%s: expected objectives to be rejected", name)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package outbox
+
+import (
+	"math/rand"
+	"time"
+)
+
+// backoff returns the delay before delivery attempt n+1 after n failed ones: base doubled per
+// failure, capped at max, then jittered down by up to half so retries of a batch spread out.
+func backoff(n int, base, max time.Duration, rnd *rand.Rand) time.Duration {
+	if base <= 0 {
+		base = time.Second
+	}
+	if max < base {
+		max = base
+	}
+	d := base
+	for i := 1; i < n && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	half := d / 2
+	return d - half + time.Duration(rnd.Int63n(int64(half)+1))
+}
@@ -0,0 +1,24 @@
+package outbox
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestBackoffIsCappedAndJittered(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	base, max := time.Second, 5*time.Minute
+	for _, n := range []int{1, 2, 5, 30, 64, 1000} {
+		want := max
+		if n <= 9 {
+			want = base << (n - 1)
+		}
+		for i := 0; i < 50; i++ {
+			d := backoff(n, base, max, rnd)
+			if d < want/2 || d > want {
+				t.Fatalf("backoff(%d) = %s, want within [%s, %s]", n, d, want/2, want)
+			}
+		}
+	}
+}
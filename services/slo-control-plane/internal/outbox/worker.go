@@ -2,7 +2,12 @@ package outbox
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"math/rand"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -10,28 +15,66 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/burn"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/events"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
 	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/telemetry"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/webhook"
 )
 
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// WorkerID identifies this worker's claims; it must be unique per process.
+	WorkerID string
+	// ClaimTTL is how long a claimed batch is leased. Events still claimed when it runs out are
+	// reclaimed by the next poll of any worker, so it must outlast delivering a whole batch.
+	ClaimTTL time.Duration
+	// MaxRetries is how many times a failed delivery is retried before the event is dead-lettered.
+	MaxRetries int
+	// RetryBaseDelay doubles per failed attempt up to RetryMaxDelay.
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+}
+
 type Worker struct {
-	store        *store.Store
-	sink         *burn.Sink
-	pollInterval time.Duration
-	batchSize    int
+	store    *store.Store
+	sink     *burn.Sink
+	webhooks *webhook.Client
+	cfg      Config
+	rnd      *rand.Rand
 }
 
-func NewWorker(st *store.Store, sink *burn.Sink, pollInterval time.Duration, batchSize int) *Worker {
+// NewWorker delivers burn events to sink and, unless webhooks is nil, to webhook subscriptions.
+func NewWorker(st *store.Store, sink *burn.Sink, webhooks *webhook.Client, cfg Config) *Worker {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.ClaimTTL <= 0 {
+		cfg.ClaimTTL = 5 * time.Minute
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.RetryBaseDelay <= 0 {
+		cfg.RetryBaseDelay = time.Second
+	}
+	if cfg.RetryMaxDelay < cfg.RetryBaseDelay {
+		cfg.RetryMaxDelay = cfg.RetryBaseDelay
+	}
 	return &Worker{
-		store:        st,
-		sink:         sink,
-		pollInterval: pollInterval,
-		batchSize:    batchSize,
+		store:    st,
+		sink:     sink,
+		webhooks: webhooks,
+		cfg:      cfg,
+		rnd:      rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
 func (w *Worker) Run(ctx context.Context) {
-	t := time.NewTicker(w.pollInterval)
+	t := time.NewTicker(w.cfg.PollInterval)
 	defer t.Stop()
 	for {
 		select {
@@ -47,9 +90,11 @@ func (w *Worker) flushOnce(ctx context.Context) {
 	tr := otel.Tracer("slo-control-plane/outbox")
 	ctx, span := tr.Start(ctx, "outbox.flush_once", trace.WithSpanKind(trace.SpanKindInternal))
 	defer span.End()
-	span.SetAttributes(attribute.Int("outbox.batch_size", w.batchSize))
+	span.SetAttributes(attribute.Int("outbox.batch_size", w.cfg.BatchSize), attribute.String("outbox.worker_id", w.cfg.WorkerID))
 
-	events, err := w.store.ClaimPendingOutbox(ctx, w.batchSize)
+	// The lease is taken after this, so the local deadline never outlives it.
+	deadline := time.Now().Add(w.cfg.ClaimTTL)
+	events, err := w.store.ClaimPendingOutbox(ctx, w.cfg.WorkerID, w.cfg.BatchSize, w.cfg.ClaimTTL, w.cfg.MaxRetries)
 	if err != nil {
 		telemetry.RecordSpanError(span, err)
 		log.Printf("outbox claim failed: %v", err)
@@ -58,62 +103,164 @@ func (w *Worker) flushOnce(ctx context.Context) {
 	span.SetAttributes(attribute.Int("outbox.claimed_count", len(events)))
 	delivered := 0
 	retried := 0
-	for _, ev := range events {
-		b := burn.Event{
-			ID:             ev.ID,
-			ServiceID:      ev.AggregateID, // adjusted below if present in payload
-			SLOID:          ev.AggregateID,
-			EventType:      "burn_continued",
-			Value:          0,
-			Threshold:      0,
-			ObservedAt:     time.Now().UTC(),
-			Source:         "control-plane",
-			IdempotencyKey: ev.IdempotencyKey,
-		}
-		if v, ok := ev.Payload["serviceId"].(string); ok {
-			if parsed, err := parseUUID(v); err == nil {
-				b.ServiceID = parsed
-			}
+	deadLettered := 0
+	undelivered := deliverWithinLease(events, deadline, time.Now, func(ev store.OutboxEvent) {
+		var err error
+		if ev.AggregateType == store.WebhookAggregateType {
+			err = w.deliverWebhook(ctx, ev)
+		} else {
+			err = w.deliverBurnEvent(ctx, ev)
 		}
-		if v, ok := ev.Payload["sloId"].(string); ok {
-			if parsed, err := parseUUID(v); err == nil {
-				b.SLOID = parsed
+		if err != nil {
+			if w.fail(ctx, ev, err) {
+				deadLettered++
+			} else {
+				retried++
 			}
+			return
 		}
-		if v, ok := ev.Payload["eventType"].(string); ok && v != "" {
-			b.EventType = v
-		}
-		if v, ok := ev.Payload["value"].(float64); ok {
-			b.Value = float32(v)
-		}
-		if v, ok := ev.Payload["threshold"].(float64); ok {
-			b.Threshold = float32(v)
-		}
-		if v, ok := ev.Payload["source"].(string); ok && v != "" {
-			b.Source = v
-		}
-
-		if err := w.sink.InsertEvent(ctx, b); err != nil {
-			_ = w.store.MarkOutboxRetry(ctx, ev.ID, ev.RetryCount+1, err.Error())
-			retried++
-			continue
+		if err := w.store.MarkOutboxDelivered(ctx, ev.ID, w.cfg.WorkerID); err != nil {
+			// A claim lost to another worker is delivered again by its new holder.
+			log.Printf("outbox mark delivered %s failed: %v", ev.ID, err)
+			return
 		}
-		_ = w.store.InsertBurnEventView(ctx, store.BurnEvent{
-			ID:             b.ID,
-			ServiceID:      b.ServiceID,
-			SLOID:          b.SLOID,
-			EventType:      b.EventType,
-			Value:          b.Value,
-			Threshold:      b.Threshold,
-			ObservedAt:     b.ObservedAt,
-			Source:         b.Source,
-			IdempotencyKey: b.IdempotencyKey,
-		})
-		_ = w.store.MarkOutboxDelivered(ctx, ev.ID)
 		delivered++
+	})
+	if undelivered > 0 {
+		// Another worker may already have reclaimed the rest.
+		log.Printf("outbox claim expired with %d events undelivered", undelivered)
 	}
 	span.SetAttributes(
 		attribute.Int("outbox.delivered_count", delivered),
 		attribute.Int("outbox.retried_count", retried),
+		attribute.Int("outbox.dead_lettered_count", deadLettered),
 	)
 }
+
+// deliverWithinLease hands events to deliver in order while the claim leased until deadline is
+// still held, and returns how many it left undelivered once the lease ran out.
+func deliverWithinLease(events []store.OutboxEvent, deadline time.Time, now func() time.Time, deliver func(store.OutboxEvent)) int {
+	for i, ev := range events {
+		if !now().Before(deadline) {
+			return len(events) - i
+		}
+		deliver(ev)
+	}
+	return 0
+}
+
+// fail schedules a retry of ev or dead-letters it once it is out of retries, and reports which.
+func (w *Worker) fail(ctx context.Context, ev store.OutboxEvent, err error) bool {
+	attempt := ev.RetryCount + 1
+	if attempt > w.cfg.MaxRetries {
+		if markErr := w.store.MarkOutboxDeadLetter(ctx, ev.ID, w.cfg.WorkerID, attempt, err.Error()); markErr != nil {
+			log.Printf("outbox dead-letter %s failed: %v", ev.ID, markErr)
+		} else {
+			log.Printf("outbox event %s dead-lettered after %d attempts: %v", ev.ID, attempt, err)
+		}
+		return true
+	}
+	next := time.Now().Add(backoff(attempt, w.cfg.RetryBaseDelay, w.cfg.RetryMaxDelay, w.rnd))
+	if markErr := w.store.MarkOutboxRetry(ctx, ev.ID, w.cfg.WorkerID, attempt, err.Error(), next); markErr != nil {
+		log.Printf("outbox retry %s failed: %v", ev.ID, markErr)
+	}
+	return false
+}
+
+// deliverBurnEvent writes the event to ClickHouse and the burn event list, then queues it for the
+// webhook subscriptions of its service.
+func (w *Worker) deliverBurnEvent(ctx context.Context, ev store.OutboxEvent) error {
+	e, err := cloudEvent(ev)
+	if err != nil {
+		return err
+	}
+	b := burn.FromEvent(e, ev.IdempotencyKey)
+	if err := w.sink.InsertEvent(ctx, b); err != nil {
+		return err
+	}
+	_ = w.store.InsertBurnEventView(ctx, store.BurnEvent{
+		ID:             b.ID,
+		ServiceID:      b.ServiceID,
+		SLOID:          b.SLOID,
+		EventType:      b.EventType,
+		Value:          b.Value,
+		Threshold:      b.Threshold,
+		ObservedAt:     b.ObservedAt,
+		Source:         b.Source,
+		IdempotencyKey: b.IdempotencyKey,
+		Objective:      b.Objective,
+		DimensionKey:   b.DimensionKey,
+		Dimensions:     b.Dimensions,
+	})
+	if w.webhooks == nil {
+		return nil
+	}
+	subs, err := w.store.ListWebhookSubscriptionsForService(ctx, b.ServiceID)
+	if err != nil {
+		return err
+	}
+	wanted := subs[:0]
+	for _, sub := range subs {
+		if sub.Wants(b.EventType) {
+			wanted = append(wanted, sub)
+		}
+	}
+	return w.store.EnqueueWebhookDeliveries(ctx, ev, e, wanted)
+}
+
+// deliverWebhook POSTs a queued burn event to its subscription. Events of deleted or disabled
+// subscriptions are dropped.
+func (w *Worker) deliverWebhook(ctx context.Context, ev store.OutboxEvent) error {
+	if w.webhooks == nil {
+		return fmt.Errorf("webhook delivery not configured")
+	}
+	sub, err := w.store.GetWebhookSubscription(ctx, ev.AggregateID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !sub.Enabled) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	e, err := cloudEvent(ev)
+	if err != nil {
+		return err
+	}
+	return w.webhooks.Send(ctx, webhook.Endpoint{
+		URL:            sub.URL,
+		Secret:         sub.Secret,
+		Timeout:        sub.Timeout,
+		ContentMode:    sub.ContentMode,
+		SubscriptionID: sub.ID,
+	}, e, time.Now().UTC())
+}
+
+// cloudEvent reads the event an outbox row carries. Burn rows hold the evaluator's event data and
+// webhook rows the structured event; webhook rows queued before events were CloudEvents hold the
+// data with an eventId key.
+func cloudEvent(ev store.OutboxEvent) (events.Event, error) {
+	if _, ok := ev.Payload["specversion"]; ok {
+		var e events.Event
+		err := json.Unmarshal(ev.PayloadJSON, &e)
+		return e, err
+	}
+	var data events.Data
+	if err := json.Unmarshal(ev.PayloadJSON, &data); err != nil {
+		return events.Event{}, fmt.Errorf("decode outbox event %s: %w", ev.ID, err)
+	}
+	if data.EventType == "" {
+		data.EventType = ev.EventType
+	}
+	if data.Source == "" {
+		data.Source = "control-plane"
+	}
+	if data.EvaluatedAt.IsZero() {
+		data.EvaluatedAt = time.Now().UTC()
+	}
+	id := ev.ID
+	if v, ok := ev.Payload["eventId"].(string); ok {
+		if parsed, err := parseUUID(v); err == nil {
+			id = parsed
+		}
+	}
+	return events.New(id, data), nil
+}
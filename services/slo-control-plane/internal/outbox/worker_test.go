@@ -0,0 +1,79 @@
+package outbox
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/events"
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/store"
+)
+
+func outboxRow(t *testing.T, payload any) store.OutboxEvent {
+	blob, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ev := store.OutboxEvent{ID: uuid.New(), EventType: events.BurnStarted, PayloadJSON: blob}
+	_ = json.Unmarshal(blob, &ev.Payload)
+	return ev
+}
+
+func TestCloudEventReadsEveryOutboxPayload(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	data := events.Data{EventType: events.BurnStarted, SLOID: uuid.New(), Source: "slo-evaluator:fast", EvaluatedAt: at}
+
+	// Burn rows hold the evaluator's data and take the outbox ID as the event ID.
+	row := outboxRow(t, data)
+	e, err := cloudEvent(row)
+	if err != nil || e.ID != row.ID || !e.Time.Equal(at) || e.Data.SLOID != data.SLOID {
+		t.Fatalf("unexpected burn event %+v, %v", e, err)
+	}
+
+	// Webhook rows hold the structured event.
+	queued, err := cloudEvent(outboxRow(t, e))
+	if err != nil || queued.ID != e.ID || queued.Type != e.Type {
+		t.Fatalf("unexpected webhook event %+v, %v", queued, err)
+	}
+
+	// Webhook rows queued before CloudEvents carry the event ID next to the data.
+	legacy := map[string]any{"eventType": "burn_started", "sloId": data.SLOID.String(), "eventId": e.ID.String()}
+	old, err := cloudEvent(outboxRow(t, legacy))
+	if err != nil || old.ID != e.ID || old.Data.Source != "control-plane" || old.Time.IsZero() {
+		t.Fatalf("unexpected legacy event %+v, %v", old, err)
+	}
+}
+
+func TestDeliverWithinLeaseStopsOnceLeaseIsGone(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	batch := []store.OutboxEvent{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+	cases := []struct {
+		name        string
+		lease       time.Duration
+		delivered   int
+		undelivered int
+	}{
+		{"lease held throughout", time.Hour, 3, 0},
+		{"lease runs out mid batch", 2 * time.Minute, 2, 1},
+		{"lease gone before the first delivery", 0, 0, 3},
+	}
+	for _, tc := range cases {
+		// Each delivery takes a minute.
+		now := start
+		var delivered []uuid.UUID
+		undelivered := deliverWithinLease(batch, start.Add(tc.lease), func() time.Time { return now }, func(ev store.OutboxEvent) {
+			delivered = append(delivered, ev.ID)
+			now = now.Add(time.Minute)
+		})
+		if len(delivered) != tc.delivered || undelivered != tc.undelivered {
+			t.Fatalf("%s: delivered %d and left %d, want %d and %d", tc.name, len(delivered), undelivered, tc.delivered, tc.undelivered)
+		}
+		for i, id := range delivered {
+			if id != batch[i].ID {
+				t.Fatalf("%s: delivered out of order", tc.name)
+			}
+		}
+	}
+}
@@ -18,13 +18,14 @@ import (
 )
 
 type Config struct {
-	PollInterval       time.Duration
-	BatchSize          int
-	FolderUID          string
-	GroupPrefix        string
-	RuleIntervalSecond int
-	DefaultLabels      map[string]string
-	DefaultAnnotations map[string]string
+	PollInterval        time.Duration
+	BatchSize           int
+	FolderUID           string
+	GroupPrefix         string
+	RuleIntervalSecond  int
+	DefaultLabels       map[string]string
+	DefaultAnnotations  map[string]string
+	EventsDatasourceUID string
 }
 
 type Worker struct {
@@ -72,6 +73,12 @@ func (w *Worker) ReconcileOnce(ctx context.Context) error {
 		return err
 	}
 	span.SetAttributes(attribute.Int("slo.count", len(inputs)))
+	now := time.Now().UTC()
+	windows, err := w.store.ListCurrentMaintenanceWindows(ctx, now)
+	if err != nil {
+		telemetry.RecordSpanError(span, err)
+		return err
+	}
 	desiredRuleUIDs := map[string]struct{}{}
 	reconciled := 0
 	for idx, in := range inputs {
@@ -79,10 +86,12 @@ func (w *Worker) ReconcileOnce(ctx context.Context) error {
 			break
 		}
 		desiredSpecs, err := spec.BuildDesiredRules(in, spec.BuildOptions{
-			FolderUID:          w.cfg.FolderUID,
-			GroupPrefix:        w.cfg.GroupPrefix,
-			DefaultLabels:      w.cfg.DefaultLabels,
-			DefaultAnnotations: w.cfg.DefaultAnnotations,
+			FolderUID:           w.cfg.FolderUID,
+			GroupPrefix:         w.cfg.GroupPrefix,
+			DefaultLabels:       w.cfg.DefaultLabels,
+			DefaultAnnotations:  w.cfg.DefaultAnnotations,
+			EventsDatasourceUID: w.cfg.EventsDatasourceUID,
+			Paused:              inMaintenance(windows, in, now),
 		})
 		if err != nil {
 			log.Printf("build desired rules failed slo=%s: %v", in.ID, err)
@@ -181,6 +190,16 @@ func (w *Worker) garbageCollect(ctx context.Context, keep map[string]struct{}) e
 	return nil
 }
 
+// inMaintenance reports whether a maintenance window covering the SLO is active at now.
+func inMaintenance(windows []store.MaintenanceWindow, in store.SLOReconcileInput, now time.Time) bool {
+	for _, mw := range windows {
+		if mw.Covers(in.ID, in.ServiceID, in.OwnerTeamID) && mw.Schedule.ActiveAt(now) {
+			return true
+		}
+	}
+	return false
+}
+
 func errorText(err error) string {
 	if err == nil {
 		return ""
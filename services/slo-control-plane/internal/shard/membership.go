@@ -0,0 +1,116 @@
+package shard
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/telemetry"
+)
+
+type MemberStore interface {
+	HeartbeatMember(ctx context.Context, memberID string, ttl time.Duration) ([]string, error)
+	RemoveMember(ctx context.Context, memberID string) error
+}
+
+type Config struct {
+	MemberID          string
+	TTL               time.Duration
+	HeartbeatInterval time.Duration
+	VirtualNodes      int
+}
+
+// Membership heartbeats this replica into evaluator_members and maintains a ring over the live
+// members, so each SLO is owned by exactly one replica once heartbeats converge.
+type Membership struct {
+	store MemberStore
+	cfg   Config
+	now   func() time.Time
+
+	mu         sync.Mutex
+	ring       *Ring
+	validUntil time.Time
+}
+
+func NewMembership(st MemberStore, cfg Config) *Membership {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 30 * time.Second
+	}
+	if cfg.HeartbeatInterval <= 0 || cfg.HeartbeatInterval >= cfg.TTL {
+		cfg.HeartbeatInterval = cfg.TTL / 3
+	}
+	return &Membership{store: st, cfg: cfg, now: time.Now, ring: NewRing(nil, cfg.VirtualNodes)}
+}
+
+func (m *Membership) MemberID() string {
+	return m.cfg.MemberID
+}
+
+// Owns reports whether this replica should evaluate sloID. A replica whose own heartbeat has
+// lapsed owns nothing, since its peers have already dropped it from their rings.
+func (m *Membership) Owns(sloID uuid.UUID) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.now().Before(m.validUntil) {
+		return false
+	}
+	return m.ring.Owner(sloID.String()) == m.cfg.MemberID
+}
+
+func (m *Membership) Tick(ctx context.Context) {
+	tr := otel.Tracer("slo-control-plane/shard")
+	ctx, span := tr.Start(ctx, "shard.heartbeat", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+	span.SetAttributes(attribute.String("shard.member", m.cfg.MemberID))
+
+	started := m.now()
+	members, err := m.store.HeartbeatMember(ctx, m.cfg.MemberID, m.cfg.TTL)
+	if err != nil {
+		telemetry.RecordSpanError(span, err)
+		log.Printf("shard heartbeat failed member=%s: %v", m.cfg.MemberID, err)
+		return
+	}
+	ring := NewRing(members, m.cfg.VirtualNodes)
+
+	m.mu.Lock()
+	previous := m.ring.Members()
+	m.ring = ring
+	m.validUntil = started.Add(m.cfg.TTL)
+	m.mu.Unlock()
+
+	span.SetAttributes(attribute.Int("shard.member_count", len(ring.Members())))
+	if strings.Join(previous, ",") != strings.Join(ring.Members(), ",") {
+		log.Printf("shard membership changed member=%s members=%d [%s]", m.cfg.MemberID, len(ring.Members()), strings.Join(ring.Members(), ", "))
+	}
+}
+
+// Run heartbeats until ctx is done, then deregisters so peers pick up this replica's SLOs on
+// their next heartbeat rather than after TTL.
+func (m *Membership) Run(ctx context.Context) {
+	m.Tick(ctx)
+	t := time.NewTicker(m.cfg.HeartbeatInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			m.mu.Lock()
+			m.validUntil = time.Time{}
+			m.mu.Unlock()
+			rctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := m.store.RemoveMember(rctx, m.cfg.MemberID); err != nil {
+				log.Printf("shard deregister failed member=%s: %v", m.cfg.MemberID, err)
+			}
+			cancel()
+			return
+		case <-t.C:
+			m.Tick(ctx)
+		}
+	}
+}
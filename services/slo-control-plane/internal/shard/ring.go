@@ -0,0 +1,66 @@
+package shard
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"strconv"
+)
+
+const DefaultVirtualNodes = 128
+
+// Ring is a consistent-hash ring: adding or removing a member only moves the keys that member
+// gains or loses, not the whole keyspace.
+type Ring struct {
+	points  []uint64
+	owners  map[uint64]string
+	members []string
+}
+
+func NewRing(members []string, virtualNodes int) *Ring {
+	if virtualNodes <= 0 {
+		virtualNodes = DefaultVirtualNodes
+	}
+	r := &Ring{owners: map[uint64]string{}}
+	seen := map[string]bool{}
+	for _, m := range members {
+		if m == "" || seen[m] {
+			continue
+		}
+		seen[m] = true
+		r.members = append(r.members, m)
+		for i := 0; i < virtualNodes; i++ {
+			p := hashKey(m + "#" + strconv.Itoa(i))
+			if _, taken := r.owners[p]; taken {
+				continue
+			}
+			r.owners[p] = m
+			r.points = append(r.points, p)
+		}
+	}
+	sort.Strings(r.members)
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+	return r
+}
+
+func (r *Ring) Members() []string {
+	return append([]string(nil), r.members...)
+}
+
+// Owner returns the member responsible for key, or "" for an empty ring.
+func (r *Ring) Owner(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := hashKey(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.owners[r.points[i]]
+}
+
+func hashKey(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
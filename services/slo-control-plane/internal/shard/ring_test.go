@@ -0,0 +1,82 @@
+package shard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRingAssignsEveryKeyToOneMember(t *testing.T) {
+	ring := NewRing([]string{"c", "a", "b", "a"}, 0)
+	if got := ring.Members(); len(got) != 3 || got[0] != "a" || got[2] != "c" {
+		t.Fatalf("unexpected members %v", got)
+	}
+	counts := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		counts[ring.Owner(uuid.NewString())]++
+	}
+	for _, m := range []string{"a", "b", "c"} {
+		// 128 virtual nodes keep each share well within ±40% of the fair 1000.
+		if counts[m] < 600 || counts[m] > 1400 {
+			t.Fatalf("unbalanced ring: %v", counts)
+		}
+	}
+	if NewRing(nil, 0).Owner("x") != "" {
+		t.Fatalf("expected empty ring to own nothing")
+	}
+}
+
+func TestRingOnlyMovesKeysOfChangedMember(t *testing.T) {
+	before := NewRing([]string{"a", "b", "c"}, 0)
+	after := NewRing([]string{"a", "b", "c", "d"}, 0)
+	for i := 0; i < 2000; i++ {
+		key := uuid.NewString()
+		was, now := before.Owner(key), after.Owner(key)
+		if was != now && now != "d" {
+			t.Fatalf("key %s moved from %s to %s, expected only moves to the new member", key, was, now)
+		}
+	}
+}
+
+type fakeMemberStore struct {
+	members []string
+	err     error
+}
+
+func (f *fakeMemberStore) HeartbeatMember(_ context.Context, _ string, _ time.Duration) ([]string, error) {
+	return f.members, f.err
+}
+
+func (f *fakeMemberStore) RemoveMember(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestMembershipOwnsDisjointShards(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	st := &fakeMemberStore{members: []string{"a", "b"}}
+	a := NewMembership(st, Config{MemberID: "a", TTL: 30 * time.Second})
+	b := NewMembership(st, Config{MemberID: "b", TTL: 30 * time.Second})
+	a.now = func() time.Time { return now }
+	b.now = a.now
+	a.Tick(context.Background())
+	b.Tick(context.Background())
+
+	for i := 0; i < 500; i++ {
+		id := uuid.New()
+		if a.Owns(id) == b.Owns(id) {
+			t.Fatalf("expected exactly one owner for %s", id)
+		}
+	}
+
+	st.err = errors.New("connection refused")
+	now = now.Add(31 * time.Second)
+	a.Tick(context.Background())
+	for i := 0; i < 100; i++ {
+		if a.Owns(uuid.New()) {
+			t.Fatalf("expected a replica with a lapsed heartbeat to own nothing")
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package sli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Definition struct {
+	SpanSelector
+	SLOID     uuid.UUID
+	Type      string
+	Threshold float64
+	// Op is how a good value compares to Threshold: lt, lte, gt or gte, with empty meaning lte.
+	Op            string
+	WindowMinutes int
+	Target        float64
+	DatasourceURL string
+	GoodQuery     string
+	BadQuery      string
+	TotalQuery    string
+	Good          *SpanSelector
+	Bad           *SpanSelector
+	Total         *SpanSelector
+
+	BudgetingMethod   string
+	TimeSliceMinutes  int
+	TimeSliceQuantile float64
+	// MinSliceEvents drops timeslices with fewer spans so one request cannot decide a slice.
+	MinSliceEvents int
+	// GroupBy splits compliance by these span attribute keys (ClickHouse) or series labels
+	// (Prometheus). Grouped definitions are measured through GroupedBackend.
+	GroupBy []string
+}
+
+const (
+	TypeLatency = "latency"
+	TypeRatio   = "ratio"
+
+	BudgetingOccurrences = "Occurrences"
+	BudgetingTimeslices  = "Timeslices"
+)
+
+type SpanSelector struct {
+	Route       string
+	ServiceName string
+	SpanKind    string
+	SpanName    string
+	Attributes  []AttributeMatcher
+}
+
+const (
+	MatchEquals    = "equals"
+	MatchNotEquals = "not-equals"
+	MatchIn        = "in"
+	MatchRegex     = "regex"
+)
+
+type AttributeMatcher struct {
+	Key    string
+	Op     string
+	Values []string
+}
+
+// Backend computes SLI compliance (fraction of good events in [0,1]) for a definition over a trailing
+// window ending at end, or now when end is zero.
+type Backend interface {
+	Compliance(ctx context.Context, def Definition, windowMinutes int, end time.Time) (Sample, error)
+	Close() error
+}
+
+// Explainer is implemented by backends that can show the statements they run for a window.
+type Explainer interface {
+	Explain(def Definition, windowMinutes int, end time.Time) ([]Query, error)
+}
+
+// GroupedBackend is implemented by backends that can measure a definition per GroupBy dimension.
+// Dimensions without events in the window are absent from the result.
+type GroupedBackend interface {
+	GroupedCompliance(ctx context.Context, def Definition, windowMinutes int, end time.Time) ([]GroupSample, error)
+}
+
+// GroupSample is the compliance of one dimension of a grouped definition.
+type GroupSample struct {
+	Dimension
+	Sample
+}
+
+// Dimension is one combination of group-by values. Key is empty for ungrouped definitions.
+type Dimension struct {
+	Key    string
+	Values map[string]string
+}
+
+// NewDimension builds the dimension for values given in group-by key order. The key lists
+// key=value pairs in that order, with values query-escaped so it can be split unambiguously.
+func NewDimension(keys, values []string) Dimension {
+	d := Dimension{Values: make(map[string]string, len(keys))}
+	parts := make([]string, 0, len(keys))
+	for i, k := range keys {
+		v := ""
+		if i < len(values) {
+			v = values[i]
+		}
+		d.Values[k] = v
+		parts = append(parts, k+"="+url.QueryEscape(v))
+	}
+	d.Key = strings.Join(parts, ",")
+	return d
+}
+
+// badValueComparison is the SQL operator that marks a value bad under a good-value op.
+func badValueComparison(op string) (string, error) {
+	switch op {
+	case "", "lte":
+		return ">", nil
+	case "lt":
+		return ">=", nil
+	case "gte":
+		return "<", nil
+	case "gt":
+		return "<=", nil
+	default:
+		return "", fmt.Errorf("unsupported threshold op %q", op)
+	}
+}
+
+// Query is one backend statement. Args are its bound parameters, in order.
+type Query struct {
+	Name string
+	Text string
+	Args []any
+}
+
+// Sample is a compliance measurement together with the number of events it was computed from.
+// With no events Compliance is 1, so callers must check Sufficient before trusting it.
+type Sample struct {
+	Compliance float64
+	Events     float64
+}
+
+// Sufficient reports whether the sample has at least minEvents events, and always at least one.
+func (s Sample) Sufficient(minEvents int) bool {
+	return s.Events > 0 && s.Events >= float64(minEvents)
+}
+
+type Registry struct {
+	backends map[string]Backend
+}
+
+func NewRegistry() *Registry {
+	return &Registry{backends: map[string]Backend{}}
+}
+
+// NewDefaultRegistry registers the ClickHouse and Prometheus backends.
+func NewDefaultRegistry(clickhouseDSN, prometheusURL string, prometheusTimeout time.Duration) (*Registry, error) {
+	reg := NewRegistry()
+	ch, err := NewClickHouse(clickhouseDSN)
+	if err != nil {
+		return nil, err
+	}
+	if err := reg.Register(DatasourceClickHouse, ch); err != nil {
+		return nil, err
+	}
+	if err := reg.Register(DatasourcePrometheus, NewPrometheus(prometheusURL, prometheusTimeout)); err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
+func (r *Registry) Register(datasourceType string, b Backend) error {
+	key := normalizeType(datasourceType)
+	if key == "" {
+		return fmt.Errorf("sli backend datasource type is required")
+	}
+	if b == nil {
+		return fmt.Errorf("sli backend for %q is nil", key)
+	}
+	if _, exists := r.backends[key]; exists {
+		return fmt.Errorf("sli backend for %q already registered", key)
+	}
+	r.backends[key] = b
+	return nil
+}
+
+func (r *Registry) Lookup(datasourceType string) (Backend, bool) {
+	b, ok := r.backends[normalizeType(datasourceType)]
+	return b, ok
+}
+
+func (r *Registry) Types() []string {
+	out := make([]string, 0, len(r.backends))
+	for k := range r.backends {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (r *Registry) Close() error {
+	var errs []error
+	for _, k := range r.Types() {
+		if err := r.backends[k].Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close %s backend: %w", k, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func normalizeType(v string) string {
+	return strings.ToLower(strings.TrimSpace(v))
+}
+
+func ratioCompliance(good, total float64) float64 {
+	if total <= 0 {
+		return 1.0
+	}
+	c := good / total
+	if c > 1 {
+		return 1
+	}
+	if c < 0 {
+		return 0
+	}
+	return c
+}
+
+func badRatioCompliance(bad, total float64) float64 {
+	if total <= 0 {
+		return 1.0
+	}
+	return ratioCompliance(total-bad, total)
+}
@@ -0,0 +1,352 @@
+package sli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeBackend struct {
+	compliance float64
+	closed     bool
+}
+
+func (f *fakeBackend) Compliance(_ context.Context, _ Definition, _ int, _ time.Time) (Sample, error) {
+	return Sample{Compliance: f.compliance, Events: 1}, nil
+}
+
+func (f *fakeBackend) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestRegistryLookupByDatasourceType(t *testing.T) {
+	reg := NewRegistry()
+	fb := &fakeBackend{compliance: 0.97}
+	if err := reg.Register("ClickHouse", fb); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	got, ok := reg.Lookup(" clickhouse ")
+	if !ok || got != fb {
+		t.Fatalf("expected registered backend for clickhouse")
+	}
+	if _, ok := reg.Lookup("prometheus"); ok {
+		t.Fatalf("expected no backend for prometheus")
+	}
+	if err := reg.Close(); err != nil || !fb.closed {
+		t.Fatalf("expected backend to be closed, err=%v", err)
+	}
+}
+
+func TestRegistryRejectsDuplicateType(t *testing.T) {
+	reg := NewRegistry()
+	if err := reg.Register("clickhouse", &fakeBackend{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := reg.Register("clickhouse", &fakeBackend{}); err == nil {
+		t.Fatalf("expected duplicate registration error")
+	}
+}
+
+func mustComplianceSQL(t *testing.T, def Definition, windowMinutes int) *chQuery {
+	t.Helper()
+	q, err := clickhouseComplianceSQL(def, windowMinutes, time.Time{})
+	if err != nil {
+		t.Fatalf("clickhouseComplianceSQL() error = %v", err)
+	}
+	return q
+}
+
+func hasArg(q *chQuery, want any) bool {
+	for _, a := range q.args {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestClickHouseComplianceSQLUsesDefinition(t *testing.T) {
+	q := mustComplianceSQL(t, Definition{SpanSelector: SpanSelector{Route: "/cart/checkout"}, Type: "latency", Threshold: 500, BudgetingMethod: BudgetingOccurrences}, 5)
+	sqlText := q.String()
+	if !strings.Contains(sqlText, "countIf(Duration / 1000000 > ?)") || strings.Contains(sqlText, "quantile") || !strings.Contains(sqlText, "INTERVAL ? MINUTE") {
+		t.Fatalf("unexpected occurrences latency sql: %s", sqlText)
+	}
+	if len(q.args) != 3 || q.args[0] != 500.0 || q.args[1] != 5 || q.args[2] != "/cart/checkout" {
+		t.Fatalf("unexpected occurrences latency args: %#v", q.args)
+	}
+	q = mustComplianceSQL(t, Definition{SpanSelector: SpanSelector{Route: "/api/orders"}, Type: "error_rate", BudgetingMethod: BudgetingOccurrences}, 60)
+	sqlText = q.String()
+	if !strings.Contains(sqlText, "countIf(toInt32OrZero(SpanAttributes['http.status_code']) >= 500)") || strings.Contains(sqlText, "GROUP BY") || strings.Contains(sqlText, "/api/orders") || !hasArg(q, "/api/orders") {
+		t.Fatalf("unexpected occurrences error_rate sql: %s %#v", sqlText, q.args)
+	}
+}
+
+func TestClickHouseComplianceSQLTimeslices(t *testing.T) {
+	q := mustComplianceSQL(t, Definition{
+		SpanSelector:      SpanSelector{Route: "/cart/checkout"},
+		Type:              "latency",
+		Threshold:         500,
+		BudgetingMethod:   BudgetingTimeslices,
+		TimeSliceMinutes:  5,
+		TimeSliceQuantile: 0.95,
+	}, 60)
+	sqlText := q.String()
+	if !strings.Contains(sqlText, "quantile(0.95)") || !strings.Contains(sqlText, "INTERVAL ? minute") || !strings.Contains(sqlText, "slice_ms > ?") {
+		t.Fatalf("unexpected timeslices latency sql: %s", sqlText)
+	}
+	// Slices need at least one span by default.
+	if len(q.args) != 5 || q.args[0] != 500.0 || q.args[1] != 60 || q.args[3] != 5 || q.args[4] != 1 {
+		t.Fatalf("unexpected timeslices latency args: %#v", q.args)
+	}
+	q = mustComplianceSQL(t, Definition{
+		SpanSelector:     SpanSelector{Route: "/api/orders"},
+		Type:             "error_rate",
+		Threshold:        0.01,
+		BudgetingMethod:  BudgetingTimeslices,
+		TimeSliceMinutes: 1,
+		MinSliceEvents:   20,
+	}, 60)
+	if !strings.Contains(q.String(), "err_rate > ?") || q.args[0] != 0.01 || q.args[len(q.args)-2] != 1 || q.args[len(q.args)-1] != 20 {
+		t.Fatalf("unexpected timeslices error_rate sql: %s %#v", q.String(), q.args)
+	}
+}
+
+func TestClickHouseComplianceSQLSpanFilters(t *testing.T) {
+	q := mustComplianceSQL(t, Definition{
+		Type:      "error_rate",
+		Threshold: 0.01,
+		SpanSelector: SpanSelector{
+			ServiceName: "payment-service",
+			SpanKind:    "server",
+			SpanName:    "payments.v1.Payments/Charge",
+			Attributes: []AttributeMatcher{
+				{Key: "tenant.id", Op: MatchEquals, Values: []string{"acme"}},
+				{Key: "rpc.grpc.status_code", Op: MatchIn, Values: []string{"0", "5"}},
+				{Key: "deployment.environment", Op: MatchNotEquals, Values: []string{"staging"}},
+				{Key: "net.peer.name", Op: MatchRegex, Values: []string{"^checkout-.*"}},
+			},
+		},
+	}, 5)
+	sqlText := q.String()
+	for _, want := range []string{
+		"ServiceName = ?",
+		"lower(SpanKind) IN (?, ?)",
+		"SpanName = ?",
+		"SpanAttributes[?] = ?",
+		"SpanAttributes[?] IN (?, ?)",
+		"SpanAttributes[?] != ?",
+		"match(SpanAttributes[?], ?)",
+	} {
+		if !strings.Contains(sqlText, want) {
+			t.Fatalf("expected %q in sql: %s", want, sqlText)
+		}
+	}
+	wantArgs := []any{5, "payment-service", "server", "span_kind_server", "payments.v1.Payments/Charge",
+		"tenant.id", "acme", "rpc.grpc.status_code", "0", "5", "deployment.environment", "staging", "net.peer.name", "^checkout-.*"}
+	if len(q.args) != len(wantArgs) {
+		t.Fatalf("args = %#v, want %#v", q.args, wantArgs)
+	}
+	for i := range wantArgs {
+		if q.args[i] != wantArgs[i] {
+			t.Fatalf("arg %d = %#v, want %#v", i, q.args[i], wantArgs[i])
+		}
+	}
+	if strings.Contains(sqlText, "api-gateway") || strings.Contains(sqlText, "http.route") {
+		t.Fatalf("unexpected hard-coded filters in sql: %s", sqlText)
+	}
+}
+
+func TestClickHouseSQLKeepsHostileValuesOutOfSQL(t *testing.T) {
+	hostile := []string{
+		`/cart' OR 1=1 --`,
+		`/cart\' OR 1=1 --`,
+		"/cart'; DROP TABLE otel_traces; --",
+		"/cart\n    OR 1=1",
+	}
+	for _, route := range hostile {
+		q := mustComplianceSQL(t, Definition{
+			SpanSelector: SpanSelector{
+				Route:      route,
+				SpanName:   route,
+				Attributes: []AttributeMatcher{{Key: "tenant.id", Op: MatchIn, Values: []string{route, "acme"}}},
+			},
+			Type: "error_rate",
+		}, 5)
+		if strings.Contains(q.String(), "OR 1=1") || strings.Contains(q.String(), "DROP") {
+			t.Fatalf("hostile value leaked into sql: %s", q.String())
+		}
+		if !hasArg(q, route) {
+			t.Fatalf("expected hostile value to be bound as an argument: %#v", q.args)
+		}
+	}
+}
+
+func TestClickHouseSQLRejectsHostileAttributeKeys(t *testing.T) {
+	for _, key := range []string{
+		"tenant'] OR 1=1 --",
+		"tenant.id)",
+		"tenant id",
+		"",
+		"1tenant",
+		strings.Repeat("a", 256),
+	} {
+		_, err := clickhouseComplianceSQL(Definition{
+			SpanSelector: SpanSelector{Attributes: []AttributeMatcher{{Key: key, Op: MatchEquals, Values: []string{"x"}}}},
+			Type:         "error_rate",
+		}, 5, time.Time{})
+		if err == nil {
+			t.Fatalf("expected attribute key %q to be rejected", key)
+		}
+		if _, err := clickhouseCountSQL(SpanSelector{Attributes: []AttributeMatcher{{Key: key, Op: MatchEquals, Values: []string{"x"}}}}, nil, 5, time.Time{}); err == nil {
+			t.Fatalf("expected count sql to reject attribute key %q", key)
+		}
+	}
+	for _, key := range []string{"http.request.method", "k8s.pod.name", "rpc.grpc.status_code", "app/tier", "_internal"} {
+		if err := ValidateAttributeKey(key); err != nil {
+			t.Fatalf("expected %q to be valid: %v", key, err)
+		}
+	}
+}
+
+func TestClickHouseCountSQLPerRatioSource(t *testing.T) {
+	q, err := clickhouseCountSQL(SpanSelector{
+		ServiceName: "queue-worker",
+		SpanName:    "job.complete",
+	}, nil, 60, time.Time{})
+	if err != nil {
+		t.Fatalf("clickhouseCountSQL() error = %v", err)
+	}
+	if !strings.Contains(q.String(), "SELECT count() AS events") || !strings.Contains(q.String(), "SpanName = ?") || q.args[0] != 60 || !hasArg(q, "job.complete") {
+		t.Fatalf("unexpected count sql: %s %#v", q.String(), q.args)
+	}
+}
+
+func TestClickHouseComplianceSQLReportsEventCount(t *testing.T) {
+	q := mustComplianceSQL(t, Definition{SpanSelector: SpanSelector{Route: "/cart"}, Type: "error_rate"}, 5)
+	if !strings.HasPrefix(q.String(), "SELECT\n  count() AS events,") {
+		t.Fatalf("expected occurrences sql to select the event count first: %s", q.String())
+	}
+	q = mustComplianceSQL(t, Definition{SpanSelector: SpanSelector{Route: "/cart"}, Type: "error_rate", Threshold: 0.1, BudgetingMethod: BudgetingTimeslices}, 5)
+	if !strings.Contains(q.String(), "sum(slice_events) AS events") || !strings.Contains(q.String(), "HAVING slice_events >= ?") {
+		t.Fatalf("expected timeslices sql to count events and drop sparse slices: %s", q.String())
+	}
+}
+
+func TestSampleSufficient(t *testing.T) {
+	cases := []struct {
+		sample    Sample
+		minEvents int
+		want      bool
+	}{
+		{Sample{Compliance: 1, Events: 0}, 0, false},
+		{Sample{Compliance: 0, Events: 1}, 0, true},
+		{Sample{Compliance: 0, Events: 1}, 10, false},
+		{Sample{Compliance: 0.9, Events: 10}, 10, true},
+	}
+	for _, tc := range cases {
+		if got := tc.sample.Sufficient(tc.minEvents); got != tc.want {
+			t.Fatalf("%+v.Sufficient(%d) = %v, want %v", tc.sample, tc.minEvents, got, tc.want)
+		}
+	}
+}
+
+func TestRatioCompliance(t *testing.T) {
+	cases := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"good over total", ratioCompliance(95, 100), 0.95},
+		{"bad over total", badRatioCompliance(5, 100), 0.95},
+		{"no traffic", ratioCompliance(0, 0), 1},
+		{"bad exceeds total", badRatioCompliance(120, 100), 0},
+		{"good exceeds total", ratioCompliance(120, 100), 1},
+	}
+	for _, tc := range cases {
+		if tc.got != tc.want {
+			t.Fatalf("%s: got %v want %v", tc.name, tc.got, tc.want)
+		}
+	}
+}
+
+func TestClickHouseExplainRatio(t *testing.T) {
+	ch := &ClickHouse{}
+	got, err := ch.Explain(Definition{
+		Type:  TypeRatio,
+		Total: &SpanSelector{SpanName: "job.run"},
+		Good:  &SpanSelector{SpanName: "job.complete"},
+	}, 60, time.Time{})
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Name != "total" || got[1].Name != "good" || got[1].Args[len(got[1].Args)-1] != "job.complete" {
+		t.Fatalf("unexpected ratio queries: %+v", got)
+	}
+}
+
+func TestClickHouseSQLAsOfEndTime(t *testing.T) {
+	end := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	q, err := clickhouseComplianceSQL(Definition{SpanSelector: SpanSelector{Route: "/cart"}, Type: "error_rate"}, 5, end)
+	if err != nil {
+		t.Fatalf("clickhouseComplianceSQL() error = %v", err)
+	}
+	if strings.Contains(q.String(), "now()") || !strings.Contains(q.String(), "Timestamp >= ? - INTERVAL ? MINUTE AND Timestamp < ?") {
+		t.Fatalf("expected a window ending at a bound time: %s", q.String())
+	}
+	if q.args[0] != end || q.args[1] != 5 || q.args[2] != end {
+		t.Fatalf("unexpected window args: %#v", q.args)
+	}
+}
+
+func TestClickHouseSQLGroupsByAttributes(t *testing.T) {
+	def := Definition{SpanSelector: SpanSelector{Route: "/cart"}, Type: "latency", Threshold: 300, GroupBy: []string{"cloud.region", "tenant"}}
+	q := mustComplianceSQL(t, def, 5)
+	if !strings.HasPrefix(q.String(), "SELECT\n  SpanAttributes[?] AS group_0,\n  SpanAttributes[?] AS group_1,\n  count() AS events,") ||
+		!strings.HasSuffix(q.String(), "GROUP BY group_0, group_1") {
+		t.Fatalf("expected grouped occurrences sql: %s", q.String())
+	}
+	if q.args[0] != "cloud.region" || q.args[1] != "tenant" || q.args[2] != 300.0 {
+		t.Fatalf("unexpected grouped args: %#v", q.args)
+	}
+
+	def.BudgetingMethod = BudgetingTimeslices
+	q = mustComplianceSQL(t, def, 5)
+	if !strings.Contains(q.String(), "GROUP BY group_0, group_1, toStartOfInterval(Timestamp, INTERVAL ? minute)") ||
+		!strings.HasSuffix(q.String(), ")\nGROUP BY group_0, group_1") {
+		t.Fatalf("expected slices grouped per dimension: %s", q.String())
+	}
+	if q.args[0] != 300.0 || q.args[1] != "cloud.region" || q.args[len(q.args)-2] != 1 || q.args[len(q.args)-1] != 1 {
+		t.Fatalf("unexpected grouped timeslices args: %#v", q.args)
+	}
+
+	if _, err := clickhouseCountSQL(SpanSelector{SpanName: "job"}, []string{"bad key'"}, 5, time.Time{}); err == nil {
+		t.Fatalf("expected invalid group-by key to be rejected")
+	}
+}
+
+func TestNewDimensionKey(t *testing.T) {
+	d := NewDimension([]string{"region", "tenant"}, []string{"eu-west-1", "a,b=c"})
+	if d.Key != "region=eu-west-1,tenant=a%2Cb%3Dc" || d.Values["tenant"] != "a,b=c" {
+		t.Fatalf("unexpected dimension: %+v", d)
+	}
+}
+
+func TestClickHouseSQLThresholdOp(t *testing.T) {
+	def := Definition{SpanSelector: SpanSelector{Route: "/cart"}, Type: "latency", Threshold: 300}
+	for op, want := range map[string]string{"": "Duration / 1000000 > ?", "lt": "Duration / 1000000 >= ?", "gte": "Duration / 1000000 < ?"} {
+		def.Op = op
+		if q := mustComplianceSQL(t, def, 5); !strings.Contains(q.String(), want) {
+			t.Fatalf("op %q: expected %q in sql: %s", op, want, q.String())
+		}
+	}
+	def.Op, def.BudgetingMethod = "lt", BudgetingTimeslices
+	if q := mustComplianceSQL(t, def, 5); !strings.Contains(q.String(), "countIf(slice_ms >= ?)") {
+		t.Fatalf("expected timeslices to honour op: %s", q.String())
+	}
+	def.Op = "eq"
+	if _, err := clickhouseComplianceSQL(def, 5, time.Time{}); err == nil {
+		t.Fatalf("expected unsupported op to be rejected")
+	}
+}
@@ -0,0 +1,427 @@
+package sli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "github.com/ClickHouse/clickhouse-go/v2"
+)
+
+const DatasourceClickHouse = "clickhouse"
+
+type ClickHouse struct {
+	db *sql.DB
+}
+
+func NewClickHouse(dsn string) (*ClickHouse, error) {
+	db, err := sql.Open("clickhouse", dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &ClickHouse{db: db}, nil
+}
+
+func (c *ClickHouse) Close() error {
+	return c.db.Close()
+}
+
+func (c *ClickHouse) Compliance(ctx context.Context, def Definition, windowMinutes int, end time.Time) (Sample, error) {
+	if def.Type == TypeRatio {
+		return c.ratioCompliance(ctx, def, windowMinutes, end)
+	}
+	if def.Threshold <= 0 && (def.Type == TypeLatency || def.BudgetingMethod == BudgetingTimeslices) {
+		return Sample{}, fmt.Errorf("clickhouse sli for slo %s requires threshold", def.SLOID)
+	}
+	if def.Route == "" && def.SpanName == "" && len(def.Attributes) == 0 {
+		return Sample{}, fmt.Errorf("clickhouse sli for slo %s requires route, span name or attribute matchers", def.SLOID)
+	}
+	q, err := clickhouseComplianceSQL(def, windowMinutes, end)
+	if err != nil {
+		return Sample{}, fmt.Errorf("clickhouse sli for slo %s: %w", def.SLOID, err)
+	}
+	var events uint64
+	var compliance float64
+	if err := c.db.QueryRowContext(ctx, q.String(), q.args...).Scan(&events, &compliance); err != nil {
+		return Sample{}, err
+	}
+	return Sample{Compliance: compliance, Events: float64(events)}, nil
+}
+
+func (c *ClickHouse) ratioCompliance(ctx context.Context, def Definition, windowMinutes int, end time.Time) (Sample, error) {
+	if def.Total == nil || (def.Good == nil) == (def.Bad == nil) {
+		return Sample{}, fmt.Errorf("clickhouse ratio sli for slo %s requires total and one of good or bad", def.SLOID)
+	}
+	total, err := c.count(ctx, *def.Total, windowMinutes, end)
+	if err != nil {
+		return Sample{}, fmt.Errorf("total query: %w", err)
+	}
+	if def.Good != nil {
+		good, err := c.count(ctx, *def.Good, windowMinutes, end)
+		if err != nil {
+			return Sample{}, fmt.Errorf("good query: %w", err)
+		}
+		return Sample{Compliance: ratioCompliance(good, total), Events: total}, nil
+	}
+	bad, err := c.count(ctx, *def.Bad, windowMinutes, end)
+	if err != nil {
+		return Sample{}, fmt.Errorf("bad query: %w", err)
+	}
+	return Sample{Compliance: badRatioCompliance(bad, total), Events: total}, nil
+}
+
+func (c *ClickHouse) count(ctx context.Context, sel SpanSelector, windowMinutes int, end time.Time) (float64, error) {
+	q, err := clickhouseCountSQL(sel, nil, windowMinutes, end)
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	if err := c.db.QueryRowContext(ctx, q.String(), q.args...).Scan(&n); err != nil {
+		return 0, err
+	}
+	return float64(n), nil
+}
+
+// GroupedCompliance measures the definition once per combination of its GroupBy attribute values.
+func (c *ClickHouse) GroupedCompliance(ctx context.Context, def Definition, windowMinutes int, end time.Time) ([]GroupSample, error) {
+	if len(def.GroupBy) == 0 {
+		return nil, fmt.Errorf("clickhouse sli for slo %s has no groupBy keys", def.SLOID)
+	}
+	if def.Type == TypeRatio {
+		return c.groupedRatioCompliance(ctx, def, windowMinutes, end)
+	}
+	if def.Threshold <= 0 && (def.Type == TypeLatency || def.BudgetingMethod == BudgetingTimeslices) {
+		return nil, fmt.Errorf("clickhouse sli for slo %s requires threshold", def.SLOID)
+	}
+	if def.Route == "" && def.SpanName == "" && len(def.Attributes) == 0 {
+		return nil, fmt.Errorf("clickhouse sli for slo %s requires route, span name or attribute matchers", def.SLOID)
+	}
+	q, err := clickhouseComplianceSQL(def, windowMinutes, end)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse sli for slo %s: %w", def.SLOID, err)
+	}
+	var out []GroupSample
+	err = c.scanGroups(ctx, q, len(def.GroupBy), func(values []string, events uint64, compliance float64) {
+		out = append(out, GroupSample{Dimension: NewDimension(def.GroupBy, values), Sample: Sample{Compliance: compliance, Events: float64(events)}})
+	})
+	return out, err
+}
+
+func (c *ClickHouse) groupedRatioCompliance(ctx context.Context, def Definition, windowMinutes int, end time.Time) ([]GroupSample, error) {
+	if def.Total == nil || (def.Good == nil) == (def.Bad == nil) {
+		return nil, fmt.Errorf("clickhouse ratio sli for slo %s requires total and one of good or bad", def.SLOID)
+	}
+	totals, err := c.groupedCount(ctx, *def.Total, def.GroupBy, windowMinutes, end)
+	if err != nil {
+		return nil, fmt.Errorf("total query: %w", err)
+	}
+	name, part, score := "good", def.Good, ratioCompliance
+	if part == nil {
+		name, part, score = "bad", def.Bad, badRatioCompliance
+	}
+	counts, err := c.groupedCount(ctx, *part, def.GroupBy, windowMinutes, end)
+	if err != nil {
+		return nil, fmt.Errorf("%s query: %w", name, err)
+	}
+	out := make([]GroupSample, 0, len(totals))
+	for _, g := range totals {
+		out = append(out, GroupSample{Dimension: g.Dimension, Sample: Sample{Compliance: score(counts[g.Key].Events, g.Events), Events: g.Events}})
+	}
+	return out, nil
+}
+
+func (c *ClickHouse) groupedCount(ctx context.Context, sel SpanSelector, groupBy []string, windowMinutes int, end time.Time) (map[string]GroupSample, error) {
+	q, err := clickhouseCountSQL(sel, groupBy, windowMinutes, end)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]GroupSample{}
+	err = c.scanGroups(ctx, q, len(groupBy), func(values []string, events uint64, _ float64) {
+		d := NewDimension(groupBy, values)
+		out[d.Key] = GroupSample{Dimension: d, Sample: Sample{Events: float64(events)}}
+	})
+	return out, err
+}
+
+// scanGroups reads rows of group values followed by an event count and, for compliance queries,
+// the compliance.
+func (c *ClickHouse) scanGroups(ctx context.Context, q *chQuery, groups int, fn func(values []string, events uint64, compliance float64)) error {
+	rows, err := c.db.QueryContext(ctx, q.String(), q.args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		values := make([]string, groups)
+		var events uint64
+		var compliance float64
+		dest := make([]any, 0, len(cols))
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		dest = append(dest, &events)
+		if len(cols) > groups+1 {
+			dest = append(dest, &compliance)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		fn(values, events, compliance)
+	}
+	return rows.Err()
+}
+
+// Explain returns the SQL Compliance runs for the window.
+func (c *ClickHouse) Explain(def Definition, windowMinutes int, end time.Time) ([]Query, error) {
+	if def.Type != TypeRatio {
+		q, err := clickhouseComplianceSQL(def, windowMinutes, end)
+		if err != nil {
+			return nil, err
+		}
+		return []Query{{Name: "compliance", Text: q.String(), Args: q.args}}, nil
+	}
+	var out []Query
+	for _, part := range []struct {
+		name string
+		sel  *SpanSelector
+	}{{"total", def.Total}, {"good", def.Good}, {"bad", def.Bad}} {
+		if part.sel == nil {
+			continue
+		}
+		q, err := clickhouseCountSQL(*part.sel, def.GroupBy, windowMinutes, end)
+		if err != nil {
+			return nil, fmt.Errorf("%s query: %w", part.name, err)
+		}
+		out = append(out, Query{Name: part.name, Text: q.String(), Args: q.args})
+	}
+	return out, nil
+}
+
+// chQuery is a ClickHouse statement with positional ? arguments. Values from SLO definitions are
+// only ever passed as arguments, never spliced into the SQL text.
+type chQuery struct {
+	sql  strings.Builder
+	args []any
+}
+
+func (q *chQuery) write(sql string, args ...any) {
+	q.sql.WriteString(sql)
+	q.args = append(q.args, args...)
+}
+
+func (q *chQuery) String() string {
+	return q.sql.String()
+}
+
+// windowSQL restricts spans to the trailing window. Windows ending at a fixed time, as in a replay,
+// exclude spans at that instant.
+func windowSQL(q *chQuery, indent string, windowMinutes int, end time.Time) {
+	if end.IsZero() {
+		q.write(indent+"WHERE Timestamp >= now() - INTERVAL ? MINUTE", windowMinutes)
+		return
+	}
+	q.write(indent+"WHERE Timestamp >= ? - INTERVAL ? MINUTE AND Timestamp < ?", end, windowMinutes, end)
+}
+
+func clickhouseCountSQL(sel SpanSelector, groupBy []string, windowMinutes int, end time.Time) (*chQuery, error) {
+	q := &chQuery{}
+	q.write("SELECT ")
+	if err := groupColumnsSQL(q, groupBy, ""); err != nil {
+		return nil, err
+	}
+	q.write(`count() AS events
+FROM otel_traces
+`)
+	windowSQL(q, "", windowMinutes, end)
+	if err := spanFilterSQL(q, sel); err != nil {
+		return nil, err
+	}
+	groupBySQL(q, "", len(groupBy), "")
+	return q, nil
+}
+
+// groupColumnsSQL selects each group-by attribute as group_<i>, ahead of the aggregates.
+func groupColumnsSQL(q *chQuery, groupBy []string, sep string) error {
+	for i, key := range groupBy {
+		if err := ValidateAttributeKey(key); err != nil {
+			return err
+		}
+		q.write(fmt.Sprintf("SpanAttributes[?] AS group_%d,%s", i, sep), key)
+	}
+	return nil
+}
+
+// groupBySQL groups by the group_<i> columns, followed by any extra grouping expression.
+func groupBySQL(q *chQuery, indent string, groups int, extra string, args ...any) {
+	cols := make([]string, 0, groups+1)
+	for i := 0; i < groups; i++ {
+		cols = append(cols, fmt.Sprintf("group_%d", i))
+	}
+	if extra != "" {
+		cols = append(cols, extra)
+	}
+	if len(cols) > 0 {
+		q.write("\n"+indent+"GROUP BY "+strings.Join(cols, ", "), args...)
+	}
+}
+
+func clickhouseComplianceSQL(def Definition, windowMinutes int, end time.Time) (*chQuery, error) {
+	if def.BudgetingMethod == BudgetingTimeslices {
+		return clickhouseTimeslicesSQL(def, windowMinutes, end)
+	}
+	return clickhouseOccurrencesSQL(def, windowMinutes, end)
+}
+
+// clickhouseOccurrencesSQL scores every span: good when its latency compares to the threshold
+// as Op says (latency), or unless it failed with a 5xx status (error_rate).
+func clickhouseOccurrencesSQL(def Definition, windowMinutes int, end time.Time) (*chQuery, error) {
+	bad, err := badValueComparison(def.Op)
+	if err != nil {
+		return nil, err
+	}
+	q := &chQuery{}
+	q.write("SELECT\n  ")
+	if err := groupColumnsSQL(q, def.GroupBy, "\n  "); err != nil {
+		return nil, err
+	}
+	if def.Type == TypeLatency {
+		q.write(`count() AS events,
+  if(count() = 0, 1.0, 1 - (countIf(Duration / 1000000 `+bad+` ?) / count())) AS compliance`, def.Threshold)
+	} else {
+		q.write(`count() AS events,
+  if(count() = 0, 1.0, 1 - (countIf(toInt32OrZero(SpanAttributes['http.status_code']) >= 500) / count())) AS compliance`)
+	}
+	q.write(`
+FROM otel_traces
+`)
+	windowSQL(q, "", windowMinutes, end)
+	if err := spanFilterSQL(q, def.SpanSelector); err != nil {
+		return nil, err
+	}
+	groupBySQL(q, "", len(def.GroupBy), "")
+	return q, nil
+}
+
+// clickhouseTimeslicesSQL scores fixed slices: a slice is bad when its latency quantile or
+// error rate fails the threshold under Op. Slices with fewer than MinSliceEvents spans are not scored.
+func clickhouseTimeslicesSQL(def Definition, windowMinutes int, end time.Time) (*chQuery, error) {
+	slice := def.TimeSliceMinutes
+	if slice <= 0 {
+		slice = 1
+	}
+	minSliceEvents := def.MinSliceEvents
+	if minSliceEvents <= 0 {
+		minSliceEvents = 1
+	}
+	bad, err := badValueComparison(def.Op)
+	if err != nil {
+		return nil, err
+	}
+	outerGroups := ""
+	for i := range def.GroupBy {
+		outerGroups += fmt.Sprintf("group_%d,\n  ", i)
+	}
+	q := &chQuery{}
+	if def.Type == TypeLatency {
+		quantile := def.TimeSliceQuantile
+		if quantile <= 0 || quantile >= 1 {
+			quantile = 0.99
+		}
+		q.write(`SELECT
+  `+outerGroups+`sum(slice_events) AS events,
+  if(count() = 0, 1.0, 1 - (countIf(slice_ms `+bad+` ?) / count())) AS compliance
+FROM (
+  SELECT `, def.Threshold)
+		if err := groupColumnsSQL(q, def.GroupBy, " "); err != nil {
+			return nil, err
+		}
+		// Aggregate-function parameters must be literals; the level is a range-checked float.
+		q.write(fmt.Sprintf(`count() AS slice_events, quantile(%s)(Duration / 1000000) AS slice_ms`, strconv.FormatFloat(quantile, 'g', -1, 64)))
+	} else {
+		q.write(`SELECT
+  `+outerGroups+`sum(slice_events) AS events,
+  if(count() = 0, 1.0, 1 - (countIf(err_rate `+bad+` ?) / count())) AS compliance
+FROM (
+  SELECT `, def.Threshold)
+		if err := groupColumnsSQL(q, def.GroupBy, " "); err != nil {
+			return nil, err
+		}
+		q.write(`count() AS slice_events, countIf(toInt32OrZero(SpanAttributes['http.status_code']) >= 500) / count() AS err_rate`)
+	}
+	q.write(`
+  FROM otel_traces
+`)
+	windowSQL(q, "  ", windowMinutes, end)
+	if err := spanFilterSQL(q, def.SpanSelector); err != nil {
+		return nil, err
+	}
+	groupBySQL(q, "  ", len(def.GroupBy), "toStartOfInterval(Timestamp, INTERVAL ? minute)", slice)
+	q.write(`
+  HAVING slice_events >= ?
+)`, minSliceEvents)
+	groupBySQL(q, "", len(def.GroupBy), "")
+	return q, nil
+}
+
+// spanFilterSQL appends a span selector as AND clauses to the query's WHERE.
+func spanFilterSQL(q *chQuery, def SpanSelector) error {
+	and := func(clause string, args ...any) {
+		q.write("\n    AND "+clause, args...)
+	}
+	if def.Route != "" {
+		and("SpanAttributes['http.route'] = ?", def.Route)
+	}
+	if def.ServiceName != "" {
+		and("ServiceName = ?", def.ServiceName)
+	}
+	if def.SpanKind != "" {
+		kind := strings.ToLower(def.SpanKind)
+		and("lower(SpanKind) IN (?, ?)", kind, "span_kind_"+kind)
+	}
+	if def.SpanName != "" {
+		and("SpanName = ?", def.SpanName)
+	}
+	for _, am := range def.Attributes {
+		if len(am.Values) == 0 {
+			continue
+		}
+		if err := ValidateAttributeKey(am.Key); err != nil {
+			return err
+		}
+		switch am.Op {
+		case MatchNotEquals:
+			and("SpanAttributes[?] != ?", am.Key, am.Values[0])
+		case MatchIn:
+			placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(am.Values)), ", ")
+			args := []any{am.Key}
+			for _, v := range am.Values {
+				args = append(args, v)
+			}
+			and("SpanAttributes[?] IN ("+placeholders+")", args...)
+		case MatchRegex:
+			and("match(SpanAttributes[?], ?)", am.Key, am.Values[0])
+		default:
+			and("SpanAttributes[?] = ?", am.Key, am.Values[0])
+		}
+	}
+	return nil
+}
+
+var attributeKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.\-/]*$`)
+
+// ValidateAttributeKey accepts OpenTelemetry-style attribute keys (for example
+// "http.request.method" or "k8s.pod.name").
+func ValidateAttributeKey(key string) error {
+	if len(key) > 255 || !attributeKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid span attribute key %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package sli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DatasourcePrometheus = "prometheus"
+
+// WindowPlaceholder is substituted in good/total PromQL with the evaluation window (for example "60m").
+const WindowPlaceholder = "{{.window}}"
+
+// Prometheus measures SLIs from PromQL. Only instant queries (/api/v1/query) are supported: each
+// query is evaluated once at the window's end and must aggregate the window itself through
+// {{.window}}, for example sum(increase(http_requests_total[{{.window}}])). Range queries are not.
+type Prometheus struct {
+	defaultURL string
+	http       *http.Client
+}
+
+func NewPrometheus(defaultURL string, timeout time.Duration) *Prometheus {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &Prometheus{
+		defaultURL: strings.TrimRight(defaultURL, "/"),
+		http:       &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *Prometheus) Close() error {
+	p.http.CloseIdleConnections()
+	return nil
+}
+
+// target resolves the datasource URL and query time shared by the good/bad and total queries.
+func (p *Prometheus) target(def Definition, end time.Time) (string, time.Time, error) {
+	baseURL := strings.TrimRight(def.DatasourceURL, "/")
+	if baseURL == "" {
+		baseURL = p.defaultURL
+	}
+	if baseURL == "" {
+		return "", time.Time{}, fmt.Errorf("prometheus sli for slo %s has no datasource url", def.SLOID)
+	}
+	if def.TotalQuery == "" || (def.GoodQuery == "") == (def.BadQuery == "") {
+		return "", time.Time{}, fmt.Errorf("prometheus sli for slo %s requires totalQuery and one of goodQuery or badQuery", def.SLOID)
+	}
+	if end.IsZero() {
+		end = time.Now().UTC()
+	}
+	return baseURL, end, nil
+}
+
+func (p *Prometheus) Compliance(ctx context.Context, def Definition, windowMinutes int, end time.Time) (Sample, error) {
+	baseURL, at, err := p.target(def, end)
+	if err != nil {
+		return Sample{}, err
+	}
+	total, err := p.instantQuery(ctx, baseURL, expandWindow(def.TotalQuery, windowMinutes), at)
+	if err != nil {
+		return Sample{}, fmt.Errorf("total query: %w", err)
+	}
+	if def.GoodQuery != "" {
+		good, err := p.instantQuery(ctx, baseURL, expandWindow(def.GoodQuery, windowMinutes), at)
+		if err != nil {
+			return Sample{}, fmt.Errorf("good query: %w", err)
+		}
+		return Sample{Compliance: ratioCompliance(good, total), Events: total}, nil
+	}
+	bad, err := p.instantQuery(ctx, baseURL, expandWindow(def.BadQuery, windowMinutes), at)
+	if err != nil {
+		return Sample{}, fmt.Errorf("bad query: %w", err)
+	}
+	return Sample{Compliance: badRatioCompliance(bad, total), Events: total}, nil
+}
+
+// GroupedCompliance sums each query's series per combination of the GroupBy label values, so the
+// queries must keep those labels, for example with sum by (...).
+func (p *Prometheus) GroupedCompliance(ctx context.Context, def Definition, windowMinutes int, end time.Time) ([]GroupSample, error) {
+	if len(def.GroupBy) == 0 {
+		return nil, fmt.Errorf("prometheus sli for slo %s has no groupBy labels", def.SLOID)
+	}
+	baseURL, at, err := p.target(def, end)
+	if err != nil {
+		return nil, err
+	}
+	grouped := func(query string) (map[string]GroupSample, error) {
+		data, err := p.query(ctx, baseURL, expandWindow(query, windowMinutes), at)
+		if err != nil {
+			return nil, err
+		}
+		return data.sumBy(def.GroupBy)
+	}
+	totals, err := grouped(def.TotalQuery)
+	if err != nil {
+		return nil, fmt.Errorf("total query: %w", err)
+	}
+	name, query, score := "good", def.GoodQuery, ratioCompliance
+	if query == "" {
+		name, query, score = "bad", def.BadQuery, badRatioCompliance
+	}
+	counts, err := grouped(query)
+	if err != nil {
+		return nil, fmt.Errorf("%s query: %w", name, err)
+	}
+	out := make([]GroupSample, 0, len(totals))
+	for _, g := range totals {
+		out = append(out, GroupSample{Dimension: g.Dimension, Sample: Sample{Compliance: score(counts[g.Key].Events, g.Events), Events: g.Events}})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+// Explain returns the PromQL Compliance runs for the window.
+func (p *Prometheus) Explain(def Definition, windowMinutes int, _ time.Time) ([]Query, error) {
+	var out []Query
+	for _, part := range []struct{ name, query string }{{"total", def.TotalQuery}, {"good", def.GoodQuery}, {"bad", def.BadQuery}} {
+		if part.query != "" {
+			out = append(out, Query{Name: part.name, Text: expandWindow(part.query, windowMinutes)})
+		}
+	}
+	return out, nil
+}
+
+func (p *Prometheus) instantQuery(ctx context.Context, baseURL, query string, at time.Time) (float64, error) {
+	data, err := p.query(ctx, baseURL, query, at)
+	if err != nil {
+		return 0, err
+	}
+	return data.sum()
+}
+
+func (p *Prometheus) query(ctx context.Context, baseURL, query string, at time.Time) (promData, error) {
+	form := url.Values{}
+	form.Set("query", query)
+	form.Set("time", strconv.FormatFloat(float64(at.UnixMilli())/1000, 'f', 3, 64))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/api/v1/query", strings.NewReader(form.Encode()))
+	if err != nil {
+		return promData{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return promData{}, err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	var out promResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return promData{}, fmt.Errorf("prometheus api status=%d: invalid response: %w", resp.StatusCode, err)
+	}
+	if out.Status != "success" {
+		return promData{}, fmt.Errorf("prometheus api status=%d error_type=%s: %s", resp.StatusCode, out.ErrorType, out.Error)
+	}
+	return out.Data, nil
+}
+
+type promResponse struct {
+	Status    string   `json:"status"`
+	ErrorType string   `json:"errorType"`
+	Error     string   `json:"error"`
+	Data      promData `json:"data"`
+}
+
+type promData struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// sum collapses an instant query result to one number; vectors are summed across series.
+func (d promData) sum() (float64, error) {
+	switch d.ResultType {
+	case "scalar":
+		var sample []any
+		if err := json.Unmarshal(d.Result, &sample); err != nil {
+			return 0, err
+		}
+		return sampleValue(sample)
+	case "vector":
+		var series []struct {
+			Value []any `json:"value"`
+		}
+		if err := json.Unmarshal(d.Result, &series); err != nil {
+			return 0, err
+		}
+		total := 0.0
+		for _, s := range series {
+			v, err := sampleValue(s.Value)
+			if err != nil {
+				return 0, err
+			}
+			total += v
+		}
+		return total, nil
+	default:
+		return 0, fmt.Errorf("unsupported prometheus result type %q", d.ResultType)
+	}
+}
+
+// sumBy sums a vector result per combination of the given label values, keeping the values as the
+// group's events.
+func (d promData) sumBy(labels []string) (map[string]GroupSample, error) {
+	if d.ResultType != "vector" {
+		return nil, fmt.Errorf("grouped prometheus query must return a vector, got %q", d.ResultType)
+	}
+	var series []struct {
+		Metric map[string]string `json:"metric"`
+		Value  []any             `json:"value"`
+	}
+	if err := json.Unmarshal(d.Result, &series); err != nil {
+		return nil, err
+	}
+	out := map[string]GroupSample{}
+	for _, s := range series {
+		v, err := sampleValue(s.Value)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, len(labels))
+		for i, l := range labels {
+			values[i] = s.Metric[l]
+		}
+		dim := NewDimension(labels, values)
+		g := out[dim.Key]
+		g.Dimension = dim
+		g.Events += v
+		out[dim.Key] = g
+	}
+	return out, nil
+}
+
+func sampleValue(sample []any) (float64, error) {
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("invalid prometheus sample %v", sample)
+	}
+	raw, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid prometheus sample value %v", sample[1])
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, err
+	}
+	// Prometheus returns NaN and ±Inf for things like a division by zero; they are not event counts.
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("prometheus sample value %s is not a finite number", raw)
+	}
+	return v, nil
+}
+
+func expandWindow(query string, windowMinutes int) string {
+	return strings.ReplaceAll(query, WindowPlaceholder, fmt.Sprintf("%dm", windowMinutes))
+}
@@ -0,0 +1,141 @@
+package sli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusComplianceFromGoodTotal(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		q := r.FormValue("query")
+		queries = append(queries, q)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(q, "good") {
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"pod":"a"},"value":[1700000000,"60"]},{"metric":{"pod":"b"},"value":[1700000000,"30"]}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1700000000,"100"]}}`))
+	}))
+	defer srv.Close()
+
+	p := NewPrometheus("", time.Second)
+	got, err := p.Compliance(context.Background(), Definition{
+		DatasourceURL: srv.URL,
+		GoodQuery:     "good[{{.window}}]",
+		TotalQuery:    "total[{{.window}}]",
+	}, 60, time.Time{})
+	if err != nil {
+		t.Fatalf("Compliance() error = %v", err)
+	}
+	if got.Compliance != 0.9 || got.Events != 100 {
+		t.Fatalf("expected compliance 0.9 over 100 events, got %+v", got)
+	}
+	if len(queries) != 2 || queries[0] != "total[60m]" || queries[1] != "good[60m]" {
+		t.Fatalf("unexpected queries %v", queries)
+	}
+}
+
+func TestPrometheusComplianceNoTrafficAndErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.FormValue("query"), "bad") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+	}))
+	defer srv.Close()
+
+	p := NewPrometheus(srv.URL, time.Second)
+	got, err := p.Compliance(context.Background(), Definition{GoodQuery: "good", TotalQuery: "total"}, 5, time.Time{})
+	if err != nil || got.Compliance != 1.0 || got.Sufficient(1) {
+		t.Fatalf("expected insufficient sample with no traffic, got %+v err=%v", got, err)
+	}
+	if _, err := p.Compliance(context.Background(), Definition{GoodQuery: "bad", TotalQuery: "total"}, 5, time.Time{}); err == nil || !strings.Contains(err.Error(), "bad_data") {
+		t.Fatalf("expected prometheus api error, got %v", err)
+	}
+	if _, err := p.Compliance(context.Background(), Definition{GoodQuery: "good", BadQuery: "bad", TotalQuery: "total"}, 5, time.Time{}); err == nil {
+		t.Fatalf("expected error when both good and bad queries are set")
+	}
+	if _, err := NewPrometheus("", time.Second).Compliance(context.Background(), Definition{GoodQuery: "good", TotalQuery: "total"}, 5, time.Time{}); err == nil {
+		t.Fatalf("expected error without datasource url")
+	}
+}
+
+func TestPrometheusComplianceFromBadTotal(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := "200"
+		if strings.HasPrefix(r.FormValue("query"), "bad") {
+			value = "10"
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1700000000,"` + value + `"]}}`))
+	}))
+	defer srv.Close()
+
+	got, err := NewPrometheus(srv.URL, time.Second).Compliance(context.Background(), Definition{BadQuery: "bad", TotalQuery: "total"}, 5, time.Time{})
+	if err != nil {
+		t.Fatalf("Compliance() error = %v", err)
+	}
+	if got.Compliance != 0.95 {
+		t.Fatalf("expected compliance 0.95, got %v", got.Compliance)
+	}
+}
+
+func TestPrometheusComplianceRejectsNonFiniteValues(t *testing.T) {
+	for _, value := range []string{"NaN", "+Inf", "-Inf"} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.FormValue("query"), "total") {
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1700000000,"` + value + `"]}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"5"]}]}}`))
+		}))
+		_, err := NewPrometheus(srv.URL, time.Second).Compliance(context.Background(), Definition{GoodQuery: "good", TotalQuery: "total"}, 5, time.Time{})
+		srv.Close()
+		if err == nil || !strings.Contains(err.Error(), "not a finite number") {
+			t.Fatalf("expected total %s to be rejected, got %v", value, err)
+		}
+	}
+}
+
+func TestPrometheusExplainExpandsWindow(t *testing.T) {
+	got, err := NewPrometheus("", time.Second).Explain(Definition{
+		TotalQuery: `sum(increase(http_requests_total[{{.window}}]))`,
+		BadQuery:   `sum(increase(http_requests_total{code=~"5.."}[{{.window}}]))`,
+	}, 60, time.Time{})
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Name != "total" || got[1].Name != "bad" || !strings.Contains(got[0].Text, "[60m]") {
+		t.Fatalf("unexpected prometheus queries: %+v", got)
+	}
+}
+
+func TestPrometheusGroupedCompliance(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.FormValue("query"), "good") {
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"region":"eu"},"value":[1700000000,"45"]},{"metric":{"region":"us"},"value":[1700000000,"10"]}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"region":"eu","pod":"a"},"value":[1700000000,"30"]},{"metric":{"region":"eu","pod":"b"},"value":[1700000000,"20"]},{"metric":{"region":"us"},"value":[1700000000,"40"]}]}}`))
+	}))
+	defer srv.Close()
+
+	p := NewPrometheus(srv.URL, time.Second)
+	got, err := p.GroupedCompliance(context.Background(), Definition{GoodQuery: "good", TotalQuery: "total", GroupBy: []string{"region"}}, 5, time.Time{})
+	if err != nil {
+		t.Fatalf("GroupedCompliance() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Key != "region=eu" || got[0].Events != 50 || got[0].Compliance != 0.9 ||
+		got[1].Key != "region=us" || got[1].Events != 40 || got[1].Compliance != 0.25 {
+		t.Fatalf("unexpected groups: %+v", got)
+	}
+}
@@ -41,6 +41,7 @@ type AlertReconcileAttempt struct {
 
 type SLOReconcileInput struct {
 	SLO
+	OwnerTeamID     uuid.UUID
 	ServiceMetadata map[string]any
 	BurnState       *BurnState
 }
@@ -198,12 +199,13 @@ func (s *Store) ListSLOReconcileInputs(ctx context.Context) ([]SLOReconcileInput
 		SELECT
 			s.id, s.service_id, s.name, s.description, s.target, s.window_minutes, s.openslo_yaml,
 			s.canonical_json, s.datasource_type, s.datasource_uid, s.created_at, s.updated_at,
-			sv.metadata_json,
+			sv.owner_team_id, sv.metadata_json,
 			bs.slo_id, bs.is_burning, bs.current_severity, bs.current_compliance, bs.current_burn_rate,
 			bs.eta_exhaustion_seconds, bs.last_transition_at, bs.last_continued_at, bs.last_evaluated_at
 		FROM slos s
 		INNER JOIN services sv ON sv.id = s.service_id
-		LEFT JOIN slo_burn_state bs ON bs.slo_id = s.id
+		LEFT JOIN slo_burn_state bs ON bs.slo_id = s.id AND bs.dimension_key = ''
+			AND bs.objective = COALESCE(s.canonical_json->'objectives'->0->>'name', '')
 		ORDER BY s.created_at DESC
 	`)
 	if err != nil {
@@ -229,7 +231,7 @@ func (s *Store) ListSLOReconcileInputs(ctx context.Context) ([]SLOReconcileInput
 		if err := rows.Scan(
 			&in.ID, &in.ServiceID, &in.Name, &desc, &in.Target, &in.WindowMinutes, &in.OpenSLO,
 			&canonical, &in.DatasourceType, &in.DatasourceUID, &in.CreatedAt, &in.UpdatedAt,
-			&serviceMetadata,
+			&in.OwnerTeamID, &serviceMetadata,
 			&bsSLOID, &bsIsBurning, &bsSeverity, &bsCompliance, &bsBurnRate, &bsETA, &bsTransition, &bsContinued, &bsEvaluated,
 		); err != nil {
 			return nil, err
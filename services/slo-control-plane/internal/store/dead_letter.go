@@ -0,0 +1,160 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DeadLetterEvent is an outbox event whose delivery failed more often than the worker retries.
+type DeadLetterEvent struct {
+	OutboxEvent
+	LastError      string
+	CreatedAt      time.Time
+	DeadLetteredAt time.Time
+}
+
+type DeliveryAttempt struct {
+	AttemptNo   int
+	Error       string
+	AttemptedAt time.Time
+}
+
+const deadLetterColumns = `id, aggregate_type, aggregate_id, event_type, payload_json, retry_count, idempotency_key,
+	last_error, created_at, dead_lettered_at`
+
+func scanDeadLetterEvent(row rowScanner) (DeadLetterEvent, error) {
+	var ev DeadLetterEvent
+	var payload []byte
+	var lastError sql.NullString
+	var deadLetteredAt sql.NullTime
+	if err := row.Scan(
+		&ev.ID, &ev.AggregateType, &ev.AggregateID, &ev.EventType, &payload, &ev.RetryCount, &ev.IdempotencyKey,
+		&lastError, &ev.CreatedAt, &deadLetteredAt,
+	); err != nil {
+		return DeadLetterEvent{}, err
+	}
+	ev.Payload = decodeJSONMap(payload)
+	ev.LastError = nullStringToString(lastError)
+	if deadLetteredAt.Valid {
+		ev.DeadLetteredAt = deadLetteredAt.Time
+	}
+	return ev, nil
+}
+
+func (s *Store) ListDeadLetterOutbox(ctx context.Context, page, pageSize int) ([]DeadLetterEvent, Pagination, error) {
+	ctx, span := s.startSpan(ctx, "store.list_dead_letter_outbox")
+	defer span.End()
+	listSQL := `
+		SELECT ` + deadLetterColumns + `
+		FROM outbox_events
+		WHERE status = 'dead_letter'
+		ORDER BY dead_lettered_at DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, total, err := paginatedQueryWithArgs(
+		s.db,
+		ctx,
+		listSQL,
+		"SELECT count(*) FROM outbox_events WHERE status = 'dead_letter'",
+		page,
+		pageSize,
+		nil,
+		func(rows *sql.Rows) (DeadLetterEvent, error) {
+			return scanDeadLetterEvent(rows)
+		},
+	)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+	return rows, Pagination{Page: page, PageSize: pageSize, Total: total}, nil
+}
+
+func (s *Store) GetDeadLetterOutbox(ctx context.Context, id uuid.UUID) (DeadLetterEvent, error) {
+	ctx, span := s.startSpan(ctx, "store.get_dead_letter_outbox", attribute.String("outbox.id", id.String()))
+	defer span.End()
+	return scanDeadLetterEvent(s.db.QueryRowContext(ctx, `
+		SELECT `+deadLetterColumns+`
+		FROM outbox_events
+		WHERE id = $1 AND status = 'dead_letter'
+	`, id))
+}
+
+// ListOutboxDeliveryAttempts returns the failed delivery attempts of an outbox event, oldest first.
+func (s *Store) ListOutboxDeliveryAttempts(ctx context.Context, id uuid.UUID) ([]DeliveryAttempt, error) {
+	ctx, span := s.startSpan(ctx, "store.list_outbox_delivery_attempts", attribute.String("outbox.id", id.String()))
+	defer span.End()
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT attempt_no, error_text, attempted_at
+		FROM burn_event_delivery_attempts
+		WHERE outbox_event_id = $1
+		ORDER BY attempted_at ASC, attempt_no ASC
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []DeliveryAttempt
+	for rows.Next() {
+		var a DeliveryAttempt
+		if err := rows.Scan(&a.AttemptNo, &a.Error, &a.AttemptedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// RequeueDeadLetterOutbox makes a dead-lettered event pending again with a fresh retry budget. Its
+// attempt history is kept, and later attempts continue its numbering.
+func (s *Store) RequeueDeadLetterOutbox(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.startSpan(ctx, "store.requeue_dead_letter_outbox", attribute.String("outbox.id", id.String()))
+	defer span.End()
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE outbox_events
+		SET status = 'pending', retry_count = 0, next_attempt_at = now(), dead_lettered_at = NULL, updated_at = now()
+		WHERE id = $1 AND status = 'dead_letter'
+	`, id)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (s *Store) PurgeDeadLetterOutbox(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.startSpan(ctx, "store.purge_dead_letter_outbox", attribute.String("outbox.id", id.String()))
+	defer span.End()
+	res, err := s.db.ExecContext(ctx, `DELETE FROM outbox_events WHERE id = $1 AND status = 'dead_letter'`, id)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// PurgeDeadLetterOutboxBefore deletes the events dead-lettered before the given time, or all of
+// them when it is zero, and returns how many it deleted.
+func (s *Store) PurgeDeadLetterOutboxBefore(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := s.startSpan(ctx, "store.purge_dead_letter_outbox_before")
+	defer span.End()
+	var cutoff any
+	if !before.IsZero() {
+		cutoff = before
+	}
+	res, err := s.db.ExecContext(ctx, `
+		DELETE FROM outbox_events
+		WHERE status = 'dead_letter' AND ($1::timestamptz IS NULL OR dead_lettered_at < $1)
+	`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
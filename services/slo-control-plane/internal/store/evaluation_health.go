@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	EvaluationStatusOK     = "ok"
+	EvaluationStatusFailed = "failed"
+)
+
+type EvaluationHealth struct {
+	SLOID               uuid.UUID
+	Status              string
+	LastAttemptAt       time.Time
+	LastSuccessAt       sql.NullTime
+	LastErrorAt         sql.NullTime
+	LastError           string
+	LastErrorStage      string
+	ConsecutiveFailures int
+	LastDurationMs      int
+	LastQueryDurationMs int
+}
+
+// EvaluationOutcome is one evaluator attempt for an SLO; a non-empty Error marks it failed.
+type EvaluationOutcome struct {
+	SLOID           uuid.UUID
+	AttemptedAt     time.Time
+	Error           string
+	ErrorStage      string
+	DurationMs      int
+	QueryDurationMs int
+}
+
+type EvaluationHealthSummary struct {
+	SLOCount        int
+	FailingCount    int
+	StaleCount      int
+	LastEvaluatedAt sql.NullTime
+}
+
+func (s *Store) RecordEvaluation(ctx context.Context, o EvaluationOutcome) error {
+	ctx, span := s.startSpan(ctx, "store.record_evaluation", attribute.String("slo.id", o.SLOID.String()), attribute.Bool("evaluation.failed", o.Error != ""))
+	defer span.End()
+	if o.Error == "" {
+		_, err := s.db.ExecContext(ctx, `
+			INSERT INTO slo_evaluation_health (
+				slo_id, status, last_attempt_at, last_success_at, consecutive_failures, last_duration_ms, last_query_duration_ms, updated_at
+			) VALUES ($1, $2, $3, $3, 0, $4, $5, now())
+			ON CONFLICT (slo_id) DO UPDATE SET
+				status = EXCLUDED.status,
+				last_attempt_at = EXCLUDED.last_attempt_at,
+				last_success_at = EXCLUDED.last_success_at,
+				consecutive_failures = 0,
+				last_duration_ms = EXCLUDED.last_duration_ms,
+				last_query_duration_ms = EXCLUDED.last_query_duration_ms,
+				updated_at = now()
+		`, o.SLOID, EvaluationStatusOK, o.AttemptedAt, o.DurationMs, o.QueryDurationMs)
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO slo_evaluation_health (
+			slo_id, status, last_attempt_at, last_error_at, last_error, last_error_stage, consecutive_failures, last_duration_ms, last_query_duration_ms, updated_at
+		) VALUES ($1, $2, $3, $3, $4, $5, 1, $6, $7, now())
+		ON CONFLICT (slo_id) DO UPDATE SET
+			status = EXCLUDED.status,
+			last_attempt_at = EXCLUDED.last_attempt_at,
+			last_error_at = EXCLUDED.last_error_at,
+			last_error = EXCLUDED.last_error,
+			last_error_stage = EXCLUDED.last_error_stage,
+			consecutive_failures = slo_evaluation_health.consecutive_failures + 1,
+			last_duration_ms = EXCLUDED.last_duration_ms,
+			last_query_duration_ms = EXCLUDED.last_query_duration_ms,
+			updated_at = now()
+	`, o.SLOID, EvaluationStatusFailed, o.AttemptedAt, o.Error, o.ErrorStage, o.DurationMs, o.QueryDurationMs)
+	return err
+}
+
+func (s *Store) GetEvaluationHealth(ctx context.Context, sloID uuid.UUID) (EvaluationHealth, bool, error) {
+	ctx, span := s.startSpan(ctx, "store.get_evaluation_health", attribute.String("slo.id", sloID.String()))
+	defer span.End()
+	var h EvaluationHealth
+	var lastErr, lastStage sql.NullString
+	err := s.db.QueryRowContext(ctx, `
+		SELECT slo_id, status, last_attempt_at, last_success_at, last_error_at, last_error, last_error_stage,
+		       consecutive_failures, last_duration_ms, last_query_duration_ms
+		FROM slo_evaluation_health
+		WHERE slo_id = $1
+	`, sloID).Scan(
+		&h.SLOID, &h.Status, &h.LastAttemptAt, &h.LastSuccessAt, &h.LastErrorAt, &lastErr, &lastStage,
+		&h.ConsecutiveFailures, &h.LastDurationMs, &h.LastQueryDurationMs,
+	)
+	if err == sql.ErrNoRows {
+		return EvaluationHealth{}, false, nil
+	}
+	if err != nil {
+		return EvaluationHealth{}, false, err
+	}
+	h.LastError = nullStringToString(lastErr)
+	h.LastErrorStage = nullStringToString(lastStage)
+	return h, true, nil
+}
+
+// SummarizeEvaluationHealth counts SLOs whose latest attempt failed and SLOs with no attempt
+// since staleAfter ago (including never-evaluated ones).
+func (s *Store) SummarizeEvaluationHealth(ctx context.Context, staleAfter time.Duration) (EvaluationHealthSummary, error) {
+	ctx, span := s.startSpan(ctx, "store.summarize_evaluation_health")
+	defer span.End()
+	var out EvaluationHealthSummary
+	err := s.db.QueryRowContext(ctx, `
+		SELECT
+			count(*),
+			count(*) FILTER (WHERE h.consecutive_failures > 0),
+			count(*) FILTER (WHERE h.last_attempt_at IS NULL OR h.last_attempt_at < now() - make_interval(secs => $1)),
+			max(h.last_attempt_at)
+		FROM slos s
+		LEFT JOIN slo_evaluation_health h ON h.slo_id = s.id
+	`, staleAfter.Seconds()).Scan(&out.SLOCount, &out.FailingCount, &out.StaleCount, &out.LastEvaluatedAt)
+	return out, err
+}
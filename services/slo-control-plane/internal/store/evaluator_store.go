@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,19 +11,37 @@ import (
 )
 
 type BurnState struct {
-	SLOID              uuid.UUID
+	SLOID uuid.UUID
+	// Objective is the objective's name, empty for an unnamed objective.
+	Objective string
+	// DimensionKey identifies the group-by dimension of a grouped SLO; it is empty otherwise.
+	DimensionKey       string
+	Dimensions         map[string]string
 	IsBurning          bool
 	IsBreached         bool
+	InsufficientData   bool
+	FiringHistory      int
 	CurrentSeverity    string
 	CurrentCompliance  float32
 	CurrentBurnRate    float32
+	CurrentEvents      float64
 	ETAExhaustionSec   sql.NullInt32
+	ETALowerSec        sql.NullInt32
+	ETAUpperSec        sql.NullInt32
+	BudgetRemaining    float32
+	BudgetSamples      []BudgetSample
 	LastTransitionAt   sql.NullTime
 	BreachTransitionAt sql.NullTime
 	LastContinuedAt    sql.NullTime
 	LastEvaluatedAt    time.Time
 }
 
+// BudgetSample is one evaluation's remaining error budget, kept for exhaustion forecasting.
+type BudgetSample struct {
+	At        time.Time `json:"at"`
+	Remaining float64   `json:"remaining"`
+}
+
 func (s *Store) ListAllSLOs(ctx context.Context) ([]SLO, error) {
 	ctx, span := s.startSpan(ctx, "store.list_all_slos")
 	defer span.End()
@@ -58,19 +77,63 @@ func (s *Store) ListAllSLOs(ctx context.Context) ([]SLO, error) {
 	return result, nil
 }
 
-func (s *Store) GetBurnStateForUpdate(ctx context.Context, tx *sql.Tx, sloID uuid.UUID) (BurnState, bool, error) {
+const burnStateColumns = `slo_id, objective, dimension_key, dimensions, is_burning, is_breached, insufficient_data, firing_history, current_severity, current_compliance, current_burn_rate, current_events, eta_exhaustion_seconds, eta_exhaustion_lower_seconds, eta_exhaustion_upper_seconds, budget_remaining, budget_samples, last_transition_at, breach_transition_at, last_continued_at, last_evaluated_at`
+
+// GetBurnStateForUpdate locks the burn state of one objective and dimension; pass "" for an unnamed
+// objective or an ungrouped SLO.
+func (s *Store) GetBurnStateForUpdate(ctx context.Context, tx *sql.Tx, sloID uuid.UUID, objective, dimensionKey string) (BurnState, bool, error) {
 	ctx, span := s.startSpan(ctx, "store.get_burn_state_for_update", attribute.String("slo.id", sloID.String()))
 	defer span.End()
-	var st BurnState
-	st.SLOID = sloID
-
-	err := tx.QueryRowContext(ctx, `
-		SELECT slo_id, is_burning, is_breached, current_severity, current_compliance, current_burn_rate, eta_exhaustion_seconds, last_transition_at, breach_transition_at, last_continued_at, last_evaluated_at
+	return scanBurnState(tx.QueryRowContext(ctx, `
+		SELECT `+burnStateColumns+`
 		FROM slo_burn_state
-		WHERE slo_id = $1
+		WHERE slo_id = $1 AND objective = $2 AND dimension_key = $3
 		FOR UPDATE
-	`, sloID).Scan(
-		&st.SLOID, &st.IsBurning, &st.IsBreached, &st.CurrentSeverity, &st.CurrentCompliance, &st.CurrentBurnRate, &st.ETAExhaustionSec, &st.LastTransitionAt, &st.BreachTransitionAt, &st.LastContinuedAt, &st.LastEvaluatedAt,
+	`, sloID, objective, dimensionKey))
+}
+
+// GetBurnState reads the burn state without locking it, for callers that will not write it back.
+func (s *Store) GetBurnState(ctx context.Context, sloID uuid.UUID, objective, dimensionKey string) (BurnState, bool, error) {
+	ctx, span := s.startSpan(ctx, "store.get_burn_state", attribute.String("slo.id", sloID.String()))
+	defer span.End()
+	return scanBurnState(s.db.QueryRowContext(ctx, `
+		SELECT `+burnStateColumns+`
+		FROM slo_burn_state
+		WHERE slo_id = $1 AND objective = $2 AND dimension_key = $3
+	`, sloID, objective, dimensionKey))
+}
+
+// ListOpenBurnStates returns the SLO's burn states that are burning or breached, ordered by objective
+// and dimension key.
+func (s *Store) ListOpenBurnStates(ctx context.Context, sloID uuid.UUID) ([]BurnState, error) {
+	ctx, span := s.startSpan(ctx, "store.list_open_burn_states", attribute.String("slo.id", sloID.String()))
+	defer span.End()
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+burnStateColumns+`
+		FROM slo_burn_state
+		WHERE slo_id = $1 AND (is_burning OR is_breached)
+		ORDER BY objective, dimension_key
+	`, sloID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []BurnState
+	for rows.Next() {
+		st, _, err := scanBurnState(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, st)
+	}
+	return out, rows.Err()
+}
+
+func scanBurnState(row rowScanner) (BurnState, bool, error) {
+	var st BurnState
+	var samples, dimensions []byte
+	err := row.Scan(
+		&st.SLOID, &st.Objective, &st.DimensionKey, &dimensions, &st.IsBurning, &st.IsBreached, &st.InsufficientData, &st.FiringHistory, &st.CurrentSeverity, &st.CurrentCompliance, &st.CurrentBurnRate, &st.CurrentEvents, &st.ETAExhaustionSec, &st.ETALowerSec, &st.ETAUpperSec, &st.BudgetRemaining, &samples, &st.LastTransitionAt, &st.BreachTransitionAt, &st.LastContinuedAt, &st.LastEvaluatedAt,
 	)
 	if err == sql.ErrNoRows {
 		return BurnState{}, false, nil
@@ -78,9 +141,29 @@ func (s *Store) GetBurnStateForUpdate(ctx context.Context, tx *sql.Tx, sloID uui
 	if err != nil {
 		return BurnState{}, false, err
 	}
+	if len(samples) > 0 {
+		if err := json.Unmarshal(samples, &st.BudgetSamples); err != nil {
+			return BurnState{}, false, err
+		}
+	}
+	if len(dimensions) > 0 {
+		if err := json.Unmarshal(dimensions, &st.Dimensions); err != nil {
+			return BurnState{}, false, err
+		}
+	}
 	return st, true, nil
 }
 
+func (s *Store) DeleteBurnStateTx(ctx context.Context, tx *sql.Tx, sloID uuid.UUID, objective, dimensionKey string) error {
+	ctx, span := s.startSpan(ctx, "store.delete_burn_state", attribute.String("slo.id", sloID.String()))
+	defer span.End()
+	_, err := tx.ExecContext(ctx, `
+		DELETE FROM slo_burn_state
+		WHERE slo_id = $1 AND objective = $2 AND dimension_key = $3
+	`, sloID, objective, dimensionKey)
+	return err
+}
+
 func (s *Store) UpsertBurnStateTx(ctx context.Context, tx *sql.Tx, st BurnState) error {
 	ctx, span := s.startSpan(ctx, "store.upsert_burn_state", attribute.String("slo.id", st.SLOID.String()))
 	defer span.End()
@@ -96,23 +179,54 @@ func (s *Store) UpsertBurnStateTx(ctx context.Context, tx *sql.Tx, st BurnState)
 	if st.ETAExhaustionSec.Valid {
 		etaExhaustion = st.ETAExhaustionSec.Int32
 	}
+	samples := st.BudgetSamples
+	if samples == nil {
+		samples = []BudgetSample{}
+	}
+	samplesJSON, err := json.Marshal(samples)
+	if err != nil {
+		return err
+	}
+	dimensions := st.Dimensions
+	if dimensions == nil {
+		dimensions = map[string]string{}
+	}
+	dimensionsJSON, err := json.Marshal(dimensions)
+	if err != nil {
+		return err
+	}
 
-	_, err := tx.ExecContext(ctx, `
+	_, err = tx.ExecContext(ctx, `
 		INSERT INTO slo_burn_state (
-			slo_id, is_burning, is_breached, current_severity, current_compliance, current_burn_rate, eta_exhaustion_seconds, last_transition_at, breach_transition_at, last_continued_at, last_evaluated_at, updated_at
-		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,now())
-		ON CONFLICT (slo_id) DO UPDATE
-		SET is_burning = EXCLUDED.is_burning,
+			slo_id, objective, dimension_key, dimensions, is_burning, is_breached, insufficient_data, firing_history, current_severity, current_compliance, current_burn_rate, current_events, eta_exhaustion_seconds, eta_exhaustion_lower_seconds, eta_exhaustion_upper_seconds, budget_remaining, budget_samples, last_transition_at, breach_transition_at, last_continued_at, last_evaluated_at, updated_at
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,now())
+		ON CONFLICT (slo_id, objective, dimension_key) DO UPDATE
+		SET dimensions = EXCLUDED.dimensions,
+		    is_burning = EXCLUDED.is_burning,
 		    is_breached = EXCLUDED.is_breached,
+		    insufficient_data = EXCLUDED.insufficient_data,
+		    firing_history = EXCLUDED.firing_history,
 		    current_severity = EXCLUDED.current_severity,
 		    current_compliance = EXCLUDED.current_compliance,
 		    current_burn_rate = EXCLUDED.current_burn_rate,
+		    current_events = EXCLUDED.current_events,
 		    eta_exhaustion_seconds = EXCLUDED.eta_exhaustion_seconds,
+		    eta_exhaustion_lower_seconds = EXCLUDED.eta_exhaustion_lower_seconds,
+		    eta_exhaustion_upper_seconds = EXCLUDED.eta_exhaustion_upper_seconds,
+		    budget_remaining = EXCLUDED.budget_remaining,
+		    budget_samples = EXCLUDED.budget_samples,
 		    last_transition_at = EXCLUDED.last_transition_at,
 		    breach_transition_at = EXCLUDED.breach_transition_at,
 		    last_continued_at = EXCLUDED.last_continued_at,
 		    last_evaluated_at = EXCLUDED.last_evaluated_at,
 		    updated_at = now()
-	`, st.SLOID, st.IsBurning, st.IsBreached, st.CurrentSeverity, st.CurrentCompliance, st.CurrentBurnRate, etaExhaustion, lastTransition, nullableTime(st.BreachTransitionAt), lastContinued, st.LastEvaluatedAt)
+	`, st.SLOID, st.Objective, st.DimensionKey, dimensionsJSON, st.IsBurning, st.IsBreached, st.InsufficientData, st.FiringHistory, st.CurrentSeverity, st.CurrentCompliance, st.CurrentBurnRate, st.CurrentEvents, etaExhaustion, nullableInt32(st.ETALowerSec), nullableInt32(st.ETAUpperSec), st.BudgetRemaining, samplesJSON, lastTransition, nullableTime(st.BreachTransitionAt), lastContinued, st.LastEvaluatedAt)
 	return err
 }
+
+func nullableInt32(v sql.NullInt32) any {
+	if !v.Valid {
+		return nil
+	}
+	return v.Int32
+}
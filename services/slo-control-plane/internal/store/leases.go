@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// AcquireLease takes or renews the named lease for holderID. It returns false while another
+// holder's lease is still unexpired.
+func (s *Store) AcquireLease(ctx context.Context, name, holderID string, ttl time.Duration) (bool, error) {
+	ctx, span := s.startSpan(ctx, "store.acquire_lease", attribute.String("lease.name", name), attribute.String("lease.holder", holderID))
+	defer span.End()
+	var holder string
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO evaluator_leases (name, holder_id, acquired_at, renewed_at, expires_at)
+		VALUES ($1, $2, now(), now(), now() + make_interval(secs => $3))
+		ON CONFLICT (name) DO UPDATE SET
+			holder_id = EXCLUDED.holder_id,
+			acquired_at = CASE WHEN evaluator_leases.holder_id = EXCLUDED.holder_id THEN evaluator_leases.acquired_at ELSE now() END,
+			renewed_at = now(),
+			expires_at = EXCLUDED.expires_at
+		WHERE evaluator_leases.holder_id = EXCLUDED.holder_id OR evaluator_leases.expires_at <= now()
+		RETURNING holder_id
+	`, name, holderID, ttl.Seconds()).Scan(&holder)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return holder == holderID, nil
+}
+
+func (s *Store) ReleaseLease(ctx context.Context, name, holderID string) error {
+	ctx, span := s.startSpan(ctx, "store.release_lease", attribute.String("lease.name", name), attribute.String("lease.holder", holderID))
+	defer span.End()
+	_, err := s.db.ExecContext(ctx, `DELETE FROM evaluator_leases WHERE name = $1 AND holder_id = $2`, name, holderID)
+	return err
+}
+
+// HeartbeatMember registers memberID as a live evaluator replica until ttl from now and returns
+// every member whose heartbeat has not expired, sorted by ID.
+func (s *Store) HeartbeatMember(ctx context.Context, memberID string, ttl time.Duration) ([]string, error) {
+	ctx, span := s.startSpan(ctx, "store.heartbeat_member", attribute.String("member.id", memberID))
+	defer span.End()
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO evaluator_members (member_id, joined_at, heartbeat_at, expires_at)
+		VALUES ($1, now(), now(), now() + make_interval(secs => $2))
+		ON CONFLICT (member_id) DO UPDATE SET
+			heartbeat_at = now(),
+			expires_at = EXCLUDED.expires_at
+	`, memberID, ttl.Seconds()); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT member_id
+		FROM evaluator_members
+		WHERE expires_at > now()
+		ORDER BY member_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var members []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		members = append(members, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("member.count", len(members)))
+	return members, nil
+}
+
+func (s *Store) RemoveMember(ctx context.Context, memberID string) error {
+	ctx, span := s.startSpan(ctx, "store.remove_member", attribute.String("member.id", memberID))
+	defer span.End()
+	_, err := s.db.ExecContext(ctx, `DELETE FROM evaluator_members WHERE member_id = $1`, memberID)
+	return err
+}
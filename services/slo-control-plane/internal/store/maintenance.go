@@ -0,0 +1,203 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/maintenance"
+)
+
+// MaintenanceWindow is planned downtime for every SLO of a team or service, or for one SLO.
+type MaintenanceWindow struct {
+	ID                uuid.UUID
+	Name              string
+	Description       string
+	ScopeType         string
+	ScopeID           uuid.UUID
+	Schedule          maintenance.Schedule
+	ExcludeFromBudget bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// Covers reports whether the window is scoped to the SLO, its service or the service's team.
+func (w MaintenanceWindow) Covers(sloID, serviceID, teamID uuid.UUID) bool {
+	switch w.ScopeType {
+	case maintenance.ScopeSLO:
+		return w.ScopeID == sloID
+	case maintenance.ScopeService:
+		return w.ScopeID == serviceID
+	case maintenance.ScopeTeam:
+		return w.ScopeID == teamID
+	}
+	return false
+}
+
+const maintenanceWindowColumns = `id, name, description, scope_type, scope_id, starts_at, ends_at, recurrence,
+	recurrence_interval, recurrence_until, time_zone, exclude_from_budget, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMaintenanceWindow(row rowScanner) (MaintenanceWindow, error) {
+	var w MaintenanceWindow
+	var desc sql.NullString
+	var until sql.NullTime
+	err := row.Scan(
+		&w.ID, &w.Name, &desc, &w.ScopeType, &w.ScopeID, &w.Schedule.StartsAt, &w.Schedule.EndsAt, &w.Schedule.Recurrence,
+		&w.Schedule.Interval, &until, &w.Schedule.TimeZone, &w.ExcludeFromBudget, &w.CreatedAt, &w.UpdatedAt,
+	)
+	w.Description = nullStringToString(desc)
+	if until.Valid {
+		w.Schedule.Until = until.Time
+	}
+	return w, err
+}
+
+func (s *Store) ListMaintenanceWindows(ctx context.Context, page, pageSize int, scopeType string, scopeID *uuid.UUID) ([]MaintenanceWindow, Pagination, error) {
+	ctx, span := s.startSpan(ctx, "store.list_maintenance_windows")
+	defer span.End()
+	where := ""
+	countWhere := ""
+	args := []any{}
+	if scopeType != "" && scopeID != nil {
+		where = "WHERE scope_type = $3 AND scope_id = $4"
+		countWhere = " WHERE scope_type = $1 AND scope_id = $2"
+		args = append(args, scopeType, *scopeID)
+	}
+	listSQL := fmt.Sprintf(`
+		SELECT %s
+		FROM maintenance_windows
+		%s
+		ORDER BY starts_at DESC
+		LIMIT $1 OFFSET $2
+	`, maintenanceWindowColumns, where)
+	rows, total, err := paginatedQueryWithArgs(
+		s.db,
+		ctx,
+		listSQL,
+		"SELECT count(*) FROM maintenance_windows"+countWhere,
+		page,
+		pageSize,
+		args,
+		func(rows *sql.Rows) (MaintenanceWindow, error) {
+			return scanMaintenanceWindow(rows)
+		},
+	)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+	return rows, Pagination{Page: page, PageSize: pageSize, Total: total}, nil
+}
+
+func (s *Store) CreateMaintenanceWindow(ctx context.Context, w MaintenanceWindow) (MaintenanceWindow, error) {
+	ctx, span := s.startSpan(ctx, "store.create_maintenance_window", attribute.String("maintenance.id", w.ID.String()))
+	defer span.End()
+	return scanMaintenanceWindow(s.db.QueryRowContext(ctx, `
+		INSERT INTO maintenance_windows (
+			id, name, description, scope_type, scope_id, starts_at, ends_at, recurrence,
+			recurrence_interval, recurrence_until, time_zone, exclude_from_budget
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+		RETURNING `+maintenanceWindowColumns,
+		w.ID, w.Name, nullableStr(w.Description), w.ScopeType, w.ScopeID, w.Schedule.StartsAt, w.Schedule.EndsAt, w.Schedule.Recurrence,
+		w.Schedule.Interval, nullableUntil(w.Schedule.Until), w.Schedule.TimeZone, w.ExcludeFromBudget,
+	))
+}
+
+func (s *Store) GetMaintenanceWindow(ctx context.Context, id uuid.UUID) (MaintenanceWindow, error) {
+	ctx, span := s.startSpan(ctx, "store.get_maintenance_window", attribute.String("maintenance.id", id.String()))
+	defer span.End()
+	return scanMaintenanceWindow(s.db.QueryRowContext(ctx, `
+		SELECT `+maintenanceWindowColumns+`
+		FROM maintenance_windows WHERE id = $1
+	`, id))
+}
+
+func (s *Store) UpdateMaintenanceWindow(ctx context.Context, w MaintenanceWindow) (MaintenanceWindow, error) {
+	ctx, span := s.startSpan(ctx, "store.update_maintenance_window", attribute.String("maintenance.id", w.ID.String()))
+	defer span.End()
+	return scanMaintenanceWindow(s.db.QueryRowContext(ctx, `
+		UPDATE maintenance_windows
+		SET name = $2, description = $3, scope_type = $4, scope_id = $5, starts_at = $6, ends_at = $7,
+		    recurrence = $8, recurrence_interval = $9, recurrence_until = $10, time_zone = $11,
+		    exclude_from_budget = $12, updated_at = now()
+		WHERE id = $1
+		RETURNING `+maintenanceWindowColumns,
+		w.ID, w.Name, nullableStr(w.Description), w.ScopeType, w.ScopeID, w.Schedule.StartsAt, w.Schedule.EndsAt, w.Schedule.Recurrence,
+		w.Schedule.Interval, nullableUntil(w.Schedule.Until), w.Schedule.TimeZone, w.ExcludeFromBudget,
+	))
+}
+
+func (s *Store) DeleteMaintenanceWindow(ctx context.Context, id uuid.UUID) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM maintenance_windows WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ListMaintenanceWindowsForSLO returns the windows scoped to the SLO, its service or the service's
+// owning team, past ones included.
+func (s *Store) ListMaintenanceWindowsForSLO(ctx context.Context, sloID uuid.UUID) ([]MaintenanceWindow, error) {
+	ctx, span := s.startSpan(ctx, "store.list_maintenance_windows_for_slo", attribute.String("slo.id", sloID.String()))
+	defer span.End()
+	return s.queryMaintenanceWindows(ctx, `
+		SELECT `+prefixedMaintenanceWindowColumns+`
+		FROM maintenance_windows m
+		INNER JOIN slos s ON s.id = $1
+		INNER JOIN services sv ON sv.id = s.service_id
+		WHERE (m.scope_type = 'slo' AND m.scope_id = s.id)
+		   OR (m.scope_type = 'service' AND m.scope_id = sv.id)
+		   OR (m.scope_type = 'team' AND m.scope_id = sv.owner_team_id)
+		ORDER BY m.starts_at ASC
+	`, sloID)
+}
+
+// ListCurrentMaintenanceWindows returns the windows that have an occurrence at or after now.
+func (s *Store) ListCurrentMaintenanceWindows(ctx context.Context, now time.Time) ([]MaintenanceWindow, error) {
+	ctx, span := s.startSpan(ctx, "store.list_current_maintenance_windows")
+	defer span.End()
+	return s.queryMaintenanceWindows(ctx, `
+		SELECT `+maintenanceWindowColumns+`
+		FROM maintenance_windows
+		WHERE ends_at > $1
+		   OR (recurrence <> '' AND (recurrence_until IS NULL OR recurrence_until + (ends_at - starts_at) > $1))
+		ORDER BY starts_at ASC
+	`, now)
+}
+
+func (s *Store) queryMaintenanceWindows(ctx context.Context, query string, args ...any) ([]MaintenanceWindow, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []MaintenanceWindow
+	for rows.Next() {
+		w, err := scanMaintenanceWindow(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, w)
+	}
+	return out, rows.Err()
+}
+
+const prefixedMaintenanceWindowColumns = `m.id, m.name, m.description, m.scope_type, m.scope_id, m.starts_at, m.ends_at,
+	m.recurrence, m.recurrence_interval, m.recurrence_until, m.time_zone, m.exclude_from_budget, m.created_at, m.updated_at`
+
+func nullableUntil(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
@@ -16,12 +16,16 @@ type OutboxEvent struct {
 	AggregateID    uuid.UUID
 	EventType      string
 	Payload        map[string]any
+	PayloadJSON    json.RawMessage
 	RetryCount     int
 	IdempotencyKey string
 }
 
-func (s *Store) ClaimPendingOutbox(ctx context.Context, batchSize int) ([]OutboxEvent, error) {
-	ctx, span := s.startSpan(ctx, "store.claim_pending_outbox", attribute.Int("outbox.batch_size", batchSize))
+// ClaimPendingOutbox leases up to batchSize due events to workerID until now + lease. Claims whose
+// lease expired, left behind by a worker that crashed or stalled, are released first; each release
+// counts as a failed delivery attempt, and an event that is out of retries is dead-lettered instead.
+func (s *Store) ClaimPendingOutbox(ctx context.Context, workerID string, batchSize int, lease time.Duration, maxRetries int) ([]OutboxEvent, error) {
+	ctx, span := s.startSpan(ctx, "store.claim_pending_outbox", attribute.String("outbox.worker_id", workerID), attribute.Int("outbox.batch_size", batchSize))
 	defer span.End()
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -29,6 +33,12 @@ func (s *Store) ClaimPendingOutbox(ctx context.Context, batchSize int) ([]Outbox
 	}
 	defer tx.Rollback()
 
+	reclaimed, err := releaseExpiredOutboxClaims(ctx, tx, maxRetries)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("outbox.reclaimed_count", reclaimed))
+
 	rows, err := tx.QueryContext(ctx, `
 		WITH claimed AS (
 			SELECT id
@@ -39,11 +49,12 @@ func (s *Store) ClaimPendingOutbox(ctx context.Context, batchSize int) ([]Outbox
 			FOR UPDATE SKIP LOCKED
 		)
 		UPDATE outbox_events o
-		SET status = 'processing', updated_at = now()
+		SET status = 'processing', claimed_by = $2, claimed_at = now(), claim_expires_at = now() + make_interval(secs => $3),
+		    updated_at = now()
 		FROM claimed
 		WHERE o.id = claimed.id
 		RETURNING o.id, o.aggregate_type, o.aggregate_id, o.event_type, o.payload_json, o.retry_count, o.idempotency_key
-	`, batchSize)
+	`, batchSize, workerID, lease.Seconds())
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +69,7 @@ func (s *Store) ClaimPendingOutbox(ctx context.Context, batchSize int) ([]Outbox
 		}
 		ev.Payload = map[string]any{}
 		_ = json.Unmarshal(payload, &ev.Payload)
+		ev.PayloadJSON = payload
 		events = append(events, ev)
 	}
 	if err := rows.Err(); err != nil {
@@ -69,54 +81,166 @@ func (s *Store) ClaimPendingOutbox(ctx context.Context, batchSize int) ([]Outbox
 	return events, nil
 }
 
-func (s *Store) MarkOutboxDelivered(ctx context.Context, id uuid.UUID) error {
+// expiredClaim is a claimed event whose lease ran out before its worker confirmed delivery.
+type expiredClaim struct {
+	id         uuid.UUID
+	retryCount int
+	claimedBy  string
+}
+
+// release counts the expired lease as a failed delivery attempt. It returns the event's new retry
+// count, the status it goes back to and the error recorded for the attempt; like a failed delivery,
+// an attempt past maxRetries dead-letters the event.
+func (c expiredClaim) release(maxRetries int) (int, string, string) {
+	worker := c.claimedBy
+	if worker == "" {
+		worker = "unknown worker"
+	}
+	errText := "claim by " + worker + " expired before delivery was confirmed"
+	attempt := c.retryCount + 1
+	if attempt > maxRetries {
+		return attempt, "dead_letter", errText
+	}
+	return attempt, "pending", errText
+}
+
+func releaseExpiredOutboxClaims(ctx context.Context, tx *sql.Tx, maxRetries int) (int, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, retry_count, claimed_by
+		FROM outbox_events
+		WHERE status = 'processing' AND claim_expires_at <= now()
+		FOR UPDATE SKIP LOCKED
+	`)
+	if err != nil {
+		return 0, err
+	}
+	var expired []expiredClaim
+	for rows.Next() {
+		var c expiredClaim
+		var claimedBy sql.NullString
+		if err := rows.Scan(&c.id, &c.retryCount, &claimedBy); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		c.claimedBy = nullStringToString(claimedBy)
+		expired = append(expired, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	for _, c := range expired {
+		retryCount, status, errText := c.release(maxRetries)
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE outbox_events
+			SET retry_count = $2, status = $3, dead_lettered_at = CASE WHEN $3 = 'dead_letter' THEN now() END,
+			    last_error = $4, next_attempt_at = now(), claimed_by = NULL, claimed_at = NULL, claim_expires_at = NULL,
+			    updated_at = now()
+			WHERE id = $1
+		`, c.id, retryCount, status, errText); err != nil {
+			return 0, err
+		}
+		if err := insertDeliveryAttempt(ctx, tx, c.id, errText); err != nil {
+			return 0, err
+		}
+	}
+	return len(expired), nil
+}
+
+// MarkOutboxDelivered records a confirmed delivery. Like MarkOutboxRetry it requires workerID to
+// hold the claim and returns sql.ErrNoRows otherwise.
+func (s *Store) MarkOutboxDelivered(ctx context.Context, id uuid.UUID, workerID string) error {
 	ctx, span := s.startSpan(ctx, "store.mark_outbox_delivered", attribute.String("outbox.id", id.String()))
 	defer span.End()
-	_, err := s.db.ExecContext(ctx, `
+	res, err := s.db.ExecContext(ctx, `
 		UPDATE outbox_events
-		SET status = 'delivered', sent_at = now(), updated_at = now()
-		WHERE id = $1
-	`, id)
-	return err
+		SET status = 'delivered', sent_at = now(), claimed_by = NULL, claimed_at = NULL, claim_expires_at = NULL, updated_at = now()
+		WHERE id = $1 AND status = 'processing' AND claimed_by = $2
+	`, id, workerID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
 }
 
-func (s *Store) MarkOutboxRetry(ctx context.Context, id uuid.UUID, retryCount int, errMsg string) error {
+// MarkOutboxRetry records a failed delivery attempt and schedules the next one. It returns
+// sql.ErrNoRows when workerID no longer holds the claim.
+func (s *Store) MarkOutboxRetry(ctx context.Context, id uuid.UUID, workerID string, retryCount int, errMsg string, nextAttempt time.Time) error {
 	ctx, span := s.startSpan(ctx, "store.mark_outbox_retry", attribute.String("outbox.id", id.String()), attribute.Int("outbox.retry_count", retryCount))
 	defer span.End()
+	return s.recordOutboxFailure(ctx, id, errMsg, `
+		UPDATE outbox_events
+		SET status = 'pending', retry_count = $3, next_attempt_at = $4, last_error = $5,
+		    claimed_by = NULL, claimed_at = NULL, claim_expires_at = NULL, updated_at = now()
+		WHERE id = $1 AND status = 'processing' AND claimed_by = $2
+	`, id, workerID, retryCount, nextAttempt, errMsg)
+}
+
+// MarkOutboxDeadLetter records the last failed delivery attempt and parks the event until it is
+// requeued or purged. Like MarkOutboxRetry it requires workerID to hold the claim.
+func (s *Store) MarkOutboxDeadLetter(ctx context.Context, id uuid.UUID, workerID string, retryCount int, errMsg string) error {
+	ctx, span := s.startSpan(ctx, "store.mark_outbox_dead_letter", attribute.String("outbox.id", id.String()), attribute.Int("outbox.retry_count", retryCount))
+	defer span.End()
+	return s.recordOutboxFailure(ctx, id, errMsg, `
+		UPDATE outbox_events
+		SET status = 'dead_letter', retry_count = $3, last_error = $4, dead_lettered_at = now(),
+		    claimed_by = NULL, claimed_at = NULL, claim_expires_at = NULL, updated_at = now()
+		WHERE id = $1 AND status = 'processing' AND claimed_by = $2
+	`, id, workerID, retryCount, errMsg)
+}
+
+func (s *Store) recordOutboxFailure(ctx context.Context, id uuid.UUID, errMsg, update string, args ...any) error {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	nextAttempt := time.Now().Add(time.Duration(1<<retryCount) * time.Second)
-	if _, err := tx.ExecContext(ctx, `
-		UPDATE outbox_events
-		SET status = 'pending', retry_count = $2, next_attempt_at = $3, updated_at = now()
-		WHERE id = $1
-	`, id, retryCount, nextAttempt); err != nil {
+	res, err := tx.ExecContext(ctx, update, args...)
+	if err != nil {
 		return err
 	}
-
-	if _, err := tx.ExecContext(ctx, `
-		INSERT INTO burn_event_delivery_attempts (id, outbox_event_id, attempt_no, error_text, attempted_at)
-		VALUES ($1, $2, $3, $4, now())
-	`, uuid.New(), id, retryCount, errMsg); err != nil {
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	if err := insertDeliveryAttempt(ctx, tx, id, errMsg); err != nil {
 		return err
 	}
-
 	return tx.Commit()
 }
 
+// insertDeliveryAttempt numbers attempts after the event's previous ones rather than by retry count,
+// which a requeue resets.
+func insertDeliveryAttempt(ctx context.Context, tx *sql.Tx, id uuid.UUID, errMsg string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO burn_event_delivery_attempts (id, outbox_event_id, attempt_no, error_text, attempted_at)
+		SELECT $1, $2, coalesce(max(attempt_no), 0) + 1, $3, now()
+		FROM burn_event_delivery_attempts
+		WHERE outbox_event_id = $2
+	`, uuid.New(), id, errMsg)
+	return err
+}
+
 func (s *Store) InsertBurnEventView(ctx context.Context, ev BurnEvent) error {
 	ctx, span := s.startSpan(ctx, "store.insert_burn_event_view", attribute.String("slo.id", ev.SLOID.String()), attribute.String("event.type", ev.EventType))
 	defer span.End()
-	_, err := s.db.ExecContext(ctx, `
+	dimensions := ev.Dimensions
+	if dimensions == nil {
+		dimensions = map[string]string{}
+	}
+	dimensionsJSON, err := json.Marshal(dimensions)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
 		INSERT INTO burn_events_view (
-			id, service_id, slo_id, event_type, value, threshold, observed_at, source, idempotency_key
-		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+			id, service_id, slo_id, event_type, value, threshold, observed_at, source, idempotency_key, objective, dimension_key, dimensions
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
 		ON CONFLICT (idempotency_key) DO NOTHING
-	`, ev.ID, ev.ServiceID, ev.SLOID, ev.EventType, ev.Value, ev.Threshold, ev.ObservedAt, ev.Source, ev.IdempotencyKey)
+	`, ev.ID, ev.ServiceID, ev.SLOID, ev.EventType, ev.Value, ev.Threshold, ev.ObservedAt, ev.Source, ev.IdempotencyKey, ev.Objective, ev.DimensionKey, dimensionsJSON)
 	return err
 }
 
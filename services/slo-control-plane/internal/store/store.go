@@ -73,6 +73,9 @@ type BurnEvent struct {
 	ObservedAt     time.Time
 	Source         string
 	IdempotencyKey string
+	Objective      string
+	DimensionKey   string
+	Dimensions     map[string]string
 }
 
 type Pagination struct {
@@ -380,7 +383,8 @@ func (s *Store) ListBurnEvents(ctx context.Context, page, pageSize int, serviceI
 	}
 	where := "WHERE " + joinWithAnd(conds)
 	listSQL := fmt.Sprintf(`
-		SELECT id, service_id, slo_id, event_type, value, threshold, observed_at, source, idempotency_key
+		SELECT id, service_id, slo_id, event_type, value, threshold, observed_at, source, idempotency_key,
+		       objective, dimension_key, dimensions
 		FROM burn_events_view
 		%s
 		ORDER BY observed_at DESC
@@ -389,8 +393,16 @@ func (s *Store) ListBurnEvents(ctx context.Context, page, pageSize int, serviceI
 	countSQL := fmt.Sprintf(`SELECT count(*) FROM burn_events_view %s`, where)
 	rows, total, err := paginatedQueryWithArgs(s.db, ctx, listSQL, countSQL, page, pageSize, args, func(rows *sql.Rows) (BurnEvent, error) {
 		var ev BurnEvent
-		err := rows.Scan(&ev.ID, &ev.ServiceID, &ev.SLOID, &ev.EventType, &ev.Value, &ev.Threshold, &ev.ObservedAt, &ev.Source, &ev.IdempotencyKey)
-		return ev, err
+		var dimensions []byte
+		if err := rows.Scan(&ev.ID, &ev.ServiceID, &ev.SLOID, &ev.EventType, &ev.Value, &ev.Threshold, &ev.ObservedAt, &ev.Source, &ev.IdempotencyKey, &ev.Objective, &ev.DimensionKey, &dimensions); err != nil {
+			return ev, err
+		}
+		if len(dimensions) > 0 {
+			if err := json.Unmarshal(dimensions, &ev.Dimensions); err != nil {
+				return ev, err
+			}
+		}
+		return ev, nil
 	})
 	if err != nil {
 		return nil, Pagination{}, err
@@ -13,3 +13,25 @@ func TestNullStringToString(t *testing.T) {
 		t.Fatalf("expected desc, got %q", got)
 	}
 }
+
+func TestExpiredClaimReleaseCountsAsAttempt(t *testing.T) {
+	cases := []struct {
+		name       string
+		claim      expiredClaim
+		maxRetries int
+		retryCount int
+		status     string
+		errText    string
+	}{
+		{"first expiry", expiredClaim{retryCount: 0, claimedBy: "w1"}, 3, 1, "pending", "claim by w1 expired before delivery was confirmed"},
+		{"last retry", expiredClaim{retryCount: 2, claimedBy: "w1"}, 3, 3, "pending", "claim by w1 expired before delivery was confirmed"},
+		{"past max retries", expiredClaim{retryCount: 3, claimedBy: "w1"}, 3, 4, "dead_letter", "claim by w1 expired before delivery was confirmed"},
+		{"no retries", expiredClaim{}, 0, 1, "dead_letter", "claim by unknown worker expired before delivery was confirmed"},
+	}
+	for _, tc := range cases {
+		retryCount, status, errText := tc.claim.release(tc.maxRetries)
+		if retryCount != tc.retryCount || status != tc.status || errText != tc.errText {
+			t.Fatalf("%s: got (%d, %q, %q), want (%d, %q, %q)", tc.name, retryCount, status, errText, tc.retryCount, tc.status, tc.errText)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// WebhookAggregateType marks outbox events that deliver one burn event to one webhook subscription.
+const WebhookAggregateType = "webhook"
+
+// WebhookSubscription pushes the SLO events of a team's or a service's SLOs to an HTTP endpoint.
+type WebhookSubscription struct {
+	ID        uuid.UUID
+	Name      string
+	ScopeType string
+	ScopeID   uuid.UUID
+	URL       string
+	Secret    string
+	// EventTypes limits the pushed events; empty pushes all of them.
+	EventTypes []string
+	// ContentMode is the CloudEvents content mode of the requests, "structured" or "binary".
+	ContentMode string
+	Timeout     time.Duration
+	Enabled     bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w WebhookSubscription) Wants(eventType string) bool {
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+const webhookSubscriptionColumns = `id, name, scope_type, scope_id, url, secret, event_types, content_mode, timeout_ms, enabled, created_at, updated_at`
+
+func scanWebhookSubscription(row rowScanner) (WebhookSubscription, error) {
+	var w WebhookSubscription
+	var eventTypes []byte
+	var timeoutMS int64
+	if err := row.Scan(&w.ID, &w.Name, &w.ScopeType, &w.ScopeID, &w.URL, &w.Secret, &eventTypes, &w.ContentMode, &timeoutMS, &w.Enabled, &w.CreatedAt, &w.UpdatedAt); err != nil {
+		return WebhookSubscription{}, err
+	}
+	_ = json.Unmarshal(eventTypes, &w.EventTypes)
+	w.Timeout = time.Duration(timeoutMS) * time.Millisecond
+	return w, nil
+}
+
+func eventTypesJSON(types []string) string {
+	if types == nil {
+		types = []string{}
+	}
+	blob, _ := json.Marshal(types)
+	return string(blob)
+}
+
+func (s *Store) ListWebhookSubscriptions(ctx context.Context, page, pageSize int, scopeType string, scopeID *uuid.UUID) ([]WebhookSubscription, Pagination, error) {
+	ctx, span := s.startSpan(ctx, "store.list_webhook_subscriptions")
+	defer span.End()
+	where := ""
+	countWhere := ""
+	args := []any{}
+	if scopeType != "" && scopeID != nil {
+		where = "WHERE scope_type = $3 AND scope_id = $4"
+		countWhere = " WHERE scope_type = $1 AND scope_id = $2"
+		args = append(args, scopeType, *scopeID)
+	}
+	listSQL := fmt.Sprintf(`
+		SELECT %s
+		FROM webhook_subscriptions
+		%s
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`, webhookSubscriptionColumns, where)
+	rows, total, err := paginatedQueryWithArgs(
+		s.db,
+		ctx,
+		listSQL,
+		"SELECT count(*) FROM webhook_subscriptions"+countWhere,
+		page,
+		pageSize,
+		args,
+		func(rows *sql.Rows) (WebhookSubscription, error) {
+			return scanWebhookSubscription(rows)
+		},
+	)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+	return rows, Pagination{Page: page, PageSize: pageSize, Total: total}, nil
+}
+
+func (s *Store) CreateWebhookSubscription(ctx context.Context, w WebhookSubscription) (WebhookSubscription, error) {
+	ctx, span := s.startSpan(ctx, "store.create_webhook_subscription", attribute.String("webhook.id", w.ID.String()))
+	defer span.End()
+	return scanWebhookSubscription(s.db.QueryRowContext(ctx, `
+		INSERT INTO webhook_subscriptions (id, name, scope_type, scope_id, url, secret, event_types, content_mode, timeout_ms, enabled)
+		VALUES ($1,$2,$3,$4,$5,$6,$7::jsonb,$8,$9,$10)
+		RETURNING `+webhookSubscriptionColumns,
+		w.ID, w.Name, w.ScopeType, w.ScopeID, w.URL, w.Secret, eventTypesJSON(w.EventTypes), w.ContentMode, w.Timeout.Milliseconds(), w.Enabled,
+	))
+}
+
+func (s *Store) GetWebhookSubscription(ctx context.Context, id uuid.UUID) (WebhookSubscription, error) {
+	ctx, span := s.startSpan(ctx, "store.get_webhook_subscription", attribute.String("webhook.id", id.String()))
+	defer span.End()
+	return scanWebhookSubscription(s.db.QueryRowContext(ctx, `
+		SELECT `+webhookSubscriptionColumns+`
+		FROM webhook_subscriptions WHERE id = $1
+	`, id))
+}
+
+func (s *Store) UpdateWebhookSubscription(ctx context.Context, w WebhookSubscription) (WebhookSubscription, error) {
+	ctx, span := s.startSpan(ctx, "store.update_webhook_subscription", attribute.String("webhook.id", w.ID.String()))
+	defer span.End()
+	return scanWebhookSubscription(s.db.QueryRowContext(ctx, `
+		UPDATE webhook_subscriptions
+		SET name = $2, scope_type = $3, scope_id = $4, url = $5, secret = $6, event_types = $7::jsonb,
+		    content_mode = $8, timeout_ms = $9, enabled = $10, updated_at = now()
+		WHERE id = $1
+		RETURNING `+webhookSubscriptionColumns,
+		w.ID, w.Name, w.ScopeType, w.ScopeID, w.URL, w.Secret, eventTypesJSON(w.EventTypes), w.ContentMode, w.Timeout.Milliseconds(), w.Enabled,
+	))
+}
+
+func (s *Store) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ListWebhookSubscriptionsForService returns the enabled subscriptions on the service or its
+// owning team.
+func (s *Store) ListWebhookSubscriptionsForService(ctx context.Context, serviceID uuid.UUID) ([]WebhookSubscription, error) {
+	ctx, span := s.startSpan(ctx, "store.list_webhook_subscriptions_for_service", attribute.String("service.id", serviceID.String()))
+	defer span.End()
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT w.id, w.name, w.scope_type, w.scope_id, w.url, w.secret, w.event_types, w.content_mode, w.timeout_ms, w.enabled, w.created_at, w.updated_at
+		FROM webhook_subscriptions w
+		INNER JOIN services sv ON sv.id = $1
+		WHERE w.enabled
+		  AND ((w.scope_type = 'service' AND w.scope_id = sv.id) OR (w.scope_type = 'team' AND w.scope_id = sv.owner_team_id))
+		ORDER BY w.created_at ASC
+	`, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []WebhookSubscription
+	for rows.Next() {
+		w, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, w)
+	}
+	return out, rows.Err()
+}
+
+// EnqueueWebhookDeliveries queues one outbox event per subscription for a delivered burn event, so
+// each endpoint is retried and dead-lettered on its own. Queuing the same burn event again is a
+// no-op.
+func (s *Store) EnqueueWebhookDeliveries(ctx context.Context, ev OutboxEvent, payload any, subs []WebhookSubscription) error {
+	ctx, span := s.startSpan(ctx, "store.enqueue_webhook_deliveries", attribute.String("outbox.id", ev.ID.String()), attribute.Int("webhook.count", len(subs)))
+	defer span.End()
+	if len(subs) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, sub := range subs {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, payload_json, status, retry_count, next_attempt_at, idempotency_key)
+			VALUES ($1, $2, $3, $4, $5::jsonb, 'pending', 0, now(), $6)
+			ON CONFLICT (idempotency_key) DO NOTHING
+		`, uuid.New(), WebhookAggregateType, sub.ID, ev.EventType, string(body), ev.IdempotencyKey+":webhook:"+sub.ID.String()); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
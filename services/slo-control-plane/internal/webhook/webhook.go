@@ -0,0 +1,116 @@
+// Package webhook pushes SLO events to subscribed HTTP endpoints as signed CloudEvents.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/events"
+)
+
+// SchemaVersion is the version of the request layout. Version 2 sends CloudEvents; fields are only
+// added within a version.
+const SchemaVersion = "2"
+
+const (
+	ContentModeStructured = "structured"
+	ContentModeBinary     = "binary"
+)
+
+const (
+	HeaderSchemaVersion = "X-SLO-Webhook-Version"
+	HeaderEventID       = "X-SLO-Event-Id"
+	HeaderEventType     = "X-SLO-Event-Type"
+	HeaderSubscription  = "X-SLO-Subscription-Id"
+	HeaderTimestamp     = "X-SLO-Timestamp"
+	// HeaderSignature carries "sha256=" and the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with
+	// the subscription secret.
+	HeaderSignature = "X-SLO-Signature"
+)
+
+type Endpoint struct {
+	URL            string
+	Secret         string
+	Timeout        time.Duration
+	ContentMode    string
+	SubscriptionID uuid.UUID
+}
+
+// clientTimeout bounds a delivery whose context has no deadline of its own; subscription timeouts
+// are at most 30s.
+const clientTimeout = 30 * time.Second
+
+type Client struct {
+	http *http.Client
+}
+
+func NewClient() *Client {
+	return &Client{http: &http.Client{Timeout: clientTimeout}}
+}
+
+// Sign returns the HeaderSignature value for body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send POSTs the event in the endpoint's content mode, structured unless it asks for binary, and
+// fails unless the endpoint answers 2xx within its timeout. The event ID stays the same across
+// retries, so receivers can deduplicate on it.
+func (c *Client) Send(ctx context.Context, ep Endpoint, e events.Event, now time.Time) error {
+	header := http.Header{}
+	var body []byte
+	var err error
+	if ep.ContentMode == ContentModeBinary {
+		header, body, err = e.Binary()
+	} else {
+		body, err = json.Marshal(e)
+		header.Set("Content-Type", events.StructuredContentType)
+	}
+	if err != nil {
+		return err
+	}
+	if ep.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, ep.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	ts := now.Unix()
+	req.Header = header
+	req.Header.Set("User-Agent", "slo-control-plane-webhook/"+SchemaVersion)
+	req.Header.Set(HeaderSchemaVersion, SchemaVersion)
+	req.Header.Set(HeaderEventID, e.ID.String())
+	req.Header.Set(HeaderEventType, e.Data.EventType)
+	req.Header.Set(HeaderSubscription, ep.SubscriptionID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, Sign(ep.Secret, ts, body))
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook %s returned %d: %s", ep.URL, resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
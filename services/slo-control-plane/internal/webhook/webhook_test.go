@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jsimonovski/heatmap-panel/services/slo-control-plane/internal/events"
+)
+
+func TestSendSignsPayload(t *testing.T) {
+	const secret = "0123456789abcdef"
+	now := time.Unix(1700000000, 0).UTC()
+	var got events.Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		if ts != now.Unix() || r.Header.Get(HeaderSignature) != Sign(secret, ts, body) {
+			t.Errorf("bad signature headers: %v", r.Header)
+		}
+		if r.Header.Get(HeaderSchemaVersion) != SchemaVersion || r.Header.Get(HeaderEventType) != "burn_started" ||
+			r.Header.Get("Content-Type") != events.StructuredContentType {
+			t.Errorf("bad event headers: %v", r.Header)
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("body is not a structured event: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	e := events.New(uuid.New(), events.Data{EventType: events.BurnStarted, SLOID: uuid.New(), EvaluatedAt: now})
+	if err := NewClient().Send(context.Background(), Endpoint{URL: srv.URL, Secret: secret, Timeout: time.Second}, e, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != e.ID || got.Data.SLOID != e.Data.SLOID {
+		t.Fatalf("payload mismatch: %+v", got)
+	}
+}
+
+func TestSendBinaryMode(t *testing.T) {
+	e := events.New(uuid.New(), events.Data{EventType: events.BurnResolved, SLOID: uuid.New(), EvaluatedAt: time.Now()})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data events.Data
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &data); err != nil || data.SLOID != e.Data.SLOID {
+			t.Errorf("body is not the event data: %s", body)
+		}
+		if r.Header.Get("ce-id") != e.ID.String() || r.Header.Get("ce-type") != e.Type || r.Header.Get("Content-Type") != events.DataContentType {
+			t.Errorf("bad binary headers: %v", r.Header)
+		}
+	}))
+	defer srv.Close()
+
+	if err := NewClient().Send(context.Background(), Endpoint{URL: srv.URL, Secret: "s", ContentMode: ContentModeBinary}, e, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendFailsOnErrorStatusAndTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		http.Error(w, "nope", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+	err := c.Send(context.Background(), Endpoint{URL: srv.URL, Secret: "s"}, events.Event{}, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("expected a 503 error, got %v", err)
+	}
+	err = c.Send(context.Background(), Endpoint{URL: srv.URL + "/slow", Secret: "s", Timeout: 20 * time.Millisecond}, events.Event{}, time.Now())
+	if err == nil {
+		t.Fatalf("expected the endpoint timeout to fail the request")
+	}
+}
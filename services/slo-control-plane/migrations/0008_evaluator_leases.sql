@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS evaluator_leases (
+  name TEXT PRIMARY KEY,
+  holder_id TEXT NOT NULL,
+  acquired_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  renewed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL
+);
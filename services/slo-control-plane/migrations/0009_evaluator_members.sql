@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS evaluator_members (
+  member_id TEXT PRIMARY KEY,
+  joined_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  heartbeat_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_evaluator_members_expires_at ON evaluator_members(expires_at);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS slo_evaluation_health (
+  slo_id UUID PRIMARY KEY REFERENCES slos(id) ON DELETE CASCADE,
+  status TEXT NOT NULL,
+  last_attempt_at TIMESTAMPTZ NOT NULL,
+  last_success_at TIMESTAMPTZ,
+  last_error_at TIMESTAMPTZ,
+  last_error TEXT,
+  last_error_stage TEXT,
+  consecutive_failures INTEGER NOT NULL DEFAULT 0,
+  last_duration_ms INTEGER NOT NULL DEFAULT 0,
+  last_query_duration_ms INTEGER NOT NULL DEFAULT 0,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_slo_evaluation_health_failing
+  ON slo_evaluation_health(consecutive_failures) WHERE consecutive_failures > 0;
@@ -0,0 +1,3 @@
+ALTER TABLE slo_burn_state
+ADD COLUMN IF NOT EXISTS insufficient_data BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN IF NOT EXISTS current_events DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE slo_burn_state
+ADD COLUMN IF NOT EXISTS firing_history INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+ALTER TABLE slo_burn_state
+ADD COLUMN IF NOT EXISTS budget_remaining REAL NOT NULL DEFAULT 1,
+ADD COLUMN IF NOT EXISTS eta_exhaustion_lower_seconds INTEGER,
+ADD COLUMN IF NOT EXISTS eta_exhaustion_upper_seconds INTEGER,
+ADD COLUMN IF NOT EXISTS budget_samples JSONB NOT NULL DEFAULT '[]'::jsonb;
@@ -0,0 +1,10 @@
+ALTER TABLE slo_burn_state
+ADD COLUMN IF NOT EXISTS dimension_key TEXT NOT NULL DEFAULT '',
+ADD COLUMN IF NOT EXISTS dimensions JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+ALTER TABLE slo_burn_state DROP CONSTRAINT IF EXISTS slo_burn_state_pkey;
+ALTER TABLE slo_burn_state ADD PRIMARY KEY (slo_id, dimension_key);
+
+ALTER TABLE burn_events_view
+ADD COLUMN IF NOT EXISTS dimension_key TEXT NOT NULL DEFAULT '',
+ADD COLUMN IF NOT EXISTS dimensions JSONB NOT NULL DEFAULT '{}'::jsonb;
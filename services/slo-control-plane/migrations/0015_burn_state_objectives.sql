@@ -0,0 +1,8 @@
+ALTER TABLE slo_burn_state
+ADD COLUMN IF NOT EXISTS objective TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE slo_burn_state DROP CONSTRAINT IF EXISTS slo_burn_state_pkey;
+ALTER TABLE slo_burn_state ADD PRIMARY KEY (slo_id, objective, dimension_key);
+
+ALTER TABLE burn_events_view
+ADD COLUMN IF NOT EXISTS objective TEXT NOT NULL DEFAULT '';
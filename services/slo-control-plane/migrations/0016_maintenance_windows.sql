@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS maintenance_windows (
+  id UUID PRIMARY KEY,
+  name TEXT NOT NULL,
+  description TEXT,
+  scope_type TEXT NOT NULL CHECK (scope_type IN ('team', 'service', 'slo')),
+  scope_id UUID NOT NULL,
+  starts_at TIMESTAMPTZ NOT NULL,
+  ends_at TIMESTAMPTZ NOT NULL,
+  recurrence TEXT NOT NULL DEFAULT '',
+  recurrence_interval INTEGER NOT NULL DEFAULT 0,
+  recurrence_until TIMESTAMPTZ,
+  time_zone TEXT NOT NULL DEFAULT '',
+  exclude_from_budget BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_maintenance_windows_scope ON maintenance_windows(scope_type, scope_id);
@@ -0,0 +1,11 @@
+ALTER TABLE outbox_events
+ADD COLUMN IF NOT EXISTS last_error TEXT;
+
+ALTER TABLE outbox_events
+ADD COLUMN IF NOT EXISTS dead_lettered_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_dead_letter
+  ON outbox_events(dead_lettered_at DESC) WHERE status = 'dead_letter';
+
+CREATE INDEX IF NOT EXISTS idx_burn_event_delivery_attempts_event
+  ON burn_event_delivery_attempts(outbox_event_id, attempted_at);
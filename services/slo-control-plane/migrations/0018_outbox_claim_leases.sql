@@ -0,0 +1,16 @@
+ALTER TABLE outbox_events
+ADD COLUMN IF NOT EXISTS claimed_by TEXT;
+
+ALTER TABLE outbox_events
+ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMPTZ;
+
+ALTER TABLE outbox_events
+ADD COLUMN IF NOT EXISTS claim_expires_at TIMESTAMPTZ;
+
+-- Rows claimed before leases existed never expire on their own; give them an expired lease.
+UPDATE outbox_events
+SET claim_expires_at = updated_at
+WHERE status = 'processing' AND claim_expires_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_claim_expiry
+  ON outbox_events(claim_expires_at) WHERE status = 'processing';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+  id UUID PRIMARY KEY,
+  name TEXT NOT NULL,
+  scope_type TEXT NOT NULL CHECK (scope_type IN ('team', 'service')),
+  scope_id UUID NOT NULL,
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  event_types JSONB NOT NULL DEFAULT '[]'::jsonb,
+  timeout_ms INTEGER NOT NULL DEFAULT 5000,
+  enabled BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_scope ON webhook_subscriptions(scope_type, scope_id);
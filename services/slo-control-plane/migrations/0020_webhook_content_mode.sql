@@ -0,0 +1,2 @@
+ALTER TABLE webhook_subscriptions
+ADD COLUMN IF NOT EXISTS content_mode TEXT NOT NULL DEFAULT 'structured' CHECK (content_mode IN ('structured', 'binary'));
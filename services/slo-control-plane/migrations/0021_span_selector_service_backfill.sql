@@ -0,0 +1,8 @@
+-- Trace SLIs stored before span filters always matched ServiceName = 'api-gateway'. Pin that in
+-- their runtime projection so the upgrade does not widen them to every service.
+UPDATE slos
+SET canonical_json = canonical_json || '{"serviceName": "api-gateway"}'::jsonb
+WHERE canonical_json ? 'route'
+  AND NOT canonical_json ? 'serviceName'
+  AND NOT canonical_json ? 'composite'
+  AND coalesce(canonical_json->>'datasourceType', '') IN ('', 'clickhouse');
@@ -0,0 +1,5 @@
+-- The old unbounded backoff scheduled some retries decades ahead; retry them now under the capped
+-- backoff so they can reach the dead-letter queue.
+UPDATE outbox_events
+SET next_attempt_at = now()
+WHERE status = 'pending' AND next_attempt_at > now() + interval '1 day';
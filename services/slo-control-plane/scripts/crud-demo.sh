@@ -126,6 +126,7 @@ spec:
           type: clickhouse
           spec:
             route: ${route}
+            serviceName: api-gateway
             type: ${type}
             threshold: ${threshold}
             datasourceUid: ${ds_uid}